@@ -0,0 +1,79 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/red-rocket-software/reminder-go/config"
+	"github.com/red-rocket-software/reminder-go/internal/reminder/domain"
+	"github.com/red-rocket-software/reminder-go/internal/reminder/storage"
+	"github.com/red-rocket-software/reminder-go/pkg/logging"
+	"github.com/red-rocket-software/reminder-go/pkg/postgresql"
+)
+
+const usage = `Usage:
+  admin erase -user <uid> [-now]   schedule erasure of all data of the user after grace period, -now erases immediately
+  admin cancel-erasure -user <uid>  cancel scheduled erasure of the user
+`
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Print(usage)
+		os.Exit(2)
+	}
+
+	command := flag.NewFlagSet(os.Args[1], flag.ExitOnError)
+	userID := command.String("user", "", "Firebase UID of the user")
+	now := command.Bool("now", false, "erase without grace period")
+	if err := command.Parse(os.Args[2:]); err != nil || *userID == "" {
+		fmt.Print(usage)
+		os.Exit(2)
+	}
+
+	cfg := config.GetConfig()
+	logger := logging.GetLogger()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	postgresClient, err := postgresql.NewClient(ctx, 5, *cfg)
+	if err != nil {
+		logger.Fatalf("Error create new db client:%v\n", err)
+	}
+	defer postgresClient.Close()
+
+	configsStorage := storage.NewConfigsStorage(postgresClient, &logger)
+
+	switch os.Args[1] {
+	case "erase":
+		if *now {
+			err = configsStorage.EraseUser(ctx, *userID)
+			if err == nil {
+				fmt.Printf("all data of user %s is erased\n", *userID)
+			}
+			break
+		}
+
+		var erasure domain.Erasure
+		erasure, err = configsStorage.RequestErasure(ctx, *userID, time.Now().AddDate(0, 0, cfg.Erasure.GraceDays))
+		if err == nil {
+			fmt.Printf("data of user %s will be erased at %s\n", *userID, erasure.EraseAt.Format(time.RFC3339))
+		}
+	case "cancel-erasure":
+		err = configsStorage.CancelErasure(ctx, *userID)
+		if err == nil {
+			fmt.Printf("erasure of user %s is cancelled\n", *userID)
+		}
+	default:
+		err = errors.New("unknown command " + os.Args[1])
+		fmt.Print(usage)
+	}
+
+	if err != nil {
+		logger.Fatalf("%s", err.Error())
+	}
+}
@@ -34,6 +34,9 @@ func main() {
 
 	todoStorage := storage.NewStorageTodo(postgresClient, &logger)
 	userConfigsStorage := storage.NewConfigsStorage(postgresClient, &logger)
+	tagsStorage := storage.NewTagsStorage(postgresClient, &logger)
+	projectsStorage := storage.NewProjectsStorage(postgresClient, &logger)
+	viewsStorage := storage.NewViewsStorage(postgresClient, &logger)
 
 	// creating firebase client
 	opt := option.WithCredentialsFile("serviceAccountKey.json")
@@ -43,7 +46,7 @@ func main() {
 		return
 	}
 
-	app := server.New(ctx, logger, todoStorage, userConfigsStorage, fireClient, *cfg)
+	app := server.New(ctx, logger, todoStorage, userConfigsStorage, tagsStorage, projectsStorage, viewsStorage, fireClient, *cfg)
 	logger.Debugf("Starting reminder server on port %s", cfg.HTTP.Port)
 
 	if err := app.Run(cfg); err != nil {
@@ -4,6 +4,7 @@ import (
 	"context"
 	"os"
 	"os/signal"
+	"syscall"
 	"time"
 
 	"github.com/red-rocket-software/reminder-go/config"
@@ -39,29 +40,46 @@ func main() {
 	}
 
 	remindStorage := todoStorage.NewStorageTodo(postgresClient, &logger)
+	configsStorage := todoStorage.NewConfigsStorage(postgresClient, &logger)
 
-	newWorker := notifier.NewWorker(ctx, remindStorage, fireClient, *cfg)
+	newWorker := notifier.NewWorker(ctx, &logger, remindStorage, configsStorage, fireClient, *cfg)
 
 	//run workers in scheduler
 	c := make(chan os.Signal, 1)
-	signal.Notify(c)
-	stop := make(chan error)
+	signal.Notify(c, os.Interrupt, syscall.SIGTERM)
+	stop := make(chan struct{})
 
-	ticker := time.NewTicker(time.Second * 10) // workers runs every 10 second
+	timer := time.NewTimer(0)                // notifications are sent when they are due, see notifier.Wakeup
+	purgeTicker := time.NewTicker(time.Hour) // trash is purged and due erasures are done every hour
 
 	go func() {
 		for {
 			select {
-			case <-ticker.C:
-				err = newWorker.ProcessSendNotification()
+			case <-timer.C:
+				// errors are logged and the worker goes on, e.g. when database is unavailable it tries again
+				// after max wait
+				err := newWorker.ProcessDueNotifications()
 				if err != nil {
 					logger.Errorf("error to process workers send notification: %v", err)
-					stop <- err
 				}
-				err = newWorker.ProcessSendDeadlineNotification()
+				err = newWorker.ProcessSendEscalations()
 				if err != nil {
-					logger.Errorf("error to process workers send deadline notification: %v", err)
-					stop <- err
+					logger.Errorf("error to process workers send escalations: %v", err)
+				}
+				// notifications and escalations are delivered from the outbox
+				next, err := newWorker.ProcessOutbox()
+				if err != nil {
+					logger.Errorf("error to process workers outbox: %v", err)
+				}
+				timer.Reset(notifier.Wakeup(time.Now(), next, cfg.Scheduler.MaxWait))
+			case <-purgeTicker.C:
+				err = newWorker.ProcessPurgeTrash()
+				if err != nil {
+					logger.Errorf("error to process workers purge trash: %v", err)
+				}
+				err = newWorker.ProcessErasures()
+				if err != nil {
+					logger.Errorf("error to process workers erasures: %v", err)
 				}
 			case <-stop:
 				logger.Info("closing goroutine")
@@ -71,8 +89,9 @@ func main() {
 
 	}()
 	<-c
-	defer ticker.Stop()
+	defer timer.Stop()
+	defer purgeTicker.Stop()
 
-	<-stop
+	close(stop)
 	logger.Info("Stop application")
 }
@@ -2,6 +2,7 @@ package config
 
 import (
 	"log"
+	"time"
 
 	"github.com/ilyakaznacheev/cleanenv"
 )
@@ -26,6 +27,99 @@ type Config struct {
 		SMTPAuthAddress     string `env-required:"true" yaml:"smtp_auth_address" env:"SMTP_AUTH_ADDRESS"`
 		SMTPServerAddress   string `env-required:"true" yaml:"smtp_server_address" env:"SMTP_SERVER_ADDRESS"`
 	} `yaml:"email"`
+	Trash struct {
+		// RetentionDays is how long deleted reminds are kept in trash before they are purged
+		RetentionDays int `env-default:"30" yaml:"retention_days" env:"TRASH_RETENTION_DAYS"`
+	} `yaml:"trash"`
+	Erasure struct {
+		// GraceDays is how long erasure of account can be cancelled before all data of the user is deleted
+		GraceDays int `env-default:"14" yaml:"grace_days" env:"ERASURE_GRACE_DAYS"`
+	} `yaml:"erasure"`
+	Escalation struct {
+		// Backoff is delay of overdue notifications: the first one is sent after the deadline, the next ones after
+		// the previous notification. The last delay is repeated until remind is completed or dismissed
+		Backoff []time.Duration `env-default:"1h,24h,72h" yaml:"backoff" env:"ESCALATION_BACKOFF"`
+	} `yaml:"escalation"`
+	Scheduler struct {
+		// MaxWait is the longest time the worker waits for the next due notification, reminds created or changed
+		// meanwhile are scheduled on the next run
+		MaxWait time.Duration `env-default:"1m" yaml:"max_wait" env:"SCHEDULER_MAX_WAIT"`
+		// WorkerID identifies worker which leases notifications, hostname and pid are used when it is empty
+		WorkerID string `yaml:"worker_id" env:"WORKER_ID"`
+		// Lease is how long notifications are leased by the worker, they are sent by other workers after it
+		// expires, e.g. when the worker has crashed
+		Lease time.Duration `env-default:"5m" yaml:"lease" env:"SCHEDULER_LEASE"`
+		// BatchSize is the number of notifications claimed at once
+		BatchSize int `env-default:"100" yaml:"batch_size" env:"SCHEDULER_BATCH_SIZE"`
+	} `yaml:"scheduler"`
+	Retry struct {
+		// MaxAttempts is the number of attempts to send notification, after the last one notification is dead
+		MaxAttempts int `env-default:"5" yaml:"max_attempts" env:"RETRY_MAX_ATTEMPTS"`
+		// Delay is the delay before the second attempt, it is doubled after each next attempt up to MaxDelay
+		Delay    time.Duration `env-default:"1m" yaml:"delay" env:"RETRY_DELAY"`
+		MaxDelay time.Duration `env-default:"1h" yaml:"max_delay" env:"RETRY_MAX_DELAY"`
+	} `yaml:"retry"`
+	Admin struct {
+		// UserIDs are Firebase UIDs of users who can manage notifications of all users
+		UserIDs []string `yaml:"user_ids" env:"ADMIN_USER_IDS"`
+	} `yaml:"admin"`
+	// Channels are notification channels available to users, addresses of the user in channels are
+	// set in user configs
+	Channels struct {
+		// Timeout limits requests of HTTP channels
+		Timeout time.Duration `env-default:"10s" yaml:"timeout" env:"CHANNELS_TIMEOUT"`
+		// AllowPrivateNetworks lets webhooks of users point to loopback and private addresses, e.g. in development.
+		// Otherwise they are rejected, so users can't make the worker call internal services
+		AllowPrivateNetworks bool `yaml:"allow_private_networks" env:"CHANNELS_ALLOW_PRIVATE_NETWORKS"`
+
+		Email struct {
+			// Disabled turns email off, it's enabled by default unlike other channels because false
+			// from config file can't override default true
+			Disabled bool `yaml:"disabled" env:"CHANNEL_EMAIL_DISABLED"`
+		} `yaml:"email"`
+		Webhook struct {
+			Enabled bool `yaml:"enabled" env:"CHANNEL_WEBHOOK_ENABLED"`
+			// Secret signs webhook body with HMAC-SHA256 in X-Reminder-Signature header when it's set
+			Secret string `yaml:"secret" env:"CHANNEL_WEBHOOK_SECRET"`
+		} `yaml:"webhook"`
+		Slack struct {
+			Enabled bool `yaml:"enabled" env:"CHANNEL_SLACK_ENABLED"`
+		} `yaml:"slack"`
+		Telegram struct {
+			Enabled  bool   `yaml:"enabled" env:"CHANNEL_TELEGRAM_ENABLED"`
+			BotToken string `yaml:"bot_token" env:"CHANNEL_TELEGRAM_BOT_TOKEN"`
+			APIURL   string `env-default:"https://api.telegram.org" yaml:"api_url" env:"CHANNEL_TELEGRAM_API_URL"`
+		} `yaml:"telegram"`
+		Ntfy struct {
+			Enabled   bool   `yaml:"enabled" env:"CHANNEL_NTFY_ENABLED"`
+			ServerURL string `env-default:"https://ntfy.sh" yaml:"server_url" env:"CHANNEL_NTFY_SERVER_URL"`
+			// Token is access token of ntfy server, topics are public without it
+			Token string `yaml:"token" env:"CHANNEL_NTFY_TOKEN"`
+		} `yaml:"ntfy"`
+		Gotify struct {
+			Enabled   bool   `yaml:"enabled" env:"CHANNEL_GOTIFY_ENABLED"`
+			ServerURL string `yaml:"server_url" env:"CHANNEL_GOTIFY_SERVER_URL"`
+		} `yaml:"gotify"`
+	} `yaml:"channels"`
+}
+
+// ChannelEnabled reports whether notification channel with given name (see domain channels) is enabled
+func (c Config) ChannelEnabled(name string) bool {
+	switch name {
+	case "email":
+		return !c.Channels.Email.Disabled
+	case "webhook":
+		return c.Channels.Webhook.Enabled
+	case "slack":
+		return c.Channels.Slack.Enabled
+	case "telegram":
+		return c.Channels.Telegram.Enabled
+	case "ntfy":
+		return c.Channels.Ntfy.Enabled
+	case "gotify":
+		return c.Channels.Gotify.Enabled
+	}
+	return false
 }
 
 func GetConfig() *Config {
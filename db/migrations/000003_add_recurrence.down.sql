@@ -0,0 +1,2 @@
+ALTER TABLE reminder.todo DROP COLUMN IF EXISTS "RecurFromCompletion";
+ALTER TABLE reminder.todo DROP COLUMN IF EXISTS "RRule";
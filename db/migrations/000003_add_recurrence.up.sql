@@ -0,0 +1,2 @@
+ALTER TABLE reminder.todo ADD COLUMN IF NOT EXISTS "RRule" varchar;
+ALTER TABLE reminder.todo ADD COLUMN IF NOT EXISTS "RecurFromCompletion" boolean NOT NULL DEFAULT false;
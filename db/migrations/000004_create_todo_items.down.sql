@@ -0,0 +1 @@
+DROP TABLE IF EXISTS reminder.todo_items;
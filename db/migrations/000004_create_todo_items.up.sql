@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS reminder.todo_items (
+  "ID" serial PRIMARY KEY,
+  "TodoID" int NOT NULL,
+  "Title" varchar NOT NULL,
+  "Completed" boolean NOT NULL DEFAULT false,
+  "DeadlineAt" timestamp,
+  "Position" int NOT NULL DEFAULT 0,
+  "CreatedAt" timestamp NOT NULL
+);
+
+CREATE INDEX ON reminder.todo_items ("TodoID", "Position");
+
+ALTER TABLE reminder.todo_items ADD FOREIGN KEY ("TodoID") REFERENCES reminder.todo ("ID") ON DELETE CASCADE;
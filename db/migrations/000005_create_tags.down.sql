@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS reminder.todo_tags;
+DROP TABLE IF EXISTS reminder.tags;
@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS reminder.tags (
+  "ID" serial PRIMARY KEY,
+  "User" varchar NOT NULL,
+  "Name" varchar NOT NULL,
+  "Color" varchar,
+  "CreatedAt" timestamp NOT NULL,
+  UNIQUE ("User", "Name")
+);
+
+CREATE TABLE IF NOT EXISTS reminder.todo_tags (
+  "TodoID" int NOT NULL,
+  "TagID" int NOT NULL,
+  PRIMARY KEY ("TodoID", "TagID")
+);
+
+CREATE INDEX ON reminder.todo_tags ("TagID");
+
+ALTER TABLE reminder.tags ADD FOREIGN KEY ("User") REFERENCES reminder.users_configs ("ID");
+ALTER TABLE reminder.todo_tags ADD FOREIGN KEY ("TodoID") REFERENCES reminder.todo ("ID") ON DELETE CASCADE;
+ALTER TABLE reminder.todo_tags ADD FOREIGN KEY ("TagID") REFERENCES reminder.tags ("ID") ON DELETE CASCADE;
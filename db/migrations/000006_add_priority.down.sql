@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS reminder.todo_user_priority_idx;
+
+ALTER TABLE reminder.todo DROP COLUMN IF EXISTS "Priority";
@@ -0,0 +1,3 @@
+ALTER TABLE reminder.todo ADD COLUMN IF NOT EXISTS "Priority" smallint NOT NULL DEFAULT 0;
+
+CREATE INDEX IF NOT EXISTS todo_user_priority_idx ON reminder.todo ("User", "Priority", "ID");
@@ -0,0 +1,3 @@
+ALTER TABLE reminder.todo DROP COLUMN IF EXISTS "ProjectID";
+
+DROP TABLE IF EXISTS reminder.projects;
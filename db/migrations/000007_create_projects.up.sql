@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS reminder.projects (
+  "ID" serial PRIMARY KEY,
+  "User" varchar NOT NULL,
+  "Name" varchar NOT NULL,
+  "Color" varchar,
+  "Position" int NOT NULL DEFAULT 0,
+  "ArchivedAt" timestamp,
+  "CreatedAt" timestamp NOT NULL
+);
+
+CREATE INDEX ON reminder.projects ("User", "Position");
+
+ALTER TABLE reminder.projects ADD FOREIGN KEY ("User") REFERENCES reminder.users_configs ("ID");
+
+ALTER TABLE reminder.todo ADD COLUMN IF NOT EXISTS "ProjectID" int;
+
+CREATE INDEX ON reminder.todo ("ProjectID");
+
+ALTER TABLE reminder.todo ADD FOREIGN KEY ("ProjectID") REFERENCES reminder.projects ("ID") ON DELETE SET NULL;
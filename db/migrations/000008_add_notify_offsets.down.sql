@@ -0,0 +1 @@
+ALTER TABLE reminder.todo DROP COLUMN IF EXISTS "NotifyOffsets";
@@ -0,0 +1,5 @@
+ALTER TABLE reminder.todo ADD COLUMN IF NOT EXISTS "NotifyOffsets" bigint[];
+
+UPDATE reminder.todo SET "NotifyOffsets" = ARRAY(
+  SELECT EXTRACT(EPOCH FROM "DeadlineAt" - p)::bigint FROM unnest("NotifyPeriod") p
+) WHERE "NotifyPeriod" IS NOT NULL;
@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS reminder.todo_members (
+  "ID" serial PRIMARY KEY,
+  "TodoID" int NOT NULL,
+  "User" varchar,
+  "Email" varchar,
+  "Role" varchar NOT NULL,
+  "Notify" boolean NOT NULL DEFAULT true,
+  "CreatedAt" timestamp NOT NULL,
+  UNIQUE ("TodoID", "User"),
+  UNIQUE ("TodoID", "Email"),
+  CHECK ("User" IS NOT NULL OR "Email" IS NOT NULL)
+);
+
+CREATE INDEX ON reminder.todo_members ("User");
+CREATE INDEX ON reminder.todo_members (lower("Email")) WHERE "User" IS NULL;
+
+ALTER TABLE reminder.todo_members ADD FOREIGN KEY ("TodoID") REFERENCES reminder.todo ("ID") ON DELETE CASCADE;
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS reminder.todo_deleted_at_idx;
+
+ALTER TABLE reminder.todo DROP COLUMN IF EXISTS "DeletedAt";
@@ -0,0 +1,3 @@
+ALTER TABLE reminder.todo ADD COLUMN IF NOT EXISTS "DeletedAt" timestamp;
+
+CREATE INDEX IF NOT EXISTS todo_deleted_at_idx ON reminder.todo ("DeletedAt") WHERE "DeletedAt" IS NOT NULL;
@@ -0,0 +1,12 @@
+-- history has no foreign key to reminder.todo, so it's kept when remind is purged
+CREATE TABLE IF NOT EXISTS reminder.todo_history (
+  "ID" bigserial PRIMARY KEY,
+  "TodoID" int NOT NULL,
+  "Owner" varchar NOT NULL,
+  "Actor" varchar NOT NULL,
+  "Action" varchar NOT NULL,
+  "Changes" jsonb NOT NULL DEFAULT '{}',
+  "CreatedAt" timestamp NOT NULL
+);
+
+CREATE INDEX ON reminder.todo_history ("TodoID", "ID");
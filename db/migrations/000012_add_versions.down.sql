@@ -0,0 +1,2 @@
+ALTER TABLE reminder.users_configs DROP COLUMN IF EXISTS "Version";
+ALTER TABLE reminder.todo DROP COLUMN IF EXISTS "Version";
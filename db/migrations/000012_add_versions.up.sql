@@ -0,0 +1,2 @@
+ALTER TABLE reminder.todo ADD COLUMN IF NOT EXISTS "Version" int NOT NULL DEFAULT 1;
+ALTER TABLE reminder.users_configs ADD COLUMN IF NOT EXISTS "Version" int NOT NULL DEFAULT 1;
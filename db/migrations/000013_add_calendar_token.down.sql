@@ -0,0 +1 @@
+ALTER TABLE reminder.users_configs DROP COLUMN IF EXISTS "CalendarToken";
@@ -0,0 +1,2 @@
+-- SHA-256 of secret token of calendar feed, the token itself isn't stored
+ALTER TABLE reminder.users_configs ADD COLUMN IF NOT EXISTS "CalendarToken" varchar UNIQUE;
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS reminder.users_configs_erase_at_idx;
+ALTER TABLE reminder.users_configs DROP COLUMN IF EXISTS "EraseAt";
+ALTER TABLE reminder.users_configs DROP COLUMN IF EXISTS "ErasureRequestedAt";
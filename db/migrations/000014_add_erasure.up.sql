@@ -0,0 +1,5 @@
+-- erasure of all data of the user is scheduled to "EraseAt" and can be cancelled until then
+ALTER TABLE reminder.users_configs ADD COLUMN IF NOT EXISTS "ErasureRequestedAt" timestamp;
+ALTER TABLE reminder.users_configs ADD COLUMN IF NOT EXISTS "EraseAt" timestamp;
+
+CREATE INDEX IF NOT EXISTS users_configs_erase_at_idx ON reminder.users_configs ("EraseAt") WHERE "EraseAt" IS NOT NULL;
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS reminder.todo_search_idx;
+ALTER TABLE reminder.todo DROP COLUMN IF EXISTS "Search";
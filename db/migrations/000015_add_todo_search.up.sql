@@ -0,0 +1,6 @@
+-- 'simple' configuration doesn't stem words, so search works the same for reminds in any language
+ALTER TABLE reminder.todo ADD COLUMN IF NOT EXISTS "Search" tsvector GENERATED ALWAYS AS (
+  setweight(to_tsvector('simple', "Title"), 'A') || setweight(to_tsvector('simple', "Description"), 'B')
+) STORED;
+
+CREATE INDEX IF NOT EXISTS todo_search_idx ON reminder.todo USING GIN ("Search");
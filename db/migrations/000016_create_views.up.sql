@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS reminder.views (
+  "ID" serial PRIMARY KEY,
+  "User" varchar NOT NULL,
+  "Name" varchar NOT NULL,
+  "Definition" jsonb NOT NULL,
+  "CreatedAt" timestamp NOT NULL
+);
+
+CREATE INDEX ON reminder.views ("User");
+
+ALTER TABLE reminder.views ADD FOREIGN KEY ("User") REFERENCES reminder.users_configs ("ID");
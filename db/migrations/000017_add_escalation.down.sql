@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS reminder.todo_overdue_idx;
+
+ALTER TABLE reminder.todo DROP COLUMN IF EXISTS "OverdueDismissedAt";
+ALTER TABLE reminder.todo DROP COLUMN IF EXISTS "NextEscalationAt";
+ALTER TABLE reminder.todo DROP COLUMN IF EXISTS "EscalatedAt";
+ALTER TABLE reminder.todo DROP COLUMN IF EXISTS "Escalations";
@@ -0,0 +1,12 @@
+-- overdue reminds are notified again on backoff schedule, escalations made before the current deadline
+-- are ignored, so moving the deadline starts the schedule over
+ALTER TABLE reminder.todo ADD COLUMN IF NOT EXISTS "Escalations" int NOT NULL DEFAULT 0;
+ALTER TABLE reminder.todo ADD COLUMN IF NOT EXISTS "EscalatedAt" timestamp;
+ALTER TABLE reminder.todo ADD COLUMN IF NOT EXISTS "NextEscalationAt" timestamp;
+ALTER TABLE reminder.todo ADD COLUMN IF NOT EXISTS "OverdueDismissedAt" timestamp;
+
+-- reminds which are already overdue aren't escalated, otherwise all of them would be notified at once
+UPDATE reminder.todo SET "OverdueDismissedAt" = now() AT TIME ZONE 'UTC'
+WHERE "Completed" = false AND "DeadlineAt" < now() AT TIME ZONE 'UTC';
+
+CREATE INDEX IF NOT EXISTS todo_overdue_idx ON reminder.todo ("DeadlineAt") WHERE "Completed" = false AND "DeletedAt" IS NULL;
@@ -0,0 +1,2 @@
+-- notification channels of the user with their addresses, email is used when the list is empty
+ALTER TABLE reminder.users_configs ADD COLUMN IF NOT EXISTS "Channels" jsonb NOT NULL DEFAULT '[]';
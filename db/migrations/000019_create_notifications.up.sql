@@ -0,0 +1,28 @@
+-- due-time queue of remind notifications: the worker adds notifications to it by schedule of reminds and sends
+-- not sent ones which are due, so notifications missed while the worker was down are sent when it is back
+CREATE TABLE IF NOT EXISTS reminder.notifications (
+  "ID" bigserial PRIMARY KEY,
+  "TodoID" int NOT NULL,
+  "Kind" varchar NOT NULL,
+  "DueAt" timestamp NOT NULL,
+  "SentAt" timestamp,
+  UNIQUE ("TodoID", "Kind", "DueAt")
+);
+
+CREATE INDEX IF NOT EXISTS notifications_due_idx ON reminder.notifications ("DueAt") WHERE "SentAt" IS NULL;
+
+ALTER TABLE reminder.notifications ADD FOREIGN KEY ("TodoID") REFERENCES reminder.todo ("ID") ON DELETE CASCADE;
+
+-- notifications which were sent or missed before the queue are treated as sent, otherwise all of them would be sent at once
+INSERT INTO reminder.notifications ("TodoID", "Kind", "DueAt", "SentAt")
+SELECT t."ID", 'deadline', p, now() AT TIME ZONE 'UTC'
+FROM reminder.todo t, unnest(t."NotifyPeriod") p
+WHERE p <= now() AT TIME ZONE 'UTC'
+ON CONFLICT DO NOTHING;
+
+INSERT INTO reminder.notifications ("TodoID", "Kind", "DueAt", "SentAt")
+SELECT t."ID", 'period', t."DeadlineAt" - u."Period" * interval '1 day', now() AT TIME ZONE 'UTC'
+FROM reminder.todo t
+INNER JOIN reminder.users_configs u ON u."ID" = t."User"
+WHERE t."Notificated" = true AND u."Period" > 0
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,4 @@
+ALTER TABLE reminder.todo DROP COLUMN IF EXISTS "EscalationLeasedUntil";
+
+ALTER TABLE reminder.notifications DROP COLUMN IF EXISTS "LeasedUntil";
+ALTER TABLE reminder.notifications DROP COLUMN IF EXISTS "LeasedBy";
@@ -0,0 +1,6 @@
+-- due notifications and overdue reminds are leased by the worker which sends them, so several workers can run
+-- at the same time. Lease of crashed worker expires and its notifications are sent by other ones
+ALTER TABLE reminder.notifications ADD COLUMN IF NOT EXISTS "LeasedBy" varchar;
+ALTER TABLE reminder.notifications ADD COLUMN IF NOT EXISTS "LeasedUntil" timestamp;
+
+ALTER TABLE reminder.todo ADD COLUMN IF NOT EXISTS "EscalationLeasedUntil" timestamp;
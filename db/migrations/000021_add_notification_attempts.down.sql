@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS reminder.notifications_dead_idx;
+
+ALTER TABLE reminder.notifications DROP COLUMN IF EXISTS "DeadAt";
+ALTER TABLE reminder.notifications DROP COLUMN IF EXISTS "NextAttemptAt";
+ALTER TABLE reminder.notifications DROP COLUMN IF EXISTS "LastError";
+ALTER TABLE reminder.notifications DROP COLUMN IF EXISTS "Attempts";
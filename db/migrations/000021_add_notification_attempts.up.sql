@@ -0,0 +1,8 @@
+-- failed notifications are retried with exponential backoff, after the last attempt they are dead and are sent
+-- again only when admin retries them
+ALTER TABLE reminder.notifications ADD COLUMN IF NOT EXISTS "Attempts" int NOT NULL DEFAULT 0;
+ALTER TABLE reminder.notifications ADD COLUMN IF NOT EXISTS "LastError" varchar;
+ALTER TABLE reminder.notifications ADD COLUMN IF NOT EXISTS "NextAttemptAt" timestamp;
+ALTER TABLE reminder.notifications ADD COLUMN IF NOT EXISTS "DeadAt" timestamp;
+
+CREATE INDEX IF NOT EXISTS notifications_dead_idx ON reminder.notifications ("DeadAt") WHERE "DeadAt" IS NOT NULL;
@@ -0,0 +1,25 @@
+-- outbox of notification deliveries: they are added in the same transaction as the state of notification or remind
+-- which caused them, and the dispatcher delivers each of them at least once. IdempotencyKey is the same for each
+-- delivery attempt, so channels can dedupe repeated ones
+CREATE TABLE IF NOT EXISTS reminder.outbox (
+  "ID" bigserial PRIMARY KEY,
+  "IdempotencyKey" varchar NOT NULL UNIQUE,
+  "TodoID" int NOT NULL,
+  "UserID" varchar NOT NULL,
+  "Channel" varchar NOT NULL,
+  "Payload" jsonb NOT NULL,
+  "CreatedAt" timestamp NOT NULL,
+  "SentAt" timestamp,
+  "LeasedBy" varchar,
+  "LeasedUntil" timestamp,
+  "Attempts" int NOT NULL DEFAULT 0,
+  "LastError" varchar,
+  "NextAttemptAt" timestamp,
+  "DeadAt" timestamp
+);
+
+CREATE INDEX IF NOT EXISTS outbox_pending_idx ON reminder.outbox ("CreatedAt") WHERE "SentAt" IS NULL AND "DeadAt" IS NULL;
+CREATE INDEX IF NOT EXISTS outbox_dead_idx ON reminder.outbox ("DeadAt") WHERE "DeadAt" IS NOT NULL;
+CREATE INDEX IF NOT EXISTS outbox_user_idx ON reminder.outbox ("UserID");
+
+ALTER TABLE reminder.outbox ADD FOREIGN KEY ("TodoID") REFERENCES reminder.todo ("ID") ON DELETE CASCADE;
@@ -0,0 +1,39 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+var ErrInvalidBulkAction = errors.New("action should be complete, reopen, delete, move_deadline or deadline_notify")
+
+// BulkAction is an action applied to each remind of bulk request
+type BulkAction string
+
+const (
+	BulkComplete BulkAction = "complete"
+	BulkReopen   BulkAction = "reopen"
+	BulkDelete   BulkAction = "delete"
+	// BulkMoveDeadline moves deadline and notify period of reminds by Days
+	BulkMoveDeadline BulkAction = "move_deadline"
+	// BulkDeadlineNotify turns deadline notifications on or off by DeadlineNotify
+	BulkDeadlineNotify BulkAction = "deadline_notify"
+)
+
+type BulkInput struct {
+	IDs    []int      `json:"ids"`
+	Action BulkAction `json:"action"`
+	// Days is used by move_deadline, negative value moves deadline back
+	Days int `json:"days,omitempty"`
+	// DeadlineNotify is used by deadline_notify
+	DeadlineNotify *bool `json:"deadline_notify,omitempty"`
+	// FinishedAt is set by server for complete action
+	FinishedAt *time.Time `json:"-"`
+}
+
+// BulkResult is a result of bulk action for one remind, failed reminds don't stop others
+type BulkResult struct {
+	ID      int    `json:"id"`
+	Success bool   `json:"success"`
+	Error   string `json:"error,omitempty"`
+}
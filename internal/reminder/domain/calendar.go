@@ -0,0 +1,7 @@
+package domain
+
+// CalendarFeed is a secret feed of user reminds for calendar clients. The token is shown only once when the feed is created
+type CalendarFeed struct {
+	Token string `json:"token"`
+	URL   string `json:"url"`
+}
@@ -0,0 +1,129 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/mail"
+	"net/url"
+	"strings"
+)
+
+// Notification channels, each of them should be enabled in config to be used
+const (
+	ChannelEmail    = "email"
+	ChannelWebhook  = "webhook"
+	ChannelSlack    = "slack"
+	ChannelTelegram = "telegram"
+	ChannelNtfy     = "ntfy"
+	ChannelGotify   = "gotify"
+)
+
+// MaxChannels limits number of channel preferences of the user
+const MaxChannels = 10
+
+// SlackHost is the host of Slack incoming webhooks
+const SlackHost = "hooks.slack.com"
+
+var ErrInvalidChannel = errors.New("invalid notification channel")
+
+// ErrPrivateAddress is returned for channel address in loopback, private, link-local or other not public network,
+// the worker doesn't send user notifications there
+var ErrPrivateAddress = errors.New("address isn't public")
+
+// nonPublicNetworks are networks which aren't covered by net.IP methods and can't be reached from the internet,
+// e.g. shared address space of carrier-grade NAT which has metadata service of some clouds
+var nonPublicNetworks = func() []*net.IPNet {
+	var networks []*net.IPNet
+	for _, cidr := range []string{"0.0.0.0/8", "100.64.0.0/10", "192.0.0.0/24", "198.18.0.0/15", "240.0.0.0/4", "64:ff9b::/96"} {
+		_, network, _ := net.ParseCIDR(cidr)
+		networks = append(networks, network)
+	}
+	return networks
+}()
+
+// PublicIP reports whether ip is public: it isn't loopback, private, link-local (which has cloud metadata
+// services), multicast or unspecified
+func PublicIP(ip net.IP) bool {
+	if ip == nil || ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() || ip.IsUnspecified() {
+		return false
+	}
+	for _, network := range nonPublicNetworks {
+		if network.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
+
+// ChannelPreference is a channel the user gets notifications by. Address depends on the channel: email address
+// (email of the account when empty), webhook or Slack incoming webhook URL, Telegram chat id, ntfy topic
+// or Gotify application token
+type ChannelPreference struct {
+	Channel string `json:"channel"`
+	Address string `json:"address,omitempty"`
+}
+
+// Validate checks that channel is known and its address fits it
+func (p ChannelPreference) Validate() error {
+	switch p.Channel {
+	case ChannelEmail:
+		if p.Address == "" {
+			return nil
+		}
+		if _, err := mail.ParseAddress(p.Address); err != nil {
+			return fmt.Errorf("%w: email address is invalid", ErrInvalidChannel)
+		}
+	case ChannelWebhook:
+		u, err := url.Parse(p.Address)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("%w: %s address should be http or https URL", ErrInvalidChannel, p.Channel)
+		}
+	case ChannelSlack:
+		u, err := url.Parse(p.Address)
+		if err != nil || u.Scheme != "https" || u.Host != SlackHost {
+			return fmt.Errorf("%w: slack address should be incoming webhook URL https://%s/...", ErrInvalidChannel, SlackHost)
+		}
+	case ChannelTelegram, ChannelGotify:
+		if strings.TrimSpace(p.Address) == "" {
+			return fmt.Errorf("%w: %s address is empty", ErrInvalidChannel, p.Channel)
+		}
+	case ChannelNtfy:
+		if strings.TrimSpace(p.Address) == "" || strings.Contains(p.Address, "/") {
+			return fmt.Errorf("%w: ntfy address should be topic name", ErrInvalidChannel)
+		}
+	default:
+		return fmt.Errorf("%w: unknown channel %q", ErrInvalidChannel, p.Channel)
+	}
+	return nil
+}
+
+// CheckPublicAddress checks that webhook address doesn't point to local network: its host isn't a local name
+// or not public IP. Host names are resolved and checked again when the worker connects to them
+func (p ChannelPreference) CheckPublicAddress() error {
+	if p.Channel != ChannelWebhook {
+		return nil
+	}
+
+	u, err := url.Parse(p.Address)
+	if err != nil {
+		return fmt.Errorf("%w: %s address should be http or https URL", ErrInvalidChannel, p.Channel)
+	}
+
+	host := strings.ToLower(strings.TrimSuffix(u.Hostname(), "."))
+	if ip := net.ParseIP(host); ip != nil {
+		if !PublicIP(ip) {
+			return fmt.Errorf("%w: %s", ErrPrivateAddress, host)
+		}
+		return nil
+	}
+
+	for _, local := range []string{"localhost", "local", "internal"} {
+		if host == local || strings.HasSuffix(host, "."+local) {
+			return fmt.Errorf("%w: %s", ErrPrivateAddress, host)
+		}
+	}
+
+	return nil
+}
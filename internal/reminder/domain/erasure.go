@@ -0,0 +1,18 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+var ErrErasureNotRequested = errors.New("erasure of account isn't requested")
+
+// ActorErased replaces id of erased user in history of reminds of other users
+const ActorErased = "erased"
+
+// Erasure is a request to delete all data of the user, it can be cancelled until EraseAt
+type Erasure struct {
+	UserID      string    `json:"user_id"`
+	RequestedAt time.Time `json:"requested_at"`
+	EraseAt     time.Time `json:"erase_at"`
+}
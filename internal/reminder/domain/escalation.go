@@ -0,0 +1,14 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrRemindNotOverdue is returned when overdue notifications are dismissed for remind which isn't overdue
+var ErrRemindNotOverdue = errors.New("remind isn't overdue")
+
+// IsOverdue reports whether deadline of not completed remind has passed
+func (t Todo) IsOverdue(now time.Time) bool {
+	return !t.Completed && t.DeadlineAt.Before(now)
+}
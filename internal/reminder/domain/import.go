@@ -0,0 +1,34 @@
+package domain
+
+import "errors"
+
+var ErrUnsupportedImportFormat = errors.New("import file should be iCalendar (.ics) or CSV (.csv)")
+
+// ImportStatus is a result of import of one row
+type ImportStatus string
+
+const (
+	// ImportImported is set for rows which are imported or, in dry run, would be imported
+	ImportImported ImportStatus = "imported"
+	// ImportSkipped is set for rows which aren't reminds to import, e.g. completed todos
+	ImportSkipped ImportStatus = "skipped"
+	ImportFailed  ImportStatus = "failed"
+)
+
+// ImportRow is a result of import of one CSV row or iCalendar component, rows are numbered from 1
+type ImportRow struct {
+	Row    int          `json:"row"`
+	Title  string       `json:"title"`
+	Status ImportStatus `json:"status"`
+	// ID is id of created remind, it isn't set in dry run
+	ID     int    `json:"id,omitempty"`
+	Reason string `json:"reason,omitempty"`
+}
+
+type ImportReport struct {
+	DryRun   bool        `json:"dry_run"`
+	Imported int         `json:"imported"`
+	Skipped  int         `json:"skipped"`
+	Failed   int         `json:"failed"`
+	Rows     []ImportRow `json:"rows"`
+}
@@ -7,6 +7,7 @@ package mock_domain
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 	domain "github.com/red-rocket-software/reminder-go/internal/reminder/domain"
@@ -35,6 +36,20 @@ func (m *MockConfigRepository) EXPECT() *MockConfigRepositoryMockRecorder {
 	return m.recorder
 }
 
+// CancelErasure mocks base method.
+func (m *MockConfigRepository) CancelErasure(ctx context.Context, userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelErasure", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CancelErasure indicates an expected call of CancelErasure.
+func (mr *MockConfigRepositoryMockRecorder) CancelErasure(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelErasure", reflect.TypeOf((*MockConfigRepository)(nil).CancelErasure), ctx, userID)
+}
+
 // CreateUserConfigs mocks base method.
 func (m *MockConfigRepository) CreateUserConfigs(ctx context.Context, userID string) (domain.UserConfigs, error) {
 	m.ctrl.T.Helper()
@@ -50,6 +65,50 @@ func (mr *MockConfigRepositoryMockRecorder) CreateUserConfigs(ctx, userID interf
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUserConfigs", reflect.TypeOf((*MockConfigRepository)(nil).CreateUserConfigs), ctx, userID)
 }
 
+// EraseUser mocks base method.
+func (m *MockConfigRepository) EraseUser(ctx context.Context, userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EraseUser", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EraseUser indicates an expected call of EraseUser.
+func (mr *MockConfigRepositoryMockRecorder) EraseUser(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EraseUser", reflect.TypeOf((*MockConfigRepository)(nil).EraseUser), ctx, userID)
+}
+
+// GetDueErasures mocks base method.
+func (m *MockConfigRepository) GetDueErasures(ctx context.Context, before time.Time) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDueErasures", ctx, before)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDueErasures indicates an expected call of GetDueErasures.
+func (mr *MockConfigRepositoryMockRecorder) GetDueErasures(ctx, before interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDueErasures", reflect.TypeOf((*MockConfigRepository)(nil).GetDueErasures), ctx, before)
+}
+
+// GetUserByCalendarToken mocks base method.
+func (m *MockConfigRepository) GetUserByCalendarToken(ctx context.Context, tokenHash string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByCalendarToken", ctx, tokenHash)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByCalendarToken indicates an expected call of GetUserByCalendarToken.
+func (mr *MockConfigRepositoryMockRecorder) GetUserByCalendarToken(ctx, tokenHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByCalendarToken", reflect.TypeOf((*MockConfigRepository)(nil).GetUserByCalendarToken), ctx, tokenHash)
+}
+
 // GetUserConfigs mocks base method.
 func (m *MockConfigRepository) GetUserConfigs(ctx context.Context, userID string) (domain.UserConfigs, error) {
 	m.ctrl.T.Helper()
@@ -65,14 +124,44 @@ func (mr *MockConfigRepositoryMockRecorder) GetUserConfigs(ctx, userID interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserConfigs", reflect.TypeOf((*MockConfigRepository)(nil).GetUserConfigs), ctx, userID)
 }
 
-// UpdateUserConfig mocks base method.
-func (m *MockConfigRepository) UpdateUserConfig(ctx context.Context, id string, input domain.UserConfigs) error {
+// RequestErasure mocks base method.
+func (m *MockConfigRepository) RequestErasure(ctx context.Context, userID string, eraseAt time.Time) (domain.Erasure, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateUserConfig", ctx, id, input)
+	ret := m.ctrl.Call(m, "RequestErasure", ctx, userID, eraseAt)
+	ret0, _ := ret[0].(domain.Erasure)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RequestErasure indicates an expected call of RequestErasure.
+func (mr *MockConfigRepositoryMockRecorder) RequestErasure(ctx, userID, eraseAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestErasure", reflect.TypeOf((*MockConfigRepository)(nil).RequestErasure), ctx, userID, eraseAt)
+}
+
+// SetCalendarToken mocks base method.
+func (m *MockConfigRepository) SetCalendarToken(ctx context.Context, userID string, tokenHash *string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetCalendarToken", ctx, userID, tokenHash)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
+// SetCalendarToken indicates an expected call of SetCalendarToken.
+func (mr *MockConfigRepositoryMockRecorder) SetCalendarToken(ctx, userID, tokenHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCalendarToken", reflect.TypeOf((*MockConfigRepository)(nil).SetCalendarToken), ctx, userID, tokenHash)
+}
+
+// UpdateUserConfig mocks base method.
+func (m *MockConfigRepository) UpdateUserConfig(ctx context.Context, id string, input domain.UserConfigs) (domain.UserConfigs, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserConfig", ctx, id, input)
+	ret0, _ := ret[0].(domain.UserConfigs)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
 // UpdateUserConfig indicates an expected call of UpdateUserConfig.
 func (mr *MockConfigRepositoryMockRecorder) UpdateUserConfig(ctx, id, input interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
@@ -7,6 +7,7 @@ package mock_domain
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 	domain "github.com/red-rocket-software/reminder-go/internal/reminder/domain"
@@ -35,6 +36,96 @@ func (m *MockTodoRepository) EXPECT() *MockTodoRepositoryMockRecorder {
 	return m.recorder
 }
 
+// AddMember mocks base method.
+func (m *MockTodoRepository) AddMember(ctx context.Context, todoID int, userID string, input domain.MemberInput) (domain.Member, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddMember", ctx, todoID, userID, input)
+	ret0, _ := ret[0].(domain.Member)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddMember indicates an expected call of AddMember.
+func (mr *MockTodoRepositoryMockRecorder) AddMember(ctx, todoID, userID, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddMember", reflect.TypeOf((*MockTodoRepository)(nil).AddMember), ctx, todoID, userID, input)
+}
+
+// BulkUpdate mocks base method.
+func (m *MockTodoRepository) BulkUpdate(ctx context.Context, userID string, input domain.BulkInput) ([]domain.BulkResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BulkUpdate", ctx, userID, input)
+	ret0, _ := ret[0].([]domain.BulkResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BulkUpdate indicates an expected call of BulkUpdate.
+func (mr *MockTodoRepositoryMockRecorder) BulkUpdate(ctx, userID, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkUpdate", reflect.TypeOf((*MockTodoRepository)(nil).BulkUpdate), ctx, userID, input)
+}
+
+// ClaimDueNotifications mocks base method.
+func (m *MockTodoRepository) ClaimDueNotifications(ctx context.Context, workerID string, now time.Time, lease time.Duration, limit int) ([]domain.Notification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimDueNotifications", ctx, workerID, now, lease, limit)
+	ret0, _ := ret[0].([]domain.Notification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimDueNotifications indicates an expected call of ClaimDueNotifications.
+func (mr *MockTodoRepositoryMockRecorder) ClaimDueNotifications(ctx, workerID, now, lease, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimDueNotifications", reflect.TypeOf((*MockTodoRepository)(nil).ClaimDueNotifications), ctx, workerID, now, lease, limit)
+}
+
+// ClaimOutbox mocks base method.
+func (m *MockTodoRepository) ClaimOutbox(ctx context.Context, workerID string, now time.Time, lease time.Duration, limit int) ([]domain.OutboxMessage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimOutbox", ctx, workerID, now, lease, limit)
+	ret0, _ := ret[0].([]domain.OutboxMessage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimOutbox indicates an expected call of ClaimOutbox.
+func (mr *MockTodoRepositoryMockRecorder) ClaimOutbox(ctx, workerID, now, lease, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimOutbox", reflect.TypeOf((*MockTodoRepository)(nil).ClaimOutbox), ctx, workerID, now, lease, limit)
+}
+
+// ClaimRemindsForEscalation mocks base method.
+func (m *MockTodoRepository) ClaimRemindsForEscalation(ctx context.Context, now time.Time, firstDelay, lease time.Duration) ([]domain.NotificationRemind, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimRemindsForEscalation", ctx, now, firstDelay, lease)
+	ret0, _ := ret[0].([]domain.NotificationRemind)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimRemindsForEscalation indicates an expected call of ClaimRemindsForEscalation.
+func (mr *MockTodoRepositoryMockRecorder) ClaimRemindsForEscalation(ctx, now, firstDelay, lease interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimRemindsForEscalation", reflect.TypeOf((*MockTodoRepository)(nil).ClaimRemindsForEscalation), ctx, now, firstDelay, lease)
+}
+
+// CreateItem mocks base method.
+func (m *MockTodoRepository) CreateItem(ctx context.Context, todoID int, userID string, input domain.TodoItemInput) (domain.TodoItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateItem", ctx, todoID, userID, input)
+	ret0, _ := ret[0].(domain.TodoItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateItem indicates an expected call of CreateItem.
+func (mr *MockTodoRepositoryMockRecorder) CreateItem(ctx, todoID, userID, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateItem", reflect.TypeOf((*MockTodoRepository)(nil).CreateItem), ctx, todoID, userID, input)
+}
+
 // CreateRemind mocks base method.
 func (m *MockTodoRepository) CreateRemind(ctx context.Context, todo domain.Todo) (domain.Todo, error) {
 	m.ctrl.T.Helper()
@@ -50,33 +141,193 @@ func (mr *MockTodoRepositoryMockRecorder) CreateRemind(ctx, todo interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRemind", reflect.TypeOf((*MockTodoRepository)(nil).CreateRemind), ctx, todo)
 }
 
+// DeleteItem mocks base method.
+func (m *MockTodoRepository) DeleteItem(ctx context.Context, todoID, itemID int, userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteItem", ctx, todoID, itemID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteItem indicates an expected call of DeleteItem.
+func (mr *MockTodoRepositoryMockRecorder) DeleteItem(ctx, todoID, itemID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteItem", reflect.TypeOf((*MockTodoRepository)(nil).DeleteItem), ctx, todoID, itemID, userID)
+}
+
+// DeleteMember mocks base method.
+func (m *MockTodoRepository) DeleteMember(ctx context.Context, todoID, memberID int, userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteMember", ctx, todoID, memberID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteMember indicates an expected call of DeleteMember.
+func (mr *MockTodoRepositoryMockRecorder) DeleteMember(ctx, todoID, memberID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMember", reflect.TypeOf((*MockTodoRepository)(nil).DeleteMember), ctx, todoID, memberID, userID)
+}
+
 // DeleteRemind mocks base method.
-func (m *MockTodoRepository) DeleteRemind(ctx context.Context, id int) error {
+func (m *MockTodoRepository) DeleteRemind(ctx context.Context, id int, userID string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteRemind", ctx, id)
+	ret := m.ctrl.Call(m, "DeleteRemind", ctx, id, userID)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteRemind indicates an expected call of DeleteRemind.
-func (mr *MockTodoRepositoryMockRecorder) DeleteRemind(ctx, id interface{}) *gomock.Call {
+func (mr *MockTodoRepositoryMockRecorder) DeleteRemind(ctx, id, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRemind", reflect.TypeOf((*MockTodoRepository)(nil).DeleteRemind), ctx, id, userID)
+}
+
+// DismissOverdue mocks base method.
+func (m *MockTodoRepository) DismissOverdue(ctx context.Context, id int, userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DismissOverdue", ctx, id, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DismissOverdue indicates an expected call of DismissOverdue.
+func (mr *MockTodoRepositoryMockRecorder) DismissOverdue(ctx, id, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DismissOverdue", reflect.TypeOf((*MockTodoRepository)(nil).DismissOverdue), ctx, id, userID)
+}
+
+// ExportItems mocks base method.
+func (m *MockTodoRepository) ExportItems(ctx context.Context, userID string, fn func(domain.TodoItem) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportItems", ctx, userID, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExportItems indicates an expected call of ExportItems.
+func (mr *MockTodoRepositoryMockRecorder) ExportItems(ctx, userID, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportItems", reflect.TypeOf((*MockTodoRepository)(nil).ExportItems), ctx, userID, fn)
+}
+
+// ExportReminds mocks base method.
+func (m *MockTodoRepository) ExportReminds(ctx context.Context, userID string, fn func(domain.Todo) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportReminds", ctx, userID, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExportReminds indicates an expected call of ExportReminds.
+func (mr *MockTodoRepositoryMockRecorder) ExportReminds(ctx, userID, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportReminds", reflect.TypeOf((*MockTodoRepository)(nil).ExportReminds), ctx, userID, fn)
+}
+
+// GetCalendarReminds mocks base method.
+func (m *MockTodoRepository) GetCalendarReminds(ctx context.Context, userID string) ([]domain.Todo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCalendarReminds", ctx, userID)
+	ret0, _ := ret[0].([]domain.Todo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCalendarReminds indicates an expected call of GetCalendarReminds.
+func (mr *MockTodoRepositoryMockRecorder) GetCalendarReminds(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCalendarReminds", reflect.TypeOf((*MockTodoRepository)(nil).GetCalendarReminds), ctx, userID)
+}
+
+// GetDeadNotifications mocks base method.
+func (m *MockTodoRepository) GetDeadNotifications(ctx context.Context, limit int) ([]domain.Notification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeadNotifications", ctx, limit)
+	ret0, _ := ret[0].([]domain.Notification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeadNotifications indicates an expected call of GetDeadNotifications.
+func (mr *MockTodoRepositoryMockRecorder) GetDeadNotifications(ctx, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeadNotifications", reflect.TypeOf((*MockTodoRepository)(nil).GetDeadNotifications), ctx, limit)
+}
+
+// GetDeadOutbox mocks base method.
+func (m *MockTodoRepository) GetDeadOutbox(ctx context.Context, limit int) ([]domain.OutboxMessage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeadOutbox", ctx, limit)
+	ret0, _ := ret[0].([]domain.OutboxMessage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeadOutbox indicates an expected call of GetDeadOutbox.
+func (mr *MockTodoRepositoryMockRecorder) GetDeadOutbox(ctx, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeadOutbox", reflect.TypeOf((*MockTodoRepository)(nil).GetDeadOutbox), ctx, limit)
+}
+
+// GetHistory mocks base method.
+func (m *MockTodoRepository) GetHistory(ctx context.Context, todoID int, userID string) ([]domain.HistoryEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHistory", ctx, todoID, userID)
+	ret0, _ := ret[0].([]domain.HistoryEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHistory indicates an expected call of GetHistory.
+func (mr *MockTodoRepositoryMockRecorder) GetHistory(ctx, todoID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHistory", reflect.TypeOf((*MockTodoRepository)(nil).GetHistory), ctx, todoID, userID)
+}
+
+// GetItems mocks base method.
+func (m *MockTodoRepository) GetItems(ctx context.Context, todoID int, userID string) ([]domain.TodoItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetItems", ctx, todoID, userID)
+	ret0, _ := ret[0].([]domain.TodoItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetItems indicates an expected call of GetItems.
+func (mr *MockTodoRepositoryMockRecorder) GetItems(ctx, todoID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetItems", reflect.TypeOf((*MockTodoRepository)(nil).GetItems), ctx, todoID, userID)
+}
+
+// GetMembers mocks base method.
+func (m *MockTodoRepository) GetMembers(ctx context.Context, todoID int, userID string) ([]domain.Member, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMembers", ctx, todoID, userID)
+	ret0, _ := ret[0].([]domain.Member)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMembers indicates an expected call of GetMembers.
+func (mr *MockTodoRepositoryMockRecorder) GetMembers(ctx, todoID, userID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRemind", reflect.TypeOf((*MockTodoRepository)(nil).DeleteRemind), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMembers", reflect.TypeOf((*MockTodoRepository)(nil).GetMembers), ctx, todoID, userID)
 }
 
 // GetRemindByID mocks base method.
-func (m *MockTodoRepository) GetRemindByID(ctx context.Context, id int) (domain.Todo, error) {
+func (m *MockTodoRepository) GetRemindByID(ctx context.Context, id int, userID string) (domain.Todo, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetRemindByID", ctx, id)
+	ret := m.ctrl.Call(m, "GetRemindByID", ctx, id, userID)
 	ret0, _ := ret[0].(domain.Todo)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetRemindByID indicates an expected call of GetRemindByID.
-func (mr *MockTodoRepositoryMockRecorder) GetRemindByID(ctx, id interface{}) *gomock.Call {
+func (mr *MockTodoRepositoryMockRecorder) GetRemindByID(ctx, id, userID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRemindByID", reflect.TypeOf((*MockTodoRepository)(nil).GetRemindByID), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRemindByID", reflect.TypeOf((*MockTodoRepository)(nil).GetRemindByID), ctx, id, userID)
 }
 
 // GetReminds mocks base method.
@@ -96,90 +347,304 @@ func (mr *MockTodoRepositoryMockRecorder) GetReminds(ctx, params, userID interfa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReminds", reflect.TypeOf((*MockTodoRepository)(nil).GetReminds), ctx, params, userID)
 }
 
-// GetRemindsForDeadlineNotification mocks base method.
-func (m *MockTodoRepository) GetRemindsForDeadlineNotification(ctx context.Context) ([]domain.NotificationRemind, string, error) {
+// GetTrash mocks base method.
+func (m *MockTodoRepository) GetTrash(ctx context.Context, userID string) ([]domain.Todo, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetRemindsForDeadlineNotification", ctx)
-	ret0, _ := ret[0].([]domain.NotificationRemind)
-	ret1, _ := ret[1].(string)
-	ret2, _ := ret[2].(error)
-	return ret0, ret1, ret2
+	ret := m.ctrl.Call(m, "GetTrash", ctx, userID)
+	ret0, _ := ret[0].([]domain.Todo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// GetRemindsForDeadlineNotification indicates an expected call of GetRemindsForDeadlineNotification.
-func (mr *MockTodoRepositoryMockRecorder) GetRemindsForDeadlineNotification(ctx interface{}) *gomock.Call {
+// GetTrash indicates an expected call of GetTrash.
+func (mr *MockTodoRepositoryMockRecorder) GetTrash(ctx, userID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRemindsForDeadlineNotification", reflect.TypeOf((*MockTodoRepository)(nil).GetRemindsForDeadlineNotification), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTrash", reflect.TypeOf((*MockTodoRepository)(nil).GetTrash), ctx, userID)
 }
 
-// GetRemindsForNotification mocks base method.
-func (m *MockTodoRepository) GetRemindsForNotification(ctx context.Context) ([]domain.NotificationRemind, error) {
+// ImportReminds mocks base method.
+func (m *MockTodoRepository) ImportReminds(ctx context.Context, reminds []domain.Todo, dryRun bool) ([]domain.BulkResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetRemindsForNotification", ctx)
-	ret0, _ := ret[0].([]domain.NotificationRemind)
+	ret := m.ctrl.Call(m, "ImportReminds", ctx, reminds, dryRun)
+	ret0, _ := ret[0].([]domain.BulkResult)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetRemindsForNotification indicates an expected call of GetRemindsForNotification.
-func (mr *MockTodoRepositoryMockRecorder) GetRemindsForNotification(ctx interface{}) *gomock.Call {
+// ImportReminds indicates an expected call of ImportReminds.
+func (mr *MockTodoRepositoryMockRecorder) ImportReminds(ctx, reminds, dryRun interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRemindsForNotification", reflect.TypeOf((*MockTodoRepository)(nil).GetRemindsForNotification), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportReminds", reflect.TypeOf((*MockTodoRepository)(nil).ImportReminds), ctx, reminds, dryRun)
 }
 
-// UpdateNotification mocks base method.
-func (m *MockTodoRepository) UpdateNotification(ctx context.Context, id int, dao domain.NotificationDAO) error {
+// MarkNotificationFailed mocks base method.
+func (m *MockTodoRepository) MarkNotificationFailed(ctx context.Context, id int64, workerID string, failedAt time.Time, lastError string, retryAt *time.Time) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateNotification", ctx, id, dao)
+	ret := m.ctrl.Call(m, "MarkNotificationFailed", ctx, id, workerID, failedAt, lastError, retryAt)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// UpdateNotification indicates an expected call of UpdateNotification.
-func (mr *MockTodoRepositoryMockRecorder) UpdateNotification(ctx, id, dao interface{}) *gomock.Call {
+// MarkNotificationFailed indicates an expected call of MarkNotificationFailed.
+func (mr *MockTodoRepositoryMockRecorder) MarkNotificationFailed(ctx, id, workerID, failedAt, lastError, retryAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkNotificationFailed", reflect.TypeOf((*MockTodoRepository)(nil).MarkNotificationFailed), ctx, id, workerID, failedAt, lastError, retryAt)
+}
+
+// MarkNotificationSent mocks base method.
+func (m *MockTodoRepository) MarkNotificationSent(ctx context.Context, id int64, workerID string, sentAt time.Time, outbox []domain.OutboxMessage) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkNotificationSent", ctx, id, workerID, sentAt, outbox)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkNotificationSent indicates an expected call of MarkNotificationSent.
+func (mr *MockTodoRepositoryMockRecorder) MarkNotificationSent(ctx, id, workerID, sentAt, outbox interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkNotificationSent", reflect.TypeOf((*MockTodoRepository)(nil).MarkNotificationSent), ctx, id, workerID, sentAt, outbox)
+}
+
+// MarkOutboxFailed mocks base method.
+func (m *MockTodoRepository) MarkOutboxFailed(ctx context.Context, id int64, workerID string, failedAt time.Time, lastError string, retryAt *time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkOutboxFailed", ctx, id, workerID, failedAt, lastError, retryAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkOutboxFailed indicates an expected call of MarkOutboxFailed.
+func (mr *MockTodoRepositoryMockRecorder) MarkOutboxFailed(ctx, id, workerID, failedAt, lastError, retryAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkOutboxFailed", reflect.TypeOf((*MockTodoRepository)(nil).MarkOutboxFailed), ctx, id, workerID, failedAt, lastError, retryAt)
+}
+
+// MarkOutboxSent mocks base method.
+func (m *MockTodoRepository) MarkOutboxSent(ctx context.Context, id int64, workerID string, sentAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkOutboxSent", ctx, id, workerID, sentAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkOutboxSent indicates an expected call of MarkOutboxSent.
+func (mr *MockTodoRepositoryMockRecorder) MarkOutboxSent(ctx, id, workerID, sentAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkOutboxSent", reflect.TypeOf((*MockTodoRepository)(nil).MarkOutboxSent), ctx, id, workerID, sentAt)
+}
+
+// NextNotificationAt mocks base method.
+func (m *MockTodoRepository) NextNotificationAt(ctx context.Context, now time.Time) (*time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NextNotificationAt", ctx, now)
+	ret0, _ := ret[0].(*time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NextNotificationAt indicates an expected call of NextNotificationAt.
+func (mr *MockTodoRepositoryMockRecorder) NextNotificationAt(ctx, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NextNotificationAt", reflect.TypeOf((*MockTodoRepository)(nil).NextNotificationAt), ctx, now)
+}
+
+// PatchRemind mocks base method.
+func (m *MockTodoRepository) PatchRemind(ctx context.Context, id int, userID string, patch domain.TodoPatch) (domain.Todo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PatchRemind", ctx, id, userID, patch)
+	ret0, _ := ret[0].(domain.Todo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PatchRemind indicates an expected call of PatchRemind.
+func (mr *MockTodoRepositoryMockRecorder) PatchRemind(ctx, id, userID, patch interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PatchRemind", reflect.TypeOf((*MockTodoRepository)(nil).PatchRemind), ctx, id, userID, patch)
+}
+
+// PurgeRemind mocks base method.
+func (m *MockTodoRepository) PurgeRemind(ctx context.Context, id int, userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeRemind", ctx, id, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PurgeRemind indicates an expected call of PurgeRemind.
+func (mr *MockTodoRepositoryMockRecorder) PurgeRemind(ctx, id, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeRemind", reflect.TypeOf((*MockTodoRepository)(nil).PurgeRemind), ctx, id, userID)
+}
+
+// PurgeTrash mocks base method.
+func (m *MockTodoRepository) PurgeTrash(ctx context.Context, before time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeTrash", ctx, before)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeTrash indicates an expected call of PurgeTrash.
+func (mr *MockTodoRepositoryMockRecorder) PurgeTrash(ctx, before interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeTrash", reflect.TypeOf((*MockTodoRepository)(nil).PurgeTrash), ctx, before)
+}
+
+// ResolveInvites mocks base method.
+func (m *MockTodoRepository) ResolveInvites(ctx context.Context, userID, email string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveInvites", ctx, userID, email)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResolveInvites indicates an expected call of ResolveInvites.
+func (mr *MockTodoRepositoryMockRecorder) ResolveInvites(ctx, userID, email interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveInvites", reflect.TypeOf((*MockTodoRepository)(nil).ResolveInvites), ctx, userID, email)
+}
+
+// RestoreRemind mocks base method.
+func (m *MockTodoRepository) RestoreRemind(ctx context.Context, id int, userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreRemind", ctx, id, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreRemind indicates an expected call of RestoreRemind.
+func (mr *MockTodoRepositoryMockRecorder) RestoreRemind(ctx, id, userID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateNotification", reflect.TypeOf((*MockTodoRepository)(nil).UpdateNotification), ctx, id, dao)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreRemind", reflect.TypeOf((*MockTodoRepository)(nil).RestoreRemind), ctx, id, userID)
 }
 
-// UpdateNotifyPeriod mocks base method.
-func (m *MockTodoRepository) UpdateNotifyPeriod(ctx context.Context, id int, timeToDelete string) error {
+// RetryNotification mocks base method.
+func (m *MockTodoRepository) RetryNotification(ctx context.Context, id int64, now time.Time) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateNotifyPeriod", ctx, id, timeToDelete)
+	ret := m.ctrl.Call(m, "RetryNotification", ctx, id, now)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// UpdateNotifyPeriod indicates an expected call of UpdateNotifyPeriod.
-func (mr *MockTodoRepositoryMockRecorder) UpdateNotifyPeriod(ctx, id, timeToDelete interface{}) *gomock.Call {
+// RetryNotification indicates an expected call of RetryNotification.
+func (mr *MockTodoRepositoryMockRecorder) RetryNotification(ctx, id, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RetryNotification", reflect.TypeOf((*MockTodoRepository)(nil).RetryNotification), ctx, id, now)
+}
+
+// RetryOutbox mocks base method.
+func (m *MockTodoRepository) RetryOutbox(ctx context.Context, id int64, now time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RetryOutbox", ctx, id, now)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RetryOutbox indicates an expected call of RetryOutbox.
+func (mr *MockTodoRepositoryMockRecorder) RetryOutbox(ctx, id, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RetryOutbox", reflect.TypeOf((*MockTodoRepository)(nil).RetryOutbox), ctx, id, now)
+}
+
+// ScheduleNotifications mocks base method.
+func (m *MockTodoRepository) ScheduleNotifications(ctx context.Context, now time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ScheduleNotifications", ctx, now)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ScheduleNotifications indicates an expected call of ScheduleNotifications.
+func (mr *MockTodoRepositoryMockRecorder) ScheduleNotifications(ctx, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ScheduleNotifications", reflect.TypeOf((*MockTodoRepository)(nil).ScheduleNotifications), ctx, now)
+}
+
+// UpdateEscalation mocks base method.
+func (m *MockTodoRepository) UpdateEscalation(ctx context.Context, id, escalations int, escalatedAt, nextAt time.Time, outbox []domain.OutboxMessage) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateEscalation", ctx, id, escalations, escalatedAt, nextAt, outbox)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateEscalation indicates an expected call of UpdateEscalation.
+func (mr *MockTodoRepositoryMockRecorder) UpdateEscalation(ctx, id, escalations, escalatedAt, nextAt, outbox interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateEscalation", reflect.TypeOf((*MockTodoRepository)(nil).UpdateEscalation), ctx, id, escalations, escalatedAt, nextAt, outbox)
+}
+
+// UpdateItem mocks base method.
+func (m *MockTodoRepository) UpdateItem(ctx context.Context, todoID, itemID int, userID string, input domain.TodoItemInput) (domain.TodoItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateItem", ctx, todoID, itemID, userID, input)
+	ret0, _ := ret[0].(domain.TodoItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateItem indicates an expected call of UpdateItem.
+func (mr *MockTodoRepositoryMockRecorder) UpdateItem(ctx, todoID, itemID, userID, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateItem", reflect.TypeOf((*MockTodoRepository)(nil).UpdateItem), ctx, todoID, itemID, userID, input)
+}
+
+// UpdateMember mocks base method.
+func (m *MockTodoRepository) UpdateMember(ctx context.Context, todoID, memberID int, userID string, input domain.MemberInput) (domain.Member, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateMember", ctx, todoID, memberID, userID, input)
+	ret0, _ := ret[0].(domain.Member)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateMember indicates an expected call of UpdateMember.
+func (mr *MockTodoRepositoryMockRecorder) UpdateMember(ctx, todoID, memberID, userID, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMember", reflect.TypeOf((*MockTodoRepository)(nil).UpdateMember), ctx, todoID, memberID, userID, input)
+}
+
+// UpdateNotification mocks base method.
+func (m *MockTodoRepository) UpdateNotification(ctx context.Context, id int, userID string, dao domain.NotificationDAO) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateNotification", ctx, id, userID, dao)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateNotification indicates an expected call of UpdateNotification.
+func (mr *MockTodoRepositoryMockRecorder) UpdateNotification(ctx, id, userID, dao interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateNotifyPeriod", reflect.TypeOf((*MockTodoRepository)(nil).UpdateNotifyPeriod), ctx, id, timeToDelete)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateNotification", reflect.TypeOf((*MockTodoRepository)(nil).UpdateNotification), ctx, id, userID, dao)
 }
 
 // UpdateRemind mocks base method.
-func (m *MockTodoRepository) UpdateRemind(ctx context.Context, id int, input domain.TodoUpdateInput) (domain.Todo, error) {
+func (m *MockTodoRepository) UpdateRemind(ctx context.Context, id int, userID string, input domain.TodoUpdateInput) (domain.Todo, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateRemind", ctx, id, input)
+	ret := m.ctrl.Call(m, "UpdateRemind", ctx, id, userID, input)
 	ret0, _ := ret[0].(domain.Todo)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // UpdateRemind indicates an expected call of UpdateRemind.
-func (mr *MockTodoRepositoryMockRecorder) UpdateRemind(ctx, id, input interface{}) *gomock.Call {
+func (mr *MockTodoRepositoryMockRecorder) UpdateRemind(ctx, id, userID, input interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRemind", reflect.TypeOf((*MockTodoRepository)(nil).UpdateRemind), ctx, id, input)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRemind", reflect.TypeOf((*MockTodoRepository)(nil).UpdateRemind), ctx, id, userID, input)
 }
 
 // UpdateStatus mocks base method.
-func (m *MockTodoRepository) UpdateStatus(ctx context.Context, id int, updateInput domain.TodoUpdateStatusInput) error {
+func (m *MockTodoRepository) UpdateStatus(ctx context.Context, id int, userID string, updateInput domain.TodoUpdateStatusInput) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateStatus", ctx, id, updateInput)
+	ret := m.ctrl.Call(m, "UpdateStatus", ctx, id, userID, updateInput)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateStatus indicates an expected call of UpdateStatus.
-func (mr *MockTodoRepositoryMockRecorder) UpdateStatus(ctx, id, updateInput interface{}) *gomock.Call {
+func (mr *MockTodoRepositoryMockRecorder) UpdateStatus(ctx, id, userID, updateInput interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatus", reflect.TypeOf((*MockTodoRepository)(nil).UpdateStatus), ctx, id, updateInput)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatus", reflect.TypeOf((*MockTodoRepository)(nil).UpdateStatus), ctx, id, userID, updateInput)
 }
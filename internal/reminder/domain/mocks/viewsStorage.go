@@ -0,0 +1,110 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: views.go
+
+// Package mock_domain is a generated GoMock package.
+package mock_domain
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	domain "github.com/red-rocket-software/reminder-go/internal/reminder/domain"
+)
+
+// MockViewRepository is a mock of ViewRepository interface.
+type MockViewRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockViewRepositoryMockRecorder
+}
+
+// MockViewRepositoryMockRecorder is the mock recorder for MockViewRepository.
+type MockViewRepositoryMockRecorder struct {
+	mock *MockViewRepository
+}
+
+// NewMockViewRepository creates a new mock instance.
+func NewMockViewRepository(ctrl *gomock.Controller) *MockViewRepository {
+	mock := &MockViewRepository{ctrl: ctrl}
+	mock.recorder = &MockViewRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockViewRepository) EXPECT() *MockViewRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateView mocks base method.
+func (m *MockViewRepository) CreateView(ctx context.Context, userID string, input domain.ViewInput) (domain.View, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateView", ctx, userID, input)
+	ret0, _ := ret[0].(domain.View)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateView indicates an expected call of CreateView.
+func (mr *MockViewRepositoryMockRecorder) CreateView(ctx, userID, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateView", reflect.TypeOf((*MockViewRepository)(nil).CreateView), ctx, userID, input)
+}
+
+// DeleteView mocks base method.
+func (m *MockViewRepository) DeleteView(ctx context.Context, id int, userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteView", ctx, id, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteView indicates an expected call of DeleteView.
+func (mr *MockViewRepositoryMockRecorder) DeleteView(ctx, id, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteView", reflect.TypeOf((*MockViewRepository)(nil).DeleteView), ctx, id, userID)
+}
+
+// GetView mocks base method.
+func (m *MockViewRepository) GetView(ctx context.Context, id int, userID string) (domain.View, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetView", ctx, id, userID)
+	ret0, _ := ret[0].(domain.View)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetView indicates an expected call of GetView.
+func (mr *MockViewRepositoryMockRecorder) GetView(ctx, id, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetView", reflect.TypeOf((*MockViewRepository)(nil).GetView), ctx, id, userID)
+}
+
+// GetViews mocks base method.
+func (m *MockViewRepository) GetViews(ctx context.Context, userID string) ([]domain.View, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetViews", ctx, userID)
+	ret0, _ := ret[0].([]domain.View)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetViews indicates an expected call of GetViews.
+func (mr *MockViewRepositoryMockRecorder) GetViews(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetViews", reflect.TypeOf((*MockViewRepository)(nil).GetViews), ctx, userID)
+}
+
+// UpdateView mocks base method.
+func (m *MockViewRepository) UpdateView(ctx context.Context, id int, userID string, input domain.ViewInput) (domain.View, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateView", ctx, id, userID, input)
+	ret0, _ := ret[0].(domain.View)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateView indicates an expected call of UpdateView.
+func (mr *MockViewRepositoryMockRecorder) UpdateView(ctx, id, userID, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateView", reflect.TypeOf((*MockViewRepository)(nil).UpdateView), ctx, id, userID, input)
+}
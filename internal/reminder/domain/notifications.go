@@ -0,0 +1,59 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+var ErrCantFindNotificationWithID = errors.New("can't find notification")
+var ErrCantFindOutboxMessageWithID = errors.New("can't find outbox message")
+
+// ErrNotificationNotLeased is returned when worker marks notification or outbox message which is leased by another
+// worker or already sent
+var ErrNotificationNotLeased = errors.New("notification isn't leased by the worker")
+
+// Kinds of queued notifications
+const (
+	// NotificationPeriod is sent Period days of user configs before the deadline if the user enabled notifications
+	NotificationPeriod = "period"
+	// NotificationDeadline is sent at each time of remind NotifyPeriod if DeadlineNotify is enabled
+	NotificationDeadline = "deadline"
+)
+
+// Notification is a queued notification about remind which is due at DueAt and isn't sent until SentAt is set.
+// Worker which sends it leases it until LeasedUntil, then other workers can send it. Failed notification is retried
+// at NextAttemptAt, after the last attempt it is dead since DeadAt
+type Notification struct {
+	ID            int64              `json:"id"`
+	Kind          string             `json:"kind"`
+	DueAt         time.Time          `json:"due_at"`
+	SentAt        *time.Time         `json:"sent_at"`
+	LeasedBy      string             `json:"leased_by,omitempty"`
+	LeasedUntil   *time.Time         `json:"leased_until,omitempty"`
+	Attempts      int                `json:"attempts"`
+	LastError     string             `json:"last_error,omitempty"`
+	NextAttemptAt *time.Time         `json:"next_attempt_at,omitempty"`
+	DeadAt        *time.Time         `json:"dead_at,omitempty"`
+	Remind        NotificationRemind `json:"remind"`
+}
+
+// OutboxMessage is a delivery of notification to the user by one of their channels. It is added to the outbox
+// together with the change of notification or remind state and delivered by the worker at least once, repeated
+// deliveries have the same IdempotencyKey. Payload has the recipient and the message, it isn't returned to admins
+// because channel addresses can have secrets in them
+type OutboxMessage struct {
+	ID             int64      `json:"id"`
+	IdempotencyKey string     `json:"idempotency_key"`
+	TodoID         int        `json:"todo_id"`
+	UserID         string     `json:"user_id"`
+	Channel        string     `json:"channel"`
+	Payload        []byte     `json:"-"`
+	CreatedAt      time.Time  `json:"created_at"`
+	SentAt         *time.Time `json:"sent_at"`
+	LeasedBy       string     `json:"leased_by,omitempty"`
+	LeasedUntil    *time.Time `json:"leased_until,omitempty"`
+	Attempts       int        `json:"attempts"`
+	LastError      string     `json:"last_error,omitempty"`
+	NextAttemptAt  *time.Time `json:"next_attempt_at,omitempty"`
+	DeadAt         *time.Time `json:"dead_at,omitempty"`
+}
@@ -0,0 +1,74 @@
+package domain
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+)
+
+var ErrInvalidPriority = errors.New("priority should be none, low, medium, high or critical")
+
+// Priority is importance of remind. Reminds are sorted by it in this order, so
+// new levels should be added in the right place with the migration of stored values
+type Priority int
+
+const (
+	PriorityNone Priority = iota
+	PriorityLow
+	PriorityMedium
+	PriorityHigh
+	PriorityCritical
+)
+
+var priorityNames = []string{"none", "low", "medium", "high", "critical"}
+
+// ParsePriority returns Priority by its name
+func ParsePriority(s string) (Priority, error) {
+	for i, name := range priorityNames {
+		if name == s {
+			return Priority(i), nil
+		}
+	}
+	return PriorityNone, fmt.Errorf("%w: %q", ErrInvalidPriority, s)
+}
+
+func (p Priority) String() string {
+	if p < PriorityNone || int(p) >= len(priorityNames) {
+		return fmt.Sprintf("Priority(%d)", int(p))
+	}
+	return priorityNames[p]
+}
+
+// MarshalText encodes priority by name, so in JSON it looks like "high"
+func (p Priority) MarshalText() ([]byte, error) {
+	if p < PriorityNone || int(p) >= len(priorityNames) {
+		return nil, fmt.Errorf("%w: %d", ErrInvalidPriority, int(p))
+	}
+	return []byte(priorityNames[p]), nil
+}
+
+func (p *Priority) UnmarshalText(text []byte) error {
+	parsed, err := ParsePriority(string(text))
+	if err != nil {
+		return err
+	}
+	*p = parsed
+	return nil
+}
+
+// Value stores priority as number to keep sorting by importance in database
+func (p Priority) Value() (driver.Value, error) {
+	return int64(p), nil
+}
+
+func (p *Priority) Scan(src any) error {
+	switch v := src.(type) {
+	case int64:
+		*p = Priority(v)
+	case nil:
+		*p = PriorityNone
+	default:
+		return fmt.Errorf("can't scan %T into Priority", src)
+	}
+	return nil
+}
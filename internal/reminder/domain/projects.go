@@ -0,0 +1,43 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var (
+	ErrCantFindProjectWithID = errors.New("can't find project")
+	ErrProjectArchived       = errors.New("project is archived")
+)
+
+// Project is a named list of reminds
+type Project struct {
+	ID       int     `json:"id"`
+	UserID   string  `json:"user_id"`
+	Name     string  `json:"name"`
+	Color    *string `json:"color"`
+	Position int     `json:"position"`
+	// ArchivedAt is set when project is archived. Reminds of archived project are hidden
+	// from the list of reminds (unless it's filtered by the project) and aren't notified
+	ArchivedAt *time.Time `json:"archived_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+type ProjectInput struct {
+	Name  string  `json:"name"`
+	Color *string `json:"color"`
+	// Position is put after the last project if it's omitted on create and isn't changed on update
+	Position *int `json:"position"`
+	Archived bool `json:"archived"`
+}
+
+//go:generate mockgen -source=projects.go -destination=mocks/projectsStorage.go
+type ProjectRepository interface {
+	GetProjects(ctx context.Context, userID string, withArchived bool) ([]Project, error)
+	CreateProject(ctx context.Context, userID string, input ProjectInput) (Project, error)
+	UpdateProject(ctx context.Context, id int, userID string, input ProjectInput) (Project, error)
+	// DeleteProject deletes project. Reminds of the project are deleted with it if withReminds is true,
+	// otherwise they are kept without project
+	DeleteProject(ctx context.Context, id int, userID string, withReminds bool) error
+}
@@ -0,0 +1,8 @@
+package domain
+
+// Highlight is title and description of remind found by search, matched words are wrapped in <mark></mark>
+// and the rest of the text is HTML escaped. Description is cut to fragments around matched words
+type Highlight struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
+}
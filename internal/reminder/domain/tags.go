@@ -0,0 +1,41 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var (
+	ErrCantFindTagWithID = errors.New("can't find tag")
+	ErrTagAlreadyExists  = errors.New("tag with such name already exists")
+)
+
+// Tags matching modes for FetchParams.TagsMatch
+const (
+	// TagsMatchAny selects reminds which have at least one of tags
+	TagsMatchAny = "any"
+	// TagsMatchAll selects reminds which have all tags
+	TagsMatchAll = "all"
+)
+
+type Tag struct {
+	ID        int       `json:"id"`
+	UserID    string    `json:"user_id"`
+	Name      string    `json:"name"`
+	Color     *string   `json:"color"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type TagInput struct {
+	Name  string  `json:"name"`
+	Color *string `json:"color"`
+}
+
+//go:generate mockgen -source=tags.go -destination=mocks/tagsStorage.go
+type TagRepository interface {
+	GetTags(ctx context.Context, userID string) ([]Tag, error)
+	CreateTag(ctx context.Context, userID string, input TagInput) (Tag, error)
+	UpdateTag(ctx context.Context, id int, userID string, input TagInput) (Tag, error)
+	DeleteTag(ctx context.Context, id int, userID string) error
+}
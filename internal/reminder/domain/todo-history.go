@@ -0,0 +1,36 @@
+package domain
+
+import "time"
+
+// ActorSystem is an actor of changes made by workers, e.g. notifier or trash purge
+const ActorSystem = "system"
+
+// HistoryAction is a kind of remind change
+type HistoryAction string
+
+const (
+	HistoryCreate       HistoryAction = "create"
+	HistoryUpdate       HistoryAction = "update"
+	HistoryStatus       HistoryAction = "status"
+	HistoryNotification HistoryAction = "notification"
+	HistoryDelete       HistoryAction = "delete"
+	HistoryRestore      HistoryAction = "restore"
+	HistoryPurge        HistoryAction = "purge"
+)
+
+// FieldChange is a value of remind field before and after the change
+type FieldChange struct {
+	From any `json:"from"`
+	To   any `json:"to"`
+}
+
+// HistoryEntry is a change of remind. Entries are kept after the remind is purged
+type HistoryEntry struct {
+	ID     int64         `json:"id"`
+	TodoID int           `json:"todo_id"`
+	Actor  string        `json:"actor"`
+	Action HistoryAction `json:"action"`
+	// Changes are keyed by json name of remind field
+	Changes   map[string]FieldChange `json:"changes"`
+	CreatedAt time.Time              `json:"created_at"`
+}
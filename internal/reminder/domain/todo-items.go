@@ -0,0 +1,44 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrCantFindItemWithID = errors.New("can't find checklist item")
+	ErrIncompleteItems    = errors.New("remind has incomplete checklist items")
+)
+
+// TodoItem is a checklist item of remind
+type TodoItem struct {
+	ID         int        `json:"id"`
+	TodoID     int        `json:"todo_id"`
+	Title      string     `json:"title"`
+	Completed  bool       `json:"completed"`
+	DeadlineAt *time.Time `json:"deadline_at"`
+	Position   int        `json:"position"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+type TodoItemInput struct {
+	Title      string  `json:"title"`
+	Completed  bool    `json:"completed"`
+	DeadlineAt *string `json:"deadline_at"`
+	// Position is the place of item in checklist. Item is added to the end if Position is empty
+	Position *int `json:"position"`
+}
+
+// Progress describes how many checklist items of remind are done
+type Progress struct {
+	Done  int `json:"done"`
+	Total int `json:"total"`
+}
+
+// ChildrenOnComplete describes what to do with checklist items when remind gets completed
+const (
+	// ChildrenComplete completes all checklist items together with the remind
+	ChildrenComplete = "complete"
+	// ChildrenBlock forbids to complete remind while it has incomplete checklist items
+	ChildrenBlock = "block"
+)
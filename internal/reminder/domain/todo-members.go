@@ -0,0 +1,57 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrCantFindMemberWithID = errors.New("can't find member")
+	ErrMemberAlreadyExists  = errors.New("remind is already shared with this user")
+	ErrInvalidRole          = errors.New("role should be viewer, editor or owner")
+)
+
+// Role is a permission of remind member. The remind author always has owner role
+type Role string
+
+const (
+	// RoleViewer can read remind and its checklist
+	RoleViewer Role = "viewer"
+	// RoleEditor can also change remind and its checklist
+	RoleEditor Role = "editor"
+	// RoleOwner can also delete remind and manage its members
+	RoleOwner Role = "owner"
+)
+
+var roleLevels = map[Role]int{RoleViewer: 1, RoleEditor: 2, RoleOwner: 3}
+
+func (r Role) Valid() bool {
+	_, ok := roleLevels[r]
+	return ok
+}
+
+// Includes reports whether role has all permissions of other role
+func (r Role) Includes(other Role) bool {
+	return roleLevels[r] >= roleLevels[other]
+}
+
+// Member is a user the remind is shared with. Invite to not yet registered email
+// has only Email and gets UserID on the first login of the user
+type Member struct {
+	ID        int       `json:"id"`
+	TodoID    int       `json:"todo_id"`
+	UserID    *string   `json:"user_id"`
+	Email     *string   `json:"email"`
+	Role      Role      `json:"role"`
+	Notify    bool      `json:"notify"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type MemberInput struct {
+	// UserID is Firebase UID, either it or Email is required on create
+	UserID *string `json:"user_id"`
+	Email  *string `json:"email"`
+	Role   Role    `json:"role"`
+	// Notify means that member gets remind notifications, true by default
+	Notify *bool `json:"notify"`
+}
@@ -0,0 +1,90 @@
+package domain
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+var ErrInvalidPatch = errors.New("invalid merge patch")
+
+// TodoPatch is JSON merge patch (RFC 7396) of remind. Only fields present in the patch are changed.
+// null removes value of optional fields, e.g. "project_id": null removes remind from its project
+type TodoPatch struct {
+	Title          *string
+	Description    *string
+	DeadlineAt     *time.Time
+	Completed      *bool
+	DeadlineNotify *bool
+	NotifyPeriod   *[]time.Time
+	Priority       *Priority
+	// ProjectID 0 removes remind from its project
+	ProjectID *int
+	// RRule "" makes remind not recurring
+	RRule               *string
+	RecurFromCompletion *bool
+	// Tags are ids of user tags, empty list removes all tags
+	Tags *[]int
+	// FinishedAt is set by server together with Completed
+	FinishedAt *time.Time
+	// Version is expected version of remind from If-Match header, 0 updates any version
+	Version int
+}
+
+// patchField is a field of TodoPatch with JSON value which is used when the field is null,
+// fields without null value can't be removed
+type patchField struct {
+	target any
+	null   string
+}
+
+func (p *TodoPatch) fields() map[string]patchField {
+	return map[string]patchField{
+		"title":                 {target: &p.Title},
+		"description":           {target: &p.Description},
+		"deadline_at":           {target: &p.DeadlineAt},
+		"completed":             {target: &p.Completed},
+		"recur_from_completion": {target: &p.RecurFromCompletion},
+		"deadline_notify":       {target: &p.DeadlineNotify, null: `false`},
+		"notify_period":         {target: &p.NotifyPeriod, null: `[]`},
+		"priority":              {target: &p.Priority, null: `"none"`},
+		"project_id":            {target: &p.ProjectID, null: `0`},
+		"rrule":                 {target: &p.RRule, null: `""`},
+		"tags":                  {target: &p.Tags, null: `[]`},
+	}
+}
+
+// UnmarshalJSON decodes merge patch object, unknown fields are rejected to not ignore typos silently
+func (p *TodoPatch) UnmarshalJSON(data []byte) error {
+	var values map[string]json.RawMessage
+	if err := json.Unmarshal(data, &values); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidPatch, err)
+	}
+	if values == nil {
+		return fmt.Errorf("%w: patch should be an object", ErrInvalidPatch)
+	}
+
+	fields := p.fields()
+
+	for name, value := range values {
+		field, ok := fields[name]
+		if !ok {
+			return fmt.Errorf("%w: unknown field %q", ErrInvalidPatch, name)
+		}
+
+		if bytes.Equal(bytes.TrimSpace(value), []byte("null")) {
+			if field.null == "" {
+				return fmt.Errorf("%w: %q can't be null", ErrInvalidPatch, name)
+			}
+			value = json.RawMessage(field.null)
+		}
+
+		if err := json.Unmarshal(value, field.target); err != nil {
+			return fmt.Errorf("%w: %q: %v", ErrInvalidPatch, name, err)
+		}
+	}
+
+	return nil
+}
@@ -11,6 +11,9 @@ import (
 var (
 	ErrDeleteFailed         = errors.New("error delete remind")
 	ErrCantFindRemindWithID = errors.New("can't find remind")
+	ErrInvalidRRule         = errors.New("invalid recurrence rule")
+	// ErrVersionMismatch is returned when entity was changed since the client got the given version of it
+	ErrVersionMismatch = errors.New("version doesn't match, entity was changed")
 )
 
 type Todo struct {
@@ -25,26 +28,68 @@ type Todo struct {
 	Notificated    bool        `json:"notificated"`
 	DeadlineNotify *bool       `json:"deadline_notify"`
 	NotifyPeriod   []time.Time `json:"notify_period"`
+	// NotifyOffsets are seconds from NotifyPeriod to DeadlineAt. NotifyPeriod is emptied while notifications
+	// are sent, offsets are kept to rebuild it for the next occurrences of recurring remind
+	NotifyOffsets []int64  `json:"-"`
+	Priority      Priority `json:"priority"`
+	ProjectID     *int     `json:"project_id"`
+	// RRule is RFC 5545 recurrence rule, e.g. "FREQ=WEEKLY;BYDAY=MO"
+	RRule *string `json:"rrule"`
+	// RecurFromCompletion counts next occurrence from the completion date instead of the deadline
+	RecurFromCompletion bool `json:"recur_from_completion"`
+	// DeletedAt is set while remind is in trash
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+	// Version is incremented on every update of remind, it's returned as ETag
+	Version int `json:"version"`
+	// OverdueDismissedAt is set when user stops overdue notifications of remind, they are resumed
+	// when the deadline is moved after it
+	OverdueDismissedAt *time.Time `json:"overdue_dismissed_at,omitempty"`
+	// Overdue is filled by GetReminds and GetRemindByID, see IsOverdue
+	Overdue bool `json:"overdue"`
+	// Occurrences are filled by GetReminds when FetchParams.Occurrences range is set
+	Occurrences []time.Time `json:"occurrences,omitempty"`
+	Progress    Progress    `json:"progress"`
+	// Highlight is filled by GetReminds when search query is given
+	Highlight *Highlight `json:"highlight,omitempty"`
+	// Items are filled only by GetRemindByID
+	Items []TodoItem `json:"items,omitempty"`
+	Tags  []Tag      `json:"tags"`
 }
 
 type TodoInput struct {
-	Title          string   `json:"title"`
-	Description    string   `json:"description"`
-	DeadlineAt     string   `json:"deadline_at"`
-	CreatedAt      string   `json:"created_at"`
-	DeadlineNotify *bool    `json:"deadline_notify"`
-	NotifyPeriod   []string `json:"notify_period"`
+	Title               string   `json:"title"`
+	Description         string   `json:"description"`
+	DeadlineAt          string   `json:"deadline_at"`
+	CreatedAt           string   `json:"created_at"`
+	DeadlineNotify      *bool    `json:"deadline_notify"`
+	NotifyPeriod        []string `json:"notify_period"`
+	Priority            Priority `json:"priority"`
+	ProjectID           *int     `json:"project_id"`
+	RRule               *string  `json:"rrule"`
+	RecurFromCompletion bool     `json:"recur_from_completion"`
+	// Tags are ids of user tags
+	Tags []int `json:"tags"`
 }
 
 type TodoUpdateInput struct {
-	Title          string     `json:"title"`
-	Description    string     `json:"description"`
-	FinishedAt     *time.Time `json:"finished_at,omitempty"`
-	Completed      bool       `json:"completed"`
-	Notificated    bool       `json:"notificated"`
-	DeadlineAt     string     `json:"deadline_at"`
-	DeadlineNotify *bool      `json:"deadline_notify"`
-	NotifyPeriod   []string   `json:"notify_period"`
+	Title               string     `json:"title"`
+	Description         string     `json:"description"`
+	FinishedAt          *time.Time `json:"finished_at,omitempty"`
+	Completed           bool       `json:"completed"`
+	Notificated         bool       `json:"notificated"`
+	DeadlineAt          string     `json:"deadline_at"`
+	DeadlineNotify      *bool      `json:"deadline_notify"`
+	NotifyPeriod        []string   `json:"notify_period"`
+	RRule               *string    `json:"rrule"`
+	RecurFromCompletion bool       `json:"recur_from_completion"`
+	// ProjectID isn't changed if field is omitted, 0 removes remind from its project
+	ProjectID *int `json:"project_id"`
+	// Priority isn't changed if field is omitted
+	Priority *Priority `json:"priority"`
+	// Tags are ids of user tags. Tags aren't changed if field is omitted, empty array removes all tags
+	Tags []int `json:"tags"`
+	// Version is expected version of remind from If-Match header, 0 updates any version
+	Version int `json:"-"`
 }
 
 type TodoResponse struct {
@@ -56,6 +101,10 @@ type TodoResponse struct {
 type TodoUpdateStatusInput struct {
 	Completed  bool       `json:"completed"`
 	FinishedAt *time.Time `json:"finished_at,omitempty"`
+	// Children is "complete" or "block" (see ChildrenComplete and ChildrenBlock), empty value ignores checklist items
+	Children string `json:"children,omitempty"`
+	// Version is expected version of remind from If-Match header, 0 updates any version
+	Version int `json:"-"`
 }
 
 type NotificationRemind struct {
@@ -64,6 +113,11 @@ type NotificationRemind struct {
 	Description string    `json:"description"`
 	DeadlineAt  time.Time `json:"deadline_at"`
 	UserID      string    `json:"user_id"`
+	Priority    Priority  `json:"priority"`
+	// MemberIDs are members of shared remind who get its notifications too
+	MemberIDs []string `json:"member_ids"`
+	// Escalations is number of overdue notifications sent since the current deadline
+	Escalations int `json:"escalations"`
 }
 
 type NotificationDAO struct {
@@ -78,22 +132,106 @@ type TimeRangeFilter struct {
 type FetchParams struct {
 	utils.Page
 	TimeRangeFilter
-	FilterByDate  string //createdAt or deadlineAt
+	FilterByDate  string //CreatedAt, DeadlineAt or Priority
 	FilterBySort  string // ASC or DESC
-	FilterByQuery string // current, all or completed
-
+	FilterByQuery string // current, all, completed or overdue
+	// Occurrences is a range (RFC3339) to expand recurring reminds in
+	Occurrences TimeRangeFilter
+	// Tags filters reminds by tag ids, TagsMatch is "any" or "all"
+	Tags      []int
+	TagsMatch string
+	// ProjectID filters reminds by project, 0 means reminds of all not archived projects
+	ProjectID int
+	// Search is full-text search query over title and description. Reminds are ordered by relevance
+	// when FilterByDate is empty
+	Search string
+	// Deadline filters reminds by deadline (RFC3339 in UTC), StartRange is inclusive and EndRange is exclusive,
+	// either of them can be empty
+	Deadline TimeRangeFilter
 }
 
 //go:generate mockgen -source=todo.go -destination=mocks/todoStorage.go
 type TodoRepository interface {
 	GetReminds(ctx context.Context, params FetchParams, userID string) ([]Todo, int, int, error)
 	CreateRemind(ctx context.Context, todo Todo) (Todo, error)
-	UpdateRemind(ctx context.Context, id int, input TodoUpdateInput) (Todo, error)
-	UpdateStatus(ctx context.Context, id int, updateInput TodoUpdateStatusInput) error
-	UpdateNotification(ctx context.Context, id int, dao NotificationDAO) error
-	DeleteRemind(ctx context.Context, id int) error
-	GetRemindByID(ctx context.Context, id int) (Todo, error)
-	UpdateNotifyPeriod(ctx context.Context, id int, timeToDelete string) error
-	GetRemindsForNotification(ctx context.Context) ([]NotificationRemind, error)
-	GetRemindsForDeadlineNotification(ctx context.Context) ([]NotificationRemind, string, error)
+	UpdateRemind(ctx context.Context, id int, userID string, input TodoUpdateInput) (Todo, error)
+	// PatchRemind changes only fields present in the patch and returns reloaded remind
+	PatchRemind(ctx context.Context, id int, userID string, patch TodoPatch) (Todo, error)
+	UpdateStatus(ctx context.Context, id int, userID string, updateInput TodoUpdateStatusInput) error
+	// UpdateNotification is used by notifier, userID is the remind author
+	UpdateNotification(ctx context.Context, id int, userID string, dao NotificationDAO) error
+	// DeleteRemind moves remind to trash
+	DeleteRemind(ctx context.Context, id int, userID string) error
+	// BulkUpdate applies action to each remind in one transaction and returns result for each of them
+	BulkUpdate(ctx context.Context, userID string, input BulkInput) ([]BulkResult, error)
+	// ImportReminds creates reminds in one transaction and returns result for each of them in the same order,
+	// dry run checks reminds and rolls the transaction back
+	ImportReminds(ctx context.Context, reminds []Todo, dryRun bool) ([]BulkResult, error)
+	// ExportReminds calls fn for each remind owned by user, including completed and trashed ones,
+	// reminds are read from database one by one and have only ids of their tags
+	ExportReminds(ctx context.Context, userID string, fn func(Todo) error) error
+	// ExportItems calls fn for each checklist item of reminds owned by user
+	ExportItems(ctx context.Context, userID string, fn func(TodoItem) error) error
+	GetTrash(ctx context.Context, userID string) ([]Todo, error)
+	RestoreRemind(ctx context.Context, id int, userID string) error
+	// PurgeRemind deletes remind from trash permanently
+	PurgeRemind(ctx context.Context, id int, userID string) error
+	// PurgeTrash permanently deletes reminds moved to trash before given time and returns their number
+	PurgeTrash(ctx context.Context, before time.Time) (int64, error)
+	GetRemindByID(ctx context.Context, id int, userID string) (Todo, error)
+	// GetHistory returns changes of remind, the remind author gets them after the remind is purged too
+	GetHistory(ctx context.Context, todoID int, userID string) ([]HistoryEntry, error)
+	// GetCalendarReminds returns all reminds available to user for calendar export
+	GetCalendarReminds(ctx context.Context, userID string) ([]Todo, error)
+	// ScheduleNotifications adds notifications of reminds to the queue by their current schedule and removes
+	// not sent ones which aren't scheduled anymore, e.g. because the deadline was moved
+	ScheduleNotifications(ctx context.Context, now time.Time) error
+	// ClaimDueNotifications leases up to limit not sent notifications which are due at now or before and aren't
+	// leased by other workers, in order of due time
+	ClaimDueNotifications(ctx context.Context, workerID string, now time.Time, lease time.Duration, limit int) ([]Notification, error)
+	// MarkNotificationSent marks notification leased by the worker as sent, so it isn't claimed anymore, and adds
+	// its deliveries to the outbox in the same transaction. Notification before the deadline marks the remind notified
+	MarkNotificationSent(ctx context.Context, id int64, workerID string, sentAt time.Time, outbox []OutboxMessage) error
+	// MarkNotificationFailed records failed attempt of notification leased by the worker, it is claimed again
+	// at retryAt or it is dead when retryAt is nil
+	MarkNotificationFailed(ctx context.Context, id int64, workerID string, failedAt time.Time, lastError string, retryAt *time.Time) error
+	// GetDeadNotifications returns up to limit dead notifications, the last dead first
+	GetDeadNotifications(ctx context.Context, limit int) ([]Notification, error)
+	// RetryNotification makes not sent notification, e.g. dead one, due at now
+	RetryNotification(ctx context.Context, id int64, now time.Time) error
+	// NextNotificationAt returns time after now when the next not sent notification or outbox message can be claimed,
+	// nil when there is none
+	NextNotificationAt(ctx context.Context, now time.Time) (*time.Time, error)
+	// ClaimRemindsForEscalation leases overdue reminds whose next overdue notification is due: the first one
+	// is due firstDelay after the deadline, the next ones at the time given to UpdateEscalation
+	ClaimRemindsForEscalation(ctx context.Context, now time.Time, firstDelay, lease time.Duration) ([]NotificationRemind, error)
+	// UpdateEscalation stores number of sent overdue notifications and time of the next one, releases the remind
+	// and adds deliveries of the overdue notification to the outbox in the same transaction
+	UpdateEscalation(ctx context.Context, id int, escalations int, escalatedAt, nextAt time.Time, outbox []OutboxMessage) error
+	// ClaimOutbox leases up to limit not sent outbox messages which aren't dead and whose next attempt is due,
+	// in order they were added
+	ClaimOutbox(ctx context.Context, workerID string, now time.Time, lease time.Duration, limit int) ([]OutboxMessage, error)
+	// MarkOutboxSent marks outbox message leased by the worker as delivered
+	MarkOutboxSent(ctx context.Context, id int64, workerID string, sentAt time.Time) error
+	// MarkOutboxFailed records failed delivery of outbox message leased by the worker, it is claimed again
+	// at retryAt or it is dead when retryAt is nil
+	MarkOutboxFailed(ctx context.Context, id int64, workerID string, failedAt time.Time, lastError string, retryAt *time.Time) error
+	// GetDeadOutbox returns up to limit dead outbox messages, the last dead first
+	GetDeadOutbox(ctx context.Context, limit int) ([]OutboxMessage, error)
+	// RetryOutbox makes not delivered outbox message, e.g. dead one, due at now
+	RetryOutbox(ctx context.Context, id int64, now time.Time) error
+	// DismissOverdue stops overdue notifications of remind, members with editor role can do it too
+	DismissOverdue(ctx context.Context, id int, userID string) error
+
+	GetItems(ctx context.Context, todoID int, userID string) ([]TodoItem, error)
+	CreateItem(ctx context.Context, todoID int, userID string, input TodoItemInput) (TodoItem, error)
+	UpdateItem(ctx context.Context, todoID, itemID int, userID string, input TodoItemInput) (TodoItem, error)
+	DeleteItem(ctx context.Context, todoID, itemID int, userID string) error
+
+	GetMembers(ctx context.Context, todoID int, userID string) ([]Member, error)
+	AddMember(ctx context.Context, todoID int, userID string, input MemberInput) (Member, error)
+	UpdateMember(ctx context.Context, todoID, memberID int, userID string, input MemberInput) (Member, error)
+	DeleteMember(ctx context.Context, todoID, memberID int, userID string) error
+	// ResolveInvites gives user access to reminds shared with user email before the user was registered
+	ResolveInvites(ctx context.Context, userID, email string) error
 }
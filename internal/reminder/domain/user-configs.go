@@ -2,15 +2,24 @@ package domain
 
 import (
 	"context"
+	"errors"
 	"time"
 )
 
+var ErrCantFindUserConfigs = errors.New("can't find user configs")
+
 type UserConfigs struct {
 	ID           string     `json:"ID"`
 	Notification bool       `json:"notification"`
 	Period       int        `json:"period"`
 	CreatedAt    time.Time  `json:"created_at"`
 	UpdatedAt    *time.Time `json:"updated_at,omitempty"`
+	// Version is incremented on every update of configs, it's returned as ETag. On update
+	// it's expected version from If-Match header, 0 updates any version
+	Version int `json:"version"`
+	// Channels are notification channels of the user, email is used when there are no channels.
+	// Channels aren't changed on update if field is omitted
+	Channels []ChannelPreference `json:"channels"`
 }
 
 //go:generate mockgen -source=user-configs.go -destination=mocks/configsStorage.go
@@ -18,5 +27,17 @@ type UserConfigs struct {
 type ConfigRepository interface {
 	GetUserConfigs(ctx context.Context, userID string) (UserConfigs, error)
 	CreateUserConfigs(ctx context.Context, userID string) (UserConfigs, error)
-	UpdateUserConfig(ctx context.Context, id string, input UserConfigs) error
+	UpdateUserConfig(ctx context.Context, id string, input UserConfigs) (UserConfigs, error)
+	// SetCalendarToken sets hash of calendar feed token of the user, nil revokes the feed
+	SetCalendarToken(ctx context.Context, userID string, tokenHash *string) error
+	// GetUserByCalendarToken returns id of the user with given hash of calendar feed token
+	GetUserByCalendarToken(ctx context.Context, tokenHash string) (string, error)
+	// RequestErasure schedules erasure of all data of the user, repeated request keeps the first schedule
+	RequestErasure(ctx context.Context, userID string, eraseAt time.Time) (Erasure, error)
+	// CancelErasure cancels scheduled erasure of the user
+	CancelErasure(ctx context.Context, userID string) error
+	// GetDueErasures returns ids of users whose erasure is scheduled before given time
+	GetDueErasures(ctx context.Context, before time.Time) ([]string, error)
+	// EraseUser deletes all reminds, tags, projects and configs of the user in one transaction
+	EraseUser(ctx context.Context, userID string) error
 }
@@ -0,0 +1,146 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+var (
+	ErrCantFindViewWithID = errors.New("can't find view")
+	ErrBuiltinView        = errors.New("built-in view can't be changed")
+	ErrInvalidRangeBound  = errors.New("range bound should be RFC3339 time, now or today with optional offset in days, e.g. today+7d")
+)
+
+// View is a saved query of reminds
+type View struct {
+	ID     int    `json:"id"`
+	UserID string `json:"user_id,omitempty"`
+	Name   string `json:"name"`
+	// Builtin views are the same for all users and can't be changed, their ids are negative
+	Builtin    bool           `json:"builtin"`
+	Definition ViewDefinition `json:"definition"`
+	CreatedAt  time.Time      `json:"created_at"`
+}
+
+type ViewInput struct {
+	Name       string         `json:"name"`
+	Definition ViewDefinition `json:"definition"`
+}
+
+// ViewDefinition is what FetchParams are built from, fields are named as query parameters of the list of reminds.
+// Filter and FilterOption can be omitted when Q is set to order reminds by relevance
+type ViewDefinition struct {
+	Limit        int    `json:"limit,omitempty"`
+	Filter       string `json:"filter,omitempty"`
+	FilterOption string `json:"filterOption,omitempty"`
+	FilterParams string `json:"filterParams"`
+	Tags         []int  `json:"tags,omitempty"`
+	TagsMatch    string `json:"tagsMatch,omitempty"`
+	Project      int    `json:"project,omitempty"`
+	Q            string `json:"q,omitempty"`
+	// Deadline limits deadline of reminds, it's resolved when the view is opened
+	Deadline *ViewRange `json:"deadline,omitempty"`
+}
+
+// ViewRange is a range with inclusive From and exclusive To, either of them can be empty.
+// Bounds are RFC3339 times, "now" or "today" (start of the current day) with optional offset in days, e.g. "today+7d"
+type ViewRange struct {
+	From string `json:"from,omitempty"`
+	To   string `json:"to,omitempty"`
+}
+
+// Ids of built-in views
+const (
+	ViewToday    = -1
+	ViewOverdue  = -2
+	ViewUpcoming = -3
+)
+
+// BuiltinViews are available to every user in addition to their own views
+var BuiltinViews = []View{
+	{
+		ID:      ViewToday,
+		Name:    "Today",
+		Builtin: true,
+		Definition: ViewDefinition{
+			Filter:       "DeadlineAt",
+			FilterOption: "ASC",
+			FilterParams: "current",
+			Deadline:     &ViewRange{From: "today", To: "today+1d"},
+		},
+	},
+	{
+		ID:      ViewOverdue,
+		Name:    "Overdue",
+		Builtin: true,
+		Definition: ViewDefinition{
+			Filter:       "DeadlineAt",
+			FilterOption: "ASC",
+			FilterParams: "current",
+			Deadline:     &ViewRange{To: "now"},
+		},
+	},
+	{
+		ID:      ViewUpcoming,
+		Name:    "Upcoming 7 days",
+		Builtin: true,
+		Definition: ViewDefinition{
+			Filter:       "DeadlineAt",
+			FilterOption: "ASC",
+			FilterParams: "current",
+			Deadline:     &ViewRange{From: "now", To: "today+7d"},
+		},
+	},
+}
+
+// BuiltinView returns built-in view by id
+func BuiltinView(id int) (View, bool) {
+	for _, view := range BuiltinViews {
+		if view.ID == id {
+			return view, true
+		}
+	}
+	return View{}, false
+}
+
+var relativeBound = regexp.MustCompile(`^(now|today)(?:([+-]\d+)d)?$`)
+
+// ResolveRangeBound returns time of range bound, relative bounds are counted from now in its location
+func ResolveRangeBound(bound string, now time.Time) (time.Time, error) {
+	m := relativeBound.FindStringSubmatch(bound)
+	if m == nil {
+		t, err := time.Parse(time.RFC3339, bound)
+		if err != nil {
+			return time.Time{}, ErrInvalidRangeBound
+		}
+		return t, nil
+	}
+
+	t := now
+	if m[1] == "today" {
+		t = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	}
+
+	if m[2] != "" {
+		days, err := strconv.Atoi(m[2])
+		if err != nil {
+			return time.Time{}, ErrInvalidRangeBound
+		}
+		t = t.AddDate(0, 0, days)
+	}
+
+	return t, nil
+}
+
+//go:generate mockgen -source=views.go -destination=mocks/viewsStorage.go
+type ViewRepository interface {
+	// GetViews returns views of the user, built-in views aren't stored
+	GetViews(ctx context.Context, userID string) ([]View, error)
+	GetView(ctx context.Context, id int, userID string) (View, error)
+	CreateView(ctx context.Context, userID string, input ViewInput) (View, error)
+	UpdateView(ctx context.Context, id int, userID string, input ViewInput) (View, error)
+	DeleteView(ctx context.Context, id int, userID string) error
+}
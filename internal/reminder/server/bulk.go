@@ -0,0 +1,83 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	model "github.com/red-rocket-software/reminder-go/internal/reminder/domain"
+	"github.com/red-rocket-software/reminder-go/pkg/utils"
+)
+
+// maxBulkIDs limits number of reminds changed by one bulk request
+const maxBulkIDs = 500
+
+// BulkReminds apply one action to many reminds
+//
+//	@Description	BulkReminds
+//	@Summary		complete, reopen, delete, move deadline or turn deadline notifications of many reminds in one transaction
+//	@Tags			reminds
+//	@Accept			json
+//	@Produce		json
+//	@Param			input	body		domain.BulkInput	true	"ids and action"
+//	@Success		200		{array}		domain.BulkResult
+//
+//	@Failure		422		{object}	utils.HTTPError
+//	@Failure		500		{object}	utils.HTTPError
+//
+//	@Router			/reminds/bulk [post]
+func (server *Server) BulkReminds(w http.ResponseWriter, r *http.Request) {
+	var input model.BulkInput
+
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		utils.JSONError(w, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	if err := validateBulkInput(input); err != nil {
+		utils.JSONError(w, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	if input.Action == model.BulkComplete {
+		tn := time.Now().Truncate(1 * time.Second)
+		input.FinishedAt = &tn
+	}
+
+	userID := r.Context().Value("userID").(string)
+
+	results, err := server.TodoStorage.BulkUpdate(server.ctx, userID, input)
+	if err != nil {
+		utils.JSONError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	utils.JSONFormat(w, http.StatusOK, results)
+}
+
+func validateBulkInput(input model.BulkInput) error {
+	if len(input.IDs) == 0 {
+		return errors.New("ids are empty")
+	}
+	if len(input.IDs) > maxBulkIDs {
+		return fmt.Errorf("no more than %d reminds can be changed at once", maxBulkIDs)
+	}
+
+	switch input.Action {
+	case model.BulkComplete, model.BulkReopen, model.BulkDelete:
+	case model.BulkMoveDeadline:
+		if input.Days == 0 {
+			return errors.New("days should be set to move deadline")
+		}
+	case model.BulkDeadlineNotify:
+		if input.DeadlineNotify == nil {
+			return errors.New("deadline_notify should be set")
+		}
+	default:
+		return model.ErrInvalidBulkAction
+	}
+
+	return nil
+}
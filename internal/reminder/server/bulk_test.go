@@ -0,0 +1,125 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/red-rocket-software/reminder-go/internal/reminder/domain"
+	mockdb "github.com/red-rocket-software/reminder-go/internal/reminder/domain/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServer_BulkReminds(t *testing.T) {
+	notify := false
+
+	testCases := []struct {
+		name               string
+		body               string
+		mockBehavior       func(store *mockdb.MockTodoRepository)
+		expectedStatusCode int
+		expectedResults    []domain.BulkResult
+	}{
+		{
+			name: "OK - per remind results",
+			body: `{"ids":[1,2],"action":"delete"}`,
+			mockBehavior: func(store *mockdb.MockTodoRepository) {
+				store.EXPECT().BulkUpdate(gomock.Any(), testUserID, domain.BulkInput{IDs: []int{1, 2}, Action: domain.BulkDelete}).Return([]domain.BulkResult{
+					{ID: 1, Success: true},
+					{ID: 2, Error: domain.ErrCantFindRemindWithID.Error()},
+				}, nil)
+			},
+			expectedStatusCode: 200,
+			expectedResults: []domain.BulkResult{
+				{ID: 1, Success: true},
+				{ID: 2, Error: domain.ErrCantFindRemindWithID.Error()},
+			},
+		},
+		{
+			name: "OK - complete sets finish time",
+			body: `{"ids":[1],"action":"complete"}`,
+			mockBehavior: func(store *mockdb.MockTodoRepository) {
+				store.EXPECT().BulkUpdate(gomock.Any(), testUserID, gomock.Any()).DoAndReturn(func(_ context.Context, _ string, input domain.BulkInput) ([]domain.BulkResult, error) {
+					require.NotNil(t, input.FinishedAt)
+					return []domain.BulkResult{{ID: 1, Success: true}}, nil
+				})
+			},
+			expectedStatusCode: 200,
+			expectedResults:    []domain.BulkResult{{ID: 1, Success: true}},
+		},
+		{
+			name: "OK - deadline notifications",
+			body: `{"ids":[1],"action":"deadline_notify","deadline_notify":false}`,
+			mockBehavior: func(store *mockdb.MockTodoRepository) {
+				store.EXPECT().BulkUpdate(gomock.Any(), testUserID, domain.BulkInput{IDs: []int{1}, Action: domain.BulkDeadlineNotify, DeadlineNotify: &notify}).Return([]domain.BulkResult{{ID: 1, Success: true}}, nil)
+			},
+			expectedStatusCode: 200,
+			expectedResults:    []domain.BulkResult{{ID: 1, Success: true}},
+		},
+		{
+			name:               "Error - no ids",
+			body:               `{"ids":[],"action":"delete"}`,
+			mockBehavior:       func(store *mockdb.MockTodoRepository) {},
+			expectedStatusCode: 422,
+		},
+		{
+			name:               "Error - unknown action",
+			body:               `{"ids":[1],"action":"archive"}`,
+			mockBehavior:       func(store *mockdb.MockTodoRepository) {},
+			expectedStatusCode: 422,
+		},
+		{
+			name:               "Error - move deadline without days",
+			body:               `{"ids":[1],"action":"move_deadline"}`,
+			mockBehavior:       func(store *mockdb.MockTodoRepository) {},
+			expectedStatusCode: 422,
+		},
+		{
+			name:               "Error - deadline notifications without value",
+			body:               `{"ids":[1],"action":"deadline_notify"}`,
+			mockBehavior:       func(store *mockdb.MockTodoRepository) {},
+			expectedStatusCode: 422,
+		},
+		{
+			name: "Error - internal error",
+			body: `{"ids":[1],"action":"move_deadline","days":1}`,
+			mockBehavior: func(store *mockdb.MockTodoRepository) {
+				store.EXPECT().BulkUpdate(gomock.Any(), testUserID, domain.BulkInput{IDs: []int{1}, Action: domain.BulkMoveDeadline, Days: 1}).Return(nil, errors.New("something went wrong"))
+			},
+			expectedStatusCode: 500,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			configStore := mockdb.NewMockConfigRepository(c)
+			todoStore := mockdb.NewMockTodoRepository(c)
+			test.mockBehavior(todoStore)
+
+			server := newTestServer(todoStore, configStore)
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodPost, "/reminds/bulk", bytes.NewBufferString(test.body))
+			req = req.WithContext(context.WithValue(req.Context(), "userID", testUserID))
+
+			handler := http.HandlerFunc(server.BulkReminds)
+			handler.ServeHTTP(w, req)
+
+			require.Equal(t, test.expectedStatusCode, w.Code)
+
+			if test.expectedResults != nil {
+				var results []domain.BulkResult
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &results))
+				require.Equal(t, test.expectedResults, results)
+			}
+		})
+	}
+}
@@ -0,0 +1,261 @@
+package server
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+	model "github.com/red-rocket-software/reminder-go/internal/reminder/domain"
+	"github.com/red-rocket-software/reminder-go/pkg/ical"
+	"github.com/red-rocket-software/reminder-go/pkg/utils"
+)
+
+// calendar components reminds can be exported as. Events are supported by more clients, e.g. Google Calendar
+const (
+	componentEvent = "event"
+	componentTodo  = "todo"
+)
+
+// icalPriorities maps remind priority to iCalendar PRIORITY, 1 is the highest and 0 is undefined
+var icalPriorities = map[model.Priority]int{
+	model.PriorityNone:     0,
+	model.PriorityLow:      9,
+	model.PriorityMedium:   5,
+	model.PriorityHigh:     3,
+	model.PriorityCritical: 1,
+}
+
+// ExportCalendar return reminds of user as iCalendar file
+//
+//	@Description	ExportCalendar
+//	@Summary		return reminds of user as iCalendar (.ics) file with events or todos
+//	@Tags			calendar
+//	@Produce		text/calendar
+//	@Param			component	query		string	false	"event (default) or todo"
+//	@Success		200			{string}	string	"iCalendar file"
+//
+//	@Failure		400			{object}	utils.HTTPError
+//	@Failure		500			{object}	utils.HTTPError
+//
+//	@Router			/calendar/export [get]
+func (server *Server) ExportCalendar(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+
+	w.Header().Set("Content-Disposition", `attachment; filename="reminds.ics"`)
+	server.writeCalendar(w, r, userID)
+}
+
+// CreateCalendarFeed create or rotate secret calendar feed of user
+//
+//	@Description	CreateCalendarFeed
+//	@Summary		create secret calendar feed url, previous url of the user stops working
+//	@Tags			calendar
+//	@Produce		json
+//	@Success		201	{object}	domain.CalendarFeed
+//
+//	@Failure		404	{object}	utils.HTTPError
+//	@Failure		500	{object}	utils.HTTPError
+//
+//	@Router			/calendar/feed [post]
+func (server *Server) CreateCalendarFeed(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+
+	token, err := newCalendarToken()
+	if err != nil {
+		utils.JSONError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	tokenHash := hashCalendarToken(token)
+	if err := server.ConfigsStorage.SetCalendarToken(server.ctx, userID, &tokenHash); err != nil {
+		calendarError(w, err)
+		return
+	}
+
+	utils.JSONFormat(w, http.StatusCreated, model.CalendarFeed{Token: token, URL: "/calendar/feed/" + token + ".ics"})
+}
+
+// DeleteCalendarFeed revoke secret calendar feed of user
+//
+//	@Description	DeleteCalendarFeed
+//	@Summary		revoke secret calendar feed url
+//	@Tags			calendar
+//	@Produce		json
+//	@Success		204	{string}	string	"calendar feed deleted"
+//
+//	@Failure		404	{object}	utils.HTTPError
+//	@Failure		500	{object}	utils.HTTPError
+//
+//	@Router			/calendar/feed [delete]
+func (server *Server) DeleteCalendarFeed(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+
+	if err := server.ConfigsStorage.SetCalendarToken(server.ctx, userID, nil); err != nil {
+		calendarError(w, err)
+		return
+	}
+
+	utils.JSONFormat(w, http.StatusNoContent, "calendar feed deleted")
+}
+
+// GetCalendarFeed return reminds of feed owner as iCalendar, the secret token is used instead of authentication
+//
+//	@Description	GetCalendarFeed
+//	@Summary		return reminds of calendar feed owner as iCalendar for calendar subscriptions
+//	@Tags			calendar
+//	@Produce		text/calendar
+//	@Param			token		path		string	true	"secret feed token"
+//	@Param			component	query		string	false	"event (default) or todo"
+//	@Success		200			{string}	string	"iCalendar file"
+//
+//	@Failure		400			{object}	utils.HTTPError
+//	@Failure		404			{object}	utils.HTTPError
+//	@Failure		500			{object}	utils.HTTPError
+//
+//	@Router			/calendar/feed/{token} [get]
+func (server *Server) GetCalendarFeed(w http.ResponseWriter, r *http.Request) {
+	token := strings.TrimSuffix(mux.Vars(r)["token"], ".ics")
+
+	userID, err := server.ConfigsStorage.GetUserByCalendarToken(server.ctx, hashCalendarToken(token))
+	if err != nil {
+		calendarError(w, err)
+		return
+	}
+
+	server.writeCalendar(w, r, userID)
+}
+
+// writeCalendar writes reminds of user in component from query
+func (server *Server) writeCalendar(w http.ResponseWriter, r *http.Request, userID string) {
+	component := r.URL.Query().Get("component")
+	if component == "" {
+		component = componentEvent
+	}
+	if component != componentEvent && component != componentTodo {
+		utils.JSONError(w, http.StatusBadRequest, errors.New("component should be event or todo"))
+		return
+	}
+
+	reminds, err := server.TodoStorage.GetCalendarReminds(server.ctx, userID)
+	if err != nil {
+		utils.JSONError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	// calendar is encoded before the response is started to be able to report errors
+	var buf bytes.Buffer
+	if err := encodeCalendar(&buf, reminds, component, time.Now()); err != nil {
+		utils.JSONError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	if _, err := buf.WriteTo(w); err != nil {
+		server.Logger.Errorf("unable to write calendar: %v", err)
+	}
+}
+
+// encodeCalendar writes reminds as VCALENDAR with VEVENT or VTODO items, notify period is mapped to alarms
+func encodeCalendar(w io.Writer, reminds []model.Todo, component string, now time.Time) error {
+	enc := ical.NewEncoder(w)
+
+	enc.Begin("VCALENDAR")
+	enc.Value("VERSION", "2.0")
+	enc.Value("PRODID", "-//Red Rocket Software//Reminder-GO//EN")
+	enc.Value("CALSCALE", "GREGORIAN")
+	enc.Text("X-WR-CALNAME", "Reminder")
+
+	name, trigger := "VEVENT", "TRIGGER"
+	if component == componentTodo {
+		// alarms of todo are related to its due time
+		name, trigger = "VTODO", "TRIGGER;RELATED=END"
+	}
+
+	for _, remind := range reminds {
+		enc.Begin(name)
+		enc.Value("UID", fmt.Sprintf("remind-%d@reminder-go", remind.ID))
+		enc.Time("DTSTAMP", now)
+		enc.Time("CREATED", remind.CreatedAt)
+		enc.Value("SEQUENCE", strconv.Itoa(remind.Version))
+		enc.Text("SUMMARY", remind.Title)
+		if remind.Description != "" {
+			enc.Text("DESCRIPTION", remind.Description)
+		}
+
+		if component == componentTodo {
+			enc.Time("DUE", remind.DeadlineAt)
+			if remind.Completed {
+				enc.Value("STATUS", "COMPLETED")
+				if remind.FinishedAt != nil {
+					enc.Time("COMPLETED", *remind.FinishedAt)
+				}
+			} else {
+				enc.Value("STATUS", "NEEDS-ACTION")
+			}
+		} else {
+			enc.Time("DTSTART", remind.DeadlineAt)
+			enc.Value("TRANSP", "TRANSPARENT")
+		}
+
+		if priority := icalPriorities[remind.Priority]; priority > 0 {
+			enc.Value("PRIORITY", strconv.Itoa(priority))
+		}
+		if remind.RRule != nil {
+			enc.Value("RRULE", *remind.RRule)
+		}
+
+		if !remind.Completed {
+			for _, period := range remind.NotifyPeriod {
+				// zero periods are placeholders and periods before creation of the remind were never notified
+				if period.IsZero() || period.Before(remind.CreatedAt) {
+					continue
+				}
+				enc.Begin("VALARM")
+				enc.Value("ACTION", "DISPLAY")
+				enc.Text("DESCRIPTION", remind.Title)
+				enc.Value(trigger, ical.FormatDuration(period.Sub(remind.DeadlineAt)))
+				enc.End("VALARM")
+			}
+		}
+
+		enc.End(name)
+	}
+
+	enc.End("VCALENDAR")
+
+	return enc.Flush()
+}
+
+// newCalendarToken returns random url safe token
+func newCalendarToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// hashCalendarToken returns hash of token which is stored instead of the token
+func hashCalendarToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func calendarError(w http.ResponseWriter, err error) {
+	if errors.Is(err, model.ErrCantFindUserConfigs) {
+		utils.JSONError(w, http.StatusNotFound, err)
+		return
+	}
+	utils.JSONError(w, http.StatusInternalServerError, err)
+}
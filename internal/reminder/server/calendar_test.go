@@ -0,0 +1,213 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/red-rocket-software/reminder-go/internal/reminder/domain"
+	mockdb "github.com/red-rocket-software/reminder-go/internal/reminder/domain/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncodeCalendar(t *testing.T) {
+	deadline := time.Date(2023, time.April, 15, 16, 0, 0, 0, time.UTC)
+	finishedAt := deadline.Add(-time.Hour)
+	now := time.Date(2023, time.April, 1, 0, 0, 0, 0, time.UTC)
+	rule := "FREQ=WEEKLY;BYDAY=MO"
+
+	reminds := []domain.Todo{
+		{
+			ID:           1,
+			Title:        "Call, mom",
+			Description:  "about weekend",
+			CreatedAt:    now,
+			DeadlineAt:   deadline,
+			NotifyPeriod: []time.Time{{}, now.Add(-time.Hour), deadline.Add(-time.Hour)},
+			Priority:     domain.PriorityCritical,
+			RRule:        &rule,
+			Version:      2,
+		},
+		{
+			ID:           2,
+			Title:        "done",
+			CreatedAt:    now,
+			DeadlineAt:   deadline,
+			Completed:    true,
+			FinishedAt:   &finishedAt,
+			NotifyPeriod: []time.Time{deadline.Add(-time.Hour)},
+			Version:      1,
+		},
+	}
+
+	t.Run("events", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, encodeCalendar(&buf, reminds, componentEvent, now))
+
+		require.Equal(t, strings.Join([]string{
+			"BEGIN:VCALENDAR",
+			"VERSION:2.0",
+			"PRODID:-//Red Rocket Software//Reminder-GO//EN",
+			"CALSCALE:GREGORIAN",
+			"X-WR-CALNAME:Reminder",
+			"BEGIN:VEVENT",
+			"UID:remind-1@reminder-go",
+			"DTSTAMP:20230401T000000Z",
+			"CREATED:20230401T000000Z",
+			"SEQUENCE:2",
+			`SUMMARY:Call\, mom`,
+			"DESCRIPTION:about weekend",
+			"DTSTART:20230415T160000Z",
+			"TRANSP:TRANSPARENT",
+			"PRIORITY:1",
+			"RRULE:FREQ=WEEKLY;BYDAY=MO",
+			"BEGIN:VALARM",
+			"ACTION:DISPLAY",
+			`DESCRIPTION:Call\, mom`,
+			"TRIGGER:-PT3600S",
+			"END:VALARM",
+			"END:VEVENT",
+			"BEGIN:VEVENT",
+			"UID:remind-2@reminder-go",
+			"DTSTAMP:20230401T000000Z",
+			"CREATED:20230401T000000Z",
+			"SEQUENCE:1",
+			"SUMMARY:done",
+			"DTSTART:20230415T160000Z",
+			"TRANSP:TRANSPARENT",
+			"END:VEVENT",
+			"END:VCALENDAR",
+			"",
+		}, "\r\n"), buf.String())
+	})
+
+	t.Run("todos", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, encodeCalendar(&buf, reminds, componentTodo, now))
+
+		ics := buf.String()
+		require.Contains(t, ics, "BEGIN:VTODO\r\n")
+		require.Contains(t, ics, "DUE:20230415T160000Z\r\nSTATUS:NEEDS-ACTION\r\n")
+		require.Contains(t, ics, "TRIGGER;RELATED=END:-PT3600S\r\n")
+		require.Contains(t, ics, "STATUS:COMPLETED\r\nCOMPLETED:20230415T150000Z\r\n")
+		require.NotContains(t, ics, "VEVENT")
+	})
+}
+
+func TestServer_ExportCalendar(t *testing.T) {
+	testCases := []struct {
+		name               string
+		query              string
+		mockBehavior       func(store *mockdb.MockTodoRepository)
+		expectedStatusCode int
+	}{
+		{
+			name: "OK",
+			mockBehavior: func(store *mockdb.MockTodoRepository) {
+				store.EXPECT().GetCalendarReminds(gomock.Any(), testUserID).Return([]domain.Todo{{ID: 1, Title: "title"}}, nil)
+			},
+			expectedStatusCode: 200,
+		},
+		{
+			name:               "Error - wrong component",
+			query:              "?component=journal",
+			mockBehavior:       func(store *mockdb.MockTodoRepository) {},
+			expectedStatusCode: 400,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			configStore := mockdb.NewMockConfigRepository(c)
+			todoStore := mockdb.NewMockTodoRepository(c)
+			test.mockBehavior(todoStore)
+
+			server := newTestServer(todoStore, configStore)
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodGet, "/calendar/export"+test.query, http.NoBody)
+			req = req.WithContext(context.WithValue(req.Context(), "userID", testUserID))
+
+			handler := http.HandlerFunc(server.ExportCalendar)
+			handler.ServeHTTP(w, req)
+
+			require.Equal(t, test.expectedStatusCode, w.Code)
+			if test.expectedStatusCode == 200 {
+				require.Equal(t, "text/calendar; charset=utf-8", w.Header().Get("Content-Type"))
+				require.Contains(t, w.Body.String(), "UID:remind-1@reminder-go")
+			}
+		})
+	}
+}
+
+func TestServer_CalendarFeed(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	configStore := mockdb.NewMockConfigRepository(c)
+	todoStore := mockdb.NewMockTodoRepository(c)
+
+	server := newTestServer(todoStore, configStore)
+	router := server.ConfigureReminderRouter()
+
+	var storedHash string
+	configStore.EXPECT().SetCalendarToken(gomock.Any(), testUserID, gomock.Any()).DoAndReturn(func(_ context.Context, _ string, tokenHash *string) error {
+		storedHash = *tokenHash
+		return nil
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/calendar/feed", http.NoBody)
+	req = req.WithContext(context.WithValue(req.Context(), "userID", testUserID))
+	http.HandlerFunc(server.CreateCalendarFeed).ServeHTTP(w, req)
+	require.Equal(t, http.StatusCreated, w.Code)
+
+	var feed domain.CalendarFeed
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &feed))
+	require.NotEmpty(t, feed.Token)
+	require.NotEqual(t, feed.Token, storedHash, "token itself isn't stored")
+
+	t.Run("feed works without bearer token", func(t *testing.T) {
+		configStore.EXPECT().GetUserByCalendarToken(gomock.Any(), storedHash).Return(testUserID, nil)
+		todoStore.EXPECT().GetCalendarReminds(gomock.Any(), testUserID).Return([]domain.Todo{{ID: 1, Title: "title"}}, nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, feed.URL, http.NoBody)
+		router.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Contains(t, w.Body.String(), "BEGIN:VCALENDAR")
+	})
+
+	t.Run("unknown token", func(t *testing.T) {
+		configStore.EXPECT().GetUserByCalendarToken(gomock.Any(), hashCalendarToken("unknown")).Return("", domain.ErrCantFindUserConfigs)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/calendar/feed/unknown.ics", http.NoBody)
+		req = mux.SetURLVars(req, map[string]string{"token": "unknown.ics"})
+		http.HandlerFunc(server.GetCalendarFeed).ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("revoke feed", func(t *testing.T) {
+		configStore.EXPECT().SetCalendarToken(gomock.Any(), testUserID, nil).Return(nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodDelete, "/calendar/feed", http.NoBody)
+		req = req.WithContext(context.WithValue(req.Context(), "userID", testUserID))
+		http.HandlerFunc(server.DeleteCalendarFeed).ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusNoContent, w.Code)
+	})
+}
@@ -1,16 +1,19 @@
 package server
 
 import (
-	"database/sql"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"mime"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/gorilla/mux"
 	model "github.com/red-rocket-software/reminder-go/internal/reminder/domain"
+	"github.com/red-rocket-software/reminder-go/pkg/rrule"
 	"github.com/red-rocket-software/reminder-go/pkg/utils"
 )
 
@@ -37,43 +40,65 @@ func (server *Server) AddRemind(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if input.Description == "" || input.DeadlineAt == "" || input.Title == "" {
-		utils.JSONError(w, http.StatusUnprocessableEntity, errors.New("nothing to save"))
+	userID := r.Context().Value("userID").(string)
+
+	todo, err := todoFromInput(input, userID)
+	if err != nil {
+		if errors.Is(err, errNothingToSave) {
+			utils.JSONError(w, http.StatusUnprocessableEntity, err)
+			return
+		}
+		utils.JSONError(w, http.StatusBadRequest, err)
 		return
 	}
 
-	userID := r.Context().Value("userID").(string)
+	remind, err := server.TodoStorage.CreateRemind(server.ctx, todo)
+	if err != nil {
+		if errors.Is(err, model.ErrCantFindTagWithID) || errors.Is(err, model.ErrCantFindProjectWithID) || errors.Is(err, model.ErrProjectArchived) {
+			utils.JSONError(w, http.StatusUnprocessableEntity, err)
+			return
+		}
+		utils.JSONError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	utils.JSONFormat(w, http.StatusCreated, remind)
+}
+
+var errNothingToSave = errors.New("nothing to save")
+
+// todoFromInput validates new remind input and converts it to remind of the user,
+// errNothingToSave is returned when required fields are empty
+func todoFromInput(input model.TodoInput, userID string) (model.Todo, error) {
+	if input.Description == "" || input.DeadlineAt == "" || input.Title == "" {
+		return model.Todo{}, errNothingToSave
+	}
 
 	var todo model.Todo
 
 	deadlineParseTime, err := time.Parse(time.RFC3339, input.DeadlineAt)
 	if err != nil {
-		utils.JSONError(w, http.StatusBadRequest, err)
-		return
+		return model.Todo{}, err
 	}
 
 	createParseTime, err := time.Parse("02.01.2006, 15:04:05", input.CreatedAt)
 	if err != nil {
-		utils.JSONError(w, http.StatusBadRequest, err)
-		return
+		return model.Todo{}, err
 	}
 
-	np := make([]time.Time, len(input.NotifyPeriod))
+	np := make([]time.Time, 0, len(input.NotifyPeriod))
 
 	if len(input.NotifyPeriod) > 0 {
 		for _, period := range input.NotifyPeriod {
 			periodParseTime, err := time.Parse(time.RFC3339, period)
 			if err != nil {
-				utils.JSONError(w, http.StatusBadRequest, err)
-				return
+				return model.Todo{}, err
 			}
 			if periodParseTime.After(deadlineParseTime) {
-				utils.JSONError(w, http.StatusBadRequest, errors.New("time to deadline notification can't be more than deadline time"))
-				return
+				return model.Todo{}, errors.New("time to deadline notification can't be more than deadline time")
 			}
 			if periodParseTime.Before(deadlineParseTime.AddDate(0, 0, -2)) {
-				utils.JSONError(w, http.StatusBadRequest, errors.New("time to deadline notification can't be less than 2 days to deadline time"))
-				return
+				return model.Todo{}, errors.New("time to deadline notification can't be less than 2 days to deadline time")
 			}
 			np = append(np, periodParseTime.Truncate(time.Minute))
 		}
@@ -86,19 +111,25 @@ func (server *Server) AddRemind(w http.ResponseWriter, r *http.Request) {
 	todo.UserID = userID
 	todo.DeadlineNotify = input.DeadlineNotify
 	todo.NotifyPeriod = np
+	todo.Priority = input.Priority
+	todo.ProjectID = input.ProjectID
 
-	remind, err := server.TodoStorage.CreateRemind(server.ctx, todo)
-	if err != nil {
-		utils.JSONError(w, http.StatusInternalServerError, err)
-		return
+	if err := validateRRule(input.RRule); err != nil {
+		return model.Todo{}, err
 	}
+	todo.RRule = input.RRule
+	todo.RecurFromCompletion = input.RecurFromCompletion
 
-	utils.JSONFormat(w, http.StatusCreated, remind)
+	for _, tagID := range input.Tags {
+		todo.Tags = append(todo.Tags, model.Tag{ID: tagID})
+	}
+
+	return todo, nil
 }
 
 // DeleteRemind
 // @Description	DeleteRemind
-// @Summary		delete remind
+// @Summary		move remind to trash
 // @Tags			reminds
 // @Accept			json
 // @Produce		json
@@ -106,6 +137,7 @@ func (server *Server) AddRemind(w http.ResponseWriter, r *http.Request) {
 // @Success		204	{string}	string	"remind with id:1 successfully deleted"
 //
 // @Failure		400	{object}	utils.HTTPError
+// @Failure		404	{object}	utils.HTTPError
 // @Failure		500	{object}	utils.HTTPError
 //
 // @Router			/remind{id} [delete]
@@ -117,8 +149,10 @@ func (server *Server) DeleteRemind(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// deleting remind from db
-	if err := server.TodoStorage.DeleteRemind(server.ctx, remindID); err != nil {
+	userID := r.Context().Value("userID").(string)
+
+	// moving remind to trash, only owners can do it
+	if err := server.TodoStorage.DeleteRemind(server.ctx, remindID, userID); err != nil {
 		if errors.Is(err, model.ErrDeleteFailed) {
 			utils.JSONError(w, http.StatusInternalServerError, err)
 			return
@@ -161,15 +195,19 @@ func (server *Server) GetRemindByID(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	todo, err := server.TodoStorage.GetRemindByID(server.ctx, rID)
+	userID := r.Context().Value("userID").(string)
+
+	todo, err := server.TodoStorage.GetRemindByID(server.ctx, rID, userID)
 	if err != nil {
-		if err == sql.ErrNoRows {
+		if errors.Is(err, model.ErrCantFindRemindWithID) {
 			utils.JSONError(w, http.StatusNotFound, err)
 			return
 		}
 		utils.JSONError(w, http.StatusInternalServerError, err)
 		return
 	}
+
+	setETag(w, todo.Version)
 	utils.JSONFormat(w, http.StatusOK, todo)
 }
 
@@ -180,11 +218,14 @@ func (server *Server) GetRemindByID(w http.ResponseWriter, r *http.Request) {
 //	@Tags			reminds
 //	@Accept			json
 //	@Produce		json
-//	@Param			id		path		int						true	"id"
-//	@Param			input	body		domain.TodoUpdateInput	true	"update info"
-//	@Success		200		{string}	domain.Todo
+//	@Param			id			path		int						true	"id"
+//	@Param			If-Match	header		string					false	"ETag of remind from GetRemindByID or UpdateRemind"
+//	@Param			input		body		domain.TodoUpdateInput	true	"update info"
+//	@Success		200			{string}	domain.Todo
 //
 //	@Failure		400		{object}	utils.HTTPError
+//	@Failure		404		{object}	utils.HTTPError
+//	@Failure		412		{object}	utils.HTTPError
 //	@Failure		422		{object}	utils.HTTPError
 //	@Failure		500		{object}	utils.HTTPError
 //
@@ -224,12 +265,145 @@ func (server *Server) UpdateRemind(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	remind, err := server.TodoStorage.UpdateRemind(server.ctx, rID, input)
+	if err := validateRRule(input.RRule); err != nil {
+		utils.JSONError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	version, ok := ifMatch(r)
+	if !ok {
+		utils.JSONError(w, http.StatusPreconditionFailed, model.ErrVersionMismatch)
+		return
+	}
+	input.Version = version
+
+	userID := r.Context().Value("userID").(string)
+
+	remind, err := server.TodoStorage.UpdateRemind(server.ctx, rID, userID, input)
 	if err != nil {
+		if errors.Is(err, model.ErrCantFindRemindWithID) {
+			utils.JSONError(w, http.StatusNotFound, err)
+			return
+		}
+		if errors.Is(err, model.ErrVersionMismatch) {
+			utils.JSONError(w, http.StatusPreconditionFailed, err)
+			return
+		}
+		if errors.Is(err, model.ErrCantFindTagWithID) || errors.Is(err, model.ErrCantFindProjectWithID) || errors.Is(err, model.ErrProjectArchived) {
+			utils.JSONError(w, http.StatusUnprocessableEntity, err)
+			return
+		}
 		utils.JSONError(w, http.StatusInternalServerError, err)
 		return
 	}
 
+	setETag(w, remind.Version)
+	utils.JSONFormat(w, http.StatusOK, remind)
+}
+
+// PatchRemind change only fields of remind present in JSON merge patch
+//
+//	@Description	PatchRemind
+//	@Summary		change remind fields present in JSON merge patch (RFC 7396), null removes optional values
+//	@Tags			reminds
+//	@Accept			application/merge-patch+json
+//	@Produce		json
+//	@Param			id			path		int					true	"id"
+//	@Param			If-Match	header		string				false	"ETag of remind from GetRemindByID or UpdateRemind"
+//	@Param			input		body		domain.TodoUpdateInput	true	"fields to change"
+//	@Success		200			{object}	domain.Todo
+//
+//	@Failure		400		{object}	utils.HTTPError
+//	@Failure		404		{object}	utils.HTTPError
+//	@Failure		412		{object}	utils.HTTPError
+//	@Failure		415		{object}	utils.HTTPError
+//	@Failure		422		{object}	utils.HTTPError
+//	@Failure		500		{object}	utils.HTTPError
+//
+//	@Router			/remind/{id} [patch]
+func (server *Server) PatchRemind(w http.ResponseWriter, r *http.Request) {
+	rID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.JSONError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	if contentType := r.Header.Get("Content-Type"); contentType != "" {
+		mediaType, _, _ := mime.ParseMediaType(contentType)
+		if mediaType != "application/merge-patch+json" && mediaType != "application/json" {
+			utils.JSONError(w, http.StatusUnsupportedMediaType, errors.New("patch should be application/merge-patch+json"))
+			return
+		}
+	}
+
+	var patch model.TodoPatch
+
+	if err := json.NewDecoder(r.Body).Decode(&patch); err != nil {
+		utils.JSONError(w, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	if patch.Title != nil && *patch.Title == "" {
+		utils.JSONError(w, http.StatusUnprocessableEntity, errors.New("title is empty"))
+		return
+	}
+
+	if patch.Description != nil && *patch.Description == "" {
+		utils.JSONError(w, http.StatusUnprocessableEntity, errors.New("description is empty"))
+		return
+	}
+
+	if patch.RRule != nil && *patch.RRule != "" {
+		if err := validateRRule(patch.RRule); err != nil {
+			utils.JSONError(w, http.StatusBadRequest, err)
+			return
+		}
+	}
+
+	if patch.DeadlineAt != nil {
+		deadline := patch.DeadlineAt.Truncate(time.Minute)
+		patch.DeadlineAt = &deadline
+	}
+
+	if patch.NotifyPeriod != nil {
+		for i, period := range *patch.NotifyPeriod {
+			(*patch.NotifyPeriod)[i] = period.Truncate(time.Minute)
+		}
+	}
+
+	if patch.Completed != nil && *patch.Completed {
+		tn := time.Now()
+		patch.FinishedAt = &tn
+	}
+
+	version, ok := ifMatch(r)
+	if !ok {
+		utils.JSONError(w, http.StatusPreconditionFailed, model.ErrVersionMismatch)
+		return
+	}
+	patch.Version = version
+
+	userID := r.Context().Value("userID").(string)
+
+	remind, err := server.TodoStorage.PatchRemind(server.ctx, rID, userID, patch)
+	if err != nil {
+		if errors.Is(err, model.ErrCantFindRemindWithID) {
+			utils.JSONError(w, http.StatusNotFound, err)
+			return
+		}
+		if errors.Is(err, model.ErrVersionMismatch) {
+			utils.JSONError(w, http.StatusPreconditionFailed, err)
+			return
+		}
+		if errors.Is(err, model.ErrCantFindTagWithID) || errors.Is(err, model.ErrCantFindProjectWithID) || errors.Is(err, model.ErrProjectArchived) {
+			utils.JSONError(w, http.StatusUnprocessableEntity, err)
+			return
+		}
+		utils.JSONError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	setETag(w, remind.Version)
 	utils.JSONFormat(w, http.StatusOK, remind)
 }
 
@@ -240,18 +414,23 @@ func (server *Server) UpdateRemind(w http.ResponseWriter, r *http.Request) {
 //	@Tags			user_config
 //	@Accept			json
 //	@Produce		json
-//	@Param			id		path		string						true	"id"
-//	@Param			input	body		domain.UserConfigs	true	"update info"
-//	@Success		200		{string}	string					"success"
+//	@Param			id			path		string				true	"id"
+//	@Param			If-Match	header		string				false	"ETag of configs from GetOrCreateUserConfig"
+//	@Param			input		body		domain.UserConfigs	true	"update info"
+//	@Success		200			{object}	domain.UserConfigs
 //
+//	@Failure		404		{object}	utils.HTTPError
+//	@Failure		412		{object}	utils.HTTPError
 //	@Failure		422		{object}	utils.HTTPError
 //	@Failure		500		{object}	utils.HTTPError
 //
 //	@Router			/configs/{id} [put]
 func (server *Server) UpdateUserConfig(w http.ResponseWriter, r *http.Request) {
-	vars := mux.Vars(r)
-
-	uID := vars["id"]
+	uID, ok := configsOwner(r)
+	if !ok {
+		utils.JSONError(w, http.StatusNotFound, model.ErrCantFindUserConfigs)
+		return
+	}
 
 	var input model.UserConfigs
 
@@ -261,13 +440,59 @@ func (server *Server) UpdateUserConfig(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err = server.ConfigsStorage.UpdateUserConfig(server.ctx, uID, input)
+	if err := server.validateChannels(input.Channels); err != nil {
+		utils.JSONError(w, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	// expected version is taken only from If-Match header, not from the body
+	version, ok := ifMatch(r)
+	if !ok {
+		utils.JSONError(w, http.StatusPreconditionFailed, model.ErrVersionMismatch)
+		return
+	}
+	input.Version = version
+
+	configs, err := server.ConfigsStorage.UpdateUserConfig(server.ctx, uID, input)
 	if err != nil {
+		if errors.Is(err, model.ErrCantFindUserConfigs) {
+			utils.JSONError(w, http.StatusNotFound, err)
+			return
+		}
+		if errors.Is(err, model.ErrVersionMismatch) {
+			utils.JSONError(w, http.StatusPreconditionFailed, err)
+			return
+		}
 		utils.JSONError(w, http.StatusInternalServerError, err)
 		return
 	}
 
-	utils.JSONFormat(w, http.StatusOK, "success")
+	setETag(w, configs.Version)
+	utils.JSONFormat(w, http.StatusOK, configs)
+}
+
+// validateChannels checks channel preferences of the user, each channel should be enabled in config and
+// addresses should be public unless private networks are allowed
+func (server *Server) validateChannels(channels []model.ChannelPreference) error {
+	if len(channels) > model.MaxChannels {
+		return fmt.Errorf("%w: no more than %d channels are allowed", model.ErrInvalidChannel, model.MaxChannels)
+	}
+
+	for _, pref := range channels {
+		if err := pref.Validate(); err != nil {
+			return err
+		}
+		if !server.config.Channels.AllowPrivateNetworks {
+			if err := pref.CheckPublicAddress(); err != nil {
+				return fmt.Errorf("%w: %v", model.ErrInvalidChannel, err)
+			}
+		}
+		if !server.config.ChannelEnabled(pref.Channel) {
+			return fmt.Errorf("%w: %s isn't enabled", model.ErrInvalidChannel, pref.Channel)
+		}
+	}
+
+	return nil
 }
 
 // UpdateCompleteStatus update Completed field to true
@@ -277,11 +502,15 @@ func (server *Server) UpdateUserConfig(w http.ResponseWriter, r *http.Request) {
 //	@Tags			reminds
 //	@Accept			json
 //	@Produce		json
-//	@Param			id		path		int							true	"id"
-//	@Param			input	body		domain.TodoUpdateStatusInput	true	"update info"
-//	@Success		200		{string}	string						"remind status updated"
+//	@Param			id			path		int								true	"id"
+//	@Param			If-Match	header		string							false	"ETag of remind from GetRemindByID or UpdateRemind"
+//	@Param			input		body		domain.TodoUpdateStatusInput	true	"update info"
+//	@Success		200			{string}	string							"remind status updated"
 //
 //	@Failure		400		{object}	utils.HTTPError
+//	@Failure		404		{object}	utils.HTTPError
+//	@Failure		409		{object}	utils.HTTPError
+//	@Failure		412		{object}	utils.HTTPError
 //	@Failure		422		{object}	utils.HTTPError
 //	@Failure		500		{object}	utils.HTTPError
 //
@@ -303,14 +532,40 @@ func (server *Server) UpdateCompleteStatus(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
+	if updateInput.Children != "" && updateInput.Children != model.ChildrenComplete && updateInput.Children != model.ChildrenBlock {
+		utils.JSONError(w, http.StatusUnprocessableEntity, errors.New("children should be complete or block"))
+		return
+	}
+
 	tn := time.Now().Truncate(1 * time.Second)
 
 	if updateInput.Completed {
 		updateInput.FinishedAt = &tn
 	}
 
-	err = server.TodoStorage.UpdateStatus(server.ctx, rID, updateInput)
+	version, ok := ifMatch(r)
+	if !ok {
+		utils.JSONError(w, http.StatusPreconditionFailed, model.ErrVersionMismatch)
+		return
+	}
+	updateInput.Version = version
+
+	userID := r.Context().Value("userID").(string)
+
+	err = server.TodoStorage.UpdateStatus(server.ctx, rID, userID, updateInput)
 	if err != nil {
+		if errors.Is(err, model.ErrCantFindRemindWithID) {
+			utils.JSONError(w, http.StatusNotFound, err)
+			return
+		}
+		if errors.Is(err, model.ErrVersionMismatch) {
+			utils.JSONError(w, http.StatusPreconditionFailed, err)
+			return
+		}
+		if errors.Is(err, model.ErrIncompleteItems) {
+			utils.JSONError(w, http.StatusConflict, err)
+			return
+		}
 		utils.JSONError(w, http.StatusInternalServerError, err)
 		return
 	}
@@ -318,6 +573,51 @@ func (server *Server) UpdateCompleteStatus(w http.ResponseWriter, r *http.Reques
 	utils.JSONFormat(w, http.StatusOK, "remind status updated")
 }
 
+// DismissOverdue stops overdue notifications of remind
+//
+//	@Description	DismissOverdue
+//	@Summary		stop overdue notifications of remind until its deadline is moved
+//	@Tags			reminds
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		int		true	"id"
+//	@Success		200	{string}	string	"overdue notifications dismissed"
+//
+//	@Failure		400	{object}	utils.HTTPError
+//	@Failure		404	{object}	utils.HTTPError
+//	@Failure		422	{object}	utils.HTTPError
+//	@Failure		500	{object}	utils.HTTPError
+//
+//	@Router			/remind/{id}/dismiss [put]
+func (server *Server) DismissOverdue(w http.ResponseWriter, r *http.Request) {
+	rID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.JSONError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	userID := r.Context().Value("userID").(string)
+
+	err = server.TodoStorage.DismissOverdue(server.ctx, rID, userID)
+	if err != nil {
+		if errors.Is(err, model.ErrCantFindRemindWithID) {
+			utils.JSONError(w, http.StatusNotFound, err)
+			return
+		}
+		if errors.Is(err, model.ErrRemindNotOverdue) {
+			utils.JSONError(w, http.StatusUnprocessableEntity, err)
+			return
+		}
+		utils.JSONError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	utils.JSONFormat(w, http.StatusOK, "overdue notifications dismissed")
+}
+
+// maxSearchLength limits length of search query in characters
+const maxSearchLength = 200
+
 // GetReminds handle get reminds.
 //
 //	@Description	GetReminds
@@ -327,8 +627,14 @@ func (server *Server) UpdateCompleteStatus(w http.ResponseWriter, r *http.Reques
 //	@Produce		json
 //	@Param			limit	query		string	true	"limit"
 //	@Param			cursor	query		string	true	"cursor"
-//	@Param			filter	query		string	true	"filter"
-//	@Param			filterOptions	query		string	true	"filterOptions"
+//	@Param			filter	query		string	true	"sort column: CreatedAt, DeadlineAt or Priority, optional with q"
+//	@Param			filterOption	query		string	true	"sort order: ASC or DESC, optional with q"
+//	@Param			q	query		string	false	"full-text search in title and description, reminds are ordered by relevance without filter"
+//	@Param			occurrencesFrom	query		string	false	"start of range to expand recurring reminds in (RFC3339)"
+//	@Param			occurrencesTo	query		string	false	"end of range to expand recurring reminds in (RFC3339)"
+//	@Param			tags	query		string	false	"comma separated tag ids"
+//	@Param			tagsMatch	query		string	false	"any or all, any by default"
+//	@Param			project	query		int	false	"project id, reminds of archived projects are returned only with this filter"
 //	@Success		200		{object}	domain.TodoResponse
 //
 //	@Failure		400		{object}	utils.HTTPError
@@ -357,16 +663,26 @@ func (server *Server) GetReminds(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	filter := r.URL.Query().Get("filter")
-	if filter == "" {
-		utils.JSONError(w, http.StatusBadRequest, errors.New("filter parameter is invalid"))
+	search := strings.TrimSpace(r.URL.Query().Get("q"))
+	if utf8.RuneCountInString(search) > maxSearchLength {
+		utils.JSONError(w, http.StatusBadRequest, fmt.Errorf("q parameter is invalid, should be no longer than %d characters", maxSearchLength))
 		return
 	}
 
+	filter := r.URL.Query().Get("filter")
 	filterOption := r.URL.Query().Get("filterOption")
-	if filterOption == "" {
-		utils.JSONError(w, http.StatusBadRequest, errors.New("FilterOption parameter is invalid"))
-		return
+
+	// search results are ordered by relevance when sort isn't given
+	if search == "" || filter != "" || filterOption != "" {
+		if filter != "CreatedAt" && filter != "DeadlineAt" && filter != "Priority" {
+			utils.JSONError(w, http.StatusBadRequest, errors.New("filter parameter is invalid, should be CreatedAt, DeadlineAt or Priority"))
+			return
+		}
+
+		if filterOption != "ASC" && filterOption != "DESC" {
+			utils.JSONError(w, http.StatusBadRequest, errors.New("FilterOption parameter is invalid, should be ASC or DESC"))
+			return
+		}
 	}
 
 	filterParams := r.URL.Query().Get("filterParams")
@@ -375,6 +691,42 @@ func (server *Server) GetReminds(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	occurrencesFrom := r.URL.Query().Get("occurrencesFrom")
+	occurrencesTo := r.URL.Query().Get("occurrencesTo")
+	if occurrencesFrom != "" || occurrencesTo != "" {
+		from, errFrom := time.Parse(time.RFC3339, occurrencesFrom)
+		to, errTo := time.Parse(time.RFC3339, occurrencesTo)
+		if errFrom != nil || errTo != nil || to.Before(from) {
+			utils.JSONError(w, http.StatusBadRequest, errors.New("occurrencesFrom and occurrencesTo parameters are invalid"))
+			return
+		}
+	}
+
+	var tags []int
+	if tagsStr := r.URL.Query().Get("tags"); tagsStr != "" {
+		for _, t := range strings.Split(tagsStr, ",") {
+			tagID, err := strconv.Atoi(t)
+			if err != nil {
+				utils.JSONError(w, http.StatusBadRequest, errors.New("tags parameter is invalid, should be comma separated ids"))
+				return
+			}
+			tags = append(tags, tagID)
+		}
+	}
+
+	tagsMatch := r.URL.Query().Get("tagsMatch")
+	if tagsMatch != "" && tagsMatch != model.TagsMatchAny && tagsMatch != model.TagsMatchAll {
+		utils.JSONError(w, http.StatusBadRequest, errors.New("tagsMatch parameter is invalid, should be any or all"))
+		return
+	}
+
+	projectStr := r.URL.Query().Get("project")
+	projectID, err := strconv.Atoi(projectStr)
+	if (err != nil && projectStr != "") || projectID < 0 {
+		utils.JSONError(w, http.StatusBadRequest, errors.New("project parameter is invalid, should be project id"))
+		return
+	}
+
 	//initialize fetchParameters
 	params := model.FetchParams{
 		Page: utils.Page{
@@ -384,6 +736,14 @@ func (server *Server) GetReminds(w http.ResponseWriter, r *http.Request) {
 		FilterByDate:  filter,
 		FilterBySort:  filterOption,
 		FilterByQuery: filterParams,
+		Occurrences: model.TimeRangeFilter{
+			StartRange: occurrencesFrom,
+			EndRange:   occurrencesTo,
+		},
+		Tags:      tags,
+		TagsMatch: tagsMatch,
+		ProjectID: projectID,
+		Search:    search,
 	}
 
 	userID := r.Context().Value("userID").(string)
@@ -407,13 +767,9 @@ func (server *Server) GetReminds(w http.ResponseWriter, r *http.Request) {
 }
 
 func (server *Server) GetOrCreateUserConfig(w http.ResponseWriter, r *http.Request) {
-	vars := mux.Vars(r)
-
-	uID := vars["id"]
-
-	if uID == "" {
-
-		utils.JSONError(w, http.StatusInternalServerError, errors.New("empty or wrong userID"))
+	uID, ok := configsOwner(r)
+	if !ok {
+		utils.JSONError(w, http.StatusNotFound, model.ErrCantFindUserConfigs)
 		return
 	}
 
@@ -424,17 +780,46 @@ func (server *Server) GetOrCreateUserConfig(w http.ResponseWriter, r *http.Reque
 	if err != nil {
 		utils.JSONError(w, http.StatusInternalServerError, err)
 		return
-	} else if userConfigs == (model.UserConfigs{}) {
+	} else if userConfigs.ID == "" {
 		userConfigs, err = server.ConfigsStorage.CreateUserConfigs(server.ctx, uID)
 		if err != nil {
 			utils.JSONError(w, http.StatusInternalServerError, err)
 			return
 		}
+
+		// configs are created on the first login, so reminds shared by email become available now
+		if email, _ := r.Context().Value("userEmail").(string); email != "" {
+			if err := server.TodoStorage.ResolveInvites(server.ctx, uID, email); err != nil {
+				utils.JSONError(w, http.StatusInternalServerError, err)
+				return
+			}
+		}
 	}
 
+	setETag(w, userConfigs.Version)
 	utils.JSONFormat(w, http.StatusOK, userConfigs)
 }
 
+// configsOwner returns user id from configs path. Users can access only their own configs,
+// so ok is false when it isn't the id of authenticated user
+func configsOwner(r *http.Request) (string, bool) {
+	uID := mux.Vars(r)["id"]
+	userID, _ := r.Context().Value("userID").(string)
+
+	return uID, uID != "" && uID == userID
+}
+
+// validateRRule checks that recurrence rule, if present, can be parsed
+func validateRRule(rule *string) error {
+	if rule == nil {
+		return nil
+	}
+	if _, err := rrule.Parse(*rule); err != nil {
+		return fmt.Errorf("%w: %v", model.ErrInvalidRRule, err)
+	}
+	return nil
+}
+
 func (server *Server) HealthCheck(w http.ResponseWriter, r *http.Request) {
 	utils.JSONFormat(w, http.StatusOK, "OK")
 }
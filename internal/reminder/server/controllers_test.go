@@ -79,6 +79,41 @@ func TestControllers_AddRemind(t *testing.T) {
 			expectedStatusCode:   400,
 			expectedResponseBody: "time to deadline notification can't be less than 2 days to deadline time",
 		},
+		{
+			name: "OK with priority",
+			body: `{"description": "Test", "title": "Title", "deadline_at": "2023-04-15T16:27:00+02:00", "created_at": "14.04.2023, 15:30:35", "deadline_notify": false, "notify_period": [], "priority": "critical"}`,
+			inputTodo: domain.Todo{
+				Description:    "Test",
+				Title:          "Title",
+				UserID:         "GxRlwVXMF0UAc15VwtkYJGWdKmj2",
+				DeadlineAt:     dTime,
+				CreatedAt:      now,
+				DeadlineNotify: &b,
+				NotifyPeriod:   []time.Time{},
+				Priority:       domain.PriorityCritical,
+			},
+			mockBehavior: func(store *mockdb.MockTodoRepository, input domain.Todo) {
+				store.EXPECT().CreateRemind(gomock.Any(), input).Return(input, nil)
+			},
+			expectedStatusCode:   201,
+			expectedResponseBody: `"priority":"critical"`,
+		},
+		{
+			name:                 "Error - invalid priority",
+			body:                 `{"description": "Test", "title": "Title", "deadline_at": "2023-04-15T16:27:00+02:00", "created_at": "14.04.2023, 15:30:35", "priority": "asap"}`,
+			inputTodo:            domain.Todo{},
+			mockBehavior:         func(store *mockdb.MockTodoRepository, input domain.Todo) {},
+			expectedStatusCode:   422,
+			expectedResponseBody: "priority should be none, low, medium, high or critical",
+		},
+		{
+			name:                 "Error - invalid recurrence rule",
+			body:                 `{"description": "Test", "title": "Title", "deadline_at": "2023-04-15T16:27:00+02:00", "created_at": "14.04.2023, 15:30:35", "rrule": "FREQ=SOMETIMES"}`,
+			inputTodo:            domain.Todo{},
+			mockBehavior:         func(store *mockdb.MockTodoRepository, input domain.Todo) {},
+			expectedStatusCode:   400,
+			expectedResponseBody: "invalid recurrence rule",
+		},
 		{
 			name:                 "Error - wrong deadline time format",
 			body:                 `{"description": "Test", "title": "Title", "user_id": "GxRlwVXMF0UAc15VwtkYJGWdKmj2", "deadline_at": "2023-04-15", "created_at": "14.04.2023, 15:30:35", "deadline_notify": false, "notify_period": []}`,
@@ -147,7 +182,7 @@ func TestControllers_GetRemindByID(t *testing.T) {
 			name: "OK",
 			id:   1,
 			mockBehavior: func(store *mockdb.MockTodoRepository, id int) {
-				store.EXPECT().GetRemindByID(gomock.Any(), gomock.Eq(1)).Return(domain.Todo{
+				store.EXPECT().GetRemindByID(gomock.Any(), gomock.Eq(1), testUserID).Return(domain.Todo{
 					ID:          1,
 					Description: "test",
 					CreatedAt:   time.Now(),
@@ -161,7 +196,7 @@ func TestControllers_GetRemindByID(t *testing.T) {
 			name: "Not found",
 			id:   1,
 			mockBehavior: func(store *mockdb.MockTodoRepository, id int) {
-				store.EXPECT().GetRemindByID(gomock.Any(), gomock.Eq(id)).Return(domain.Todo{}, sql.ErrNoRows).Times(1)
+				store.EXPECT().GetRemindByID(gomock.Any(), gomock.Eq(id), testUserID).Return(domain.Todo{}, domain.ErrCantFindRemindWithID).Times(1)
 			},
 			expectedStatusCode: 404,
 		},
@@ -169,7 +204,7 @@ func TestControllers_GetRemindByID(t *testing.T) {
 			name: "InternalError",
 			id:   1,
 			mockBehavior: func(store *mockdb.MockTodoRepository, id int) {
-				store.EXPECT().GetRemindByID(gomock.Any(), gomock.Eq(id)).Return(domain.Todo{}, sql.ErrConnDone).Times(1)
+				store.EXPECT().GetRemindByID(gomock.Any(), gomock.Eq(id), testUserID).Return(domain.Todo{}, sql.ErrConnDone).Times(1)
 			},
 			expectedStatusCode: 500,
 		},
@@ -188,6 +223,7 @@ func TestControllers_GetRemindByID(t *testing.T) {
 
 			w := httptest.NewRecorder()
 			req, _ := http.NewRequest(http.MethodGet, "/remind", http.NoBody)
+			req = req.WithContext(context.WithValue(req.Context(), "userID", testUserID))
 			req = mux.SetURLVars(req, map[string]string{"id": "1"})
 
 			handler := http.HandlerFunc(server.GetRemindByID)
@@ -211,7 +247,7 @@ func TestServer_UpdateRemind(t *testing.T) {
 			id:   1,
 			body: `{"description":"new test", "title":"new test"}`,
 			mockBehavior: func(store *mockdb.MockTodoRepository, id int) {
-				store.EXPECT().UpdateRemind(gomock.Any(), id, domain.TodoUpdateInput{
+				store.EXPECT().UpdateRemind(gomock.Any(), id, testUserID, domain.TodoUpdateInput{
 					Description: "new test",
 					Title:       "new test",
 				}).Return(domain.Todo{Description: "new test", Title: "new test"}, nil).Times(1)
@@ -230,12 +266,24 @@ func TestServer_UpdateRemind(t *testing.T) {
 			mockBehavior:       func(store *mockdb.MockTodoRepository, id int) {},
 			expectedStatusCode: 422,
 		},
+		{
+			name: "Error - remind not found",
+			id:   1,
+			body: `{"description":"new test", "title":"new test"}`,
+			mockBehavior: func(store *mockdb.MockTodoRepository, id int) {
+				store.EXPECT().UpdateRemind(gomock.Any(), gomock.Eq(id), testUserID, domain.TodoUpdateInput{
+					Description: "new test",
+					Title:       "new test",
+				}).Return(domain.Todo{}, domain.ErrCantFindRemindWithID).Times(1)
+			},
+			expectedStatusCode: 404,
+		},
 		{
 			name: "Error - Internal error",
 			id:   1,
 			body: `{"description":"new test", "title":"new test"}`,
 			mockBehavior: func(store *mockdb.MockTodoRepository, id int) {
-				store.EXPECT().UpdateRemind(gomock.Any(), gomock.Eq(id), domain.TodoUpdateInput{
+				store.EXPECT().UpdateRemind(gomock.Any(), gomock.Eq(id), testUserID, domain.TodoUpdateInput{
 					Description: "new test",
 					Title:       "new test",
 				}).Return(domain.Todo{}, errors.New("something went wrong")).Times(1)
@@ -257,6 +305,7 @@ func TestServer_UpdateRemind(t *testing.T) {
 
 			w := httptest.NewRecorder()
 			req, _ := http.NewRequest(http.MethodPut, "/remind", bytes.NewBufferString(test.body))
+			req = req.WithContext(context.WithValue(req.Context(), "userID", testUserID))
 			req = mux.SetURLVars(req, map[string]string{"id": "1"})
 
 			handler := http.HandlerFunc(server.UpdateRemind)
@@ -267,6 +316,111 @@ func TestServer_UpdateRemind(t *testing.T) {
 	}
 }
 
+func TestServer_PatchRemind(t *testing.T) {
+	title := "new title"
+	noProject := 0
+	deadline := time.Date(2023, time.April, 15, 16, 27, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name               string
+		body               string
+		contentType        string
+		mockBehavior       func(store *mockdb.MockTodoRepository)
+		expectedStatusCode int
+	}{
+		{
+			name:        "OK - single field",
+			body:        `{"title":"new title"}`,
+			contentType: "application/merge-patch+json",
+			mockBehavior: func(store *mockdb.MockTodoRepository) {
+				store.EXPECT().PatchRemind(gomock.Any(), 1, testUserID, domain.TodoPatch{Title: &title}).Return(domain.Todo{ID: 1, Title: title, Version: 2}, nil)
+			},
+			expectedStatusCode: 200,
+		},
+		{
+			name: "OK - null removes project, deadline is truncated to minutes",
+			body: `{"project_id":null, "deadline_at":"2023-04-15T16:27:45Z"}`,
+			mockBehavior: func(store *mockdb.MockTodoRepository) {
+				store.EXPECT().PatchRemind(gomock.Any(), 1, testUserID, domain.TodoPatch{ProjectID: &noProject, DeadlineAt: &deadline}).Return(domain.Todo{ID: 1}, nil)
+			},
+			expectedStatusCode: 200,
+		},
+		{
+			name:               "Error - null title",
+			body:               `{"title":null}`,
+			mockBehavior:       func(store *mockdb.MockTodoRepository) {},
+			expectedStatusCode: 422,
+		},
+		{
+			name:               "Error - empty description",
+			body:               `{"description":""}`,
+			mockBehavior:       func(store *mockdb.MockTodoRepository) {},
+			expectedStatusCode: 422,
+		},
+		{
+			name:               "Error - unknown field",
+			body:               `{"titel":"new title"}`,
+			mockBehavior:       func(store *mockdb.MockTodoRepository) {},
+			expectedStatusCode: 422,
+		},
+		{
+			name:               "Error - patch isn't an object",
+			body:               `null`,
+			mockBehavior:       func(store *mockdb.MockTodoRepository) {},
+			expectedStatusCode: 422,
+		},
+		{
+			name:               "Error - wrong content type",
+			body:               `{"title":"new title"}`,
+			contentType:        "text/plain",
+			mockBehavior:       func(store *mockdb.MockTodoRepository) {},
+			expectedStatusCode: 415,
+		},
+		{
+			name: "Error - remind not found",
+			body: `{"title":"new title"}`,
+			mockBehavior: func(store *mockdb.MockTodoRepository) {
+				store.EXPECT().PatchRemind(gomock.Any(), 1, testUserID, domain.TodoPatch{Title: &title}).Return(domain.Todo{}, domain.ErrCantFindRemindWithID)
+			},
+			expectedStatusCode: 404,
+		},
+		{
+			name: "Error - archived project",
+			body: `{"project_id":2}`,
+			mockBehavior: func(store *mockdb.MockTodoRepository) {
+				store.EXPECT().PatchRemind(gomock.Any(), 1, testUserID, gomock.Any()).Return(domain.Todo{}, domain.ErrProjectArchived)
+			},
+			expectedStatusCode: 422,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			configStore := mockdb.NewMockConfigRepository(c)
+			todoStore := mockdb.NewMockTodoRepository(c)
+			test.mockBehavior(todoStore)
+
+			server := newTestServer(todoStore, configStore)
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodPatch, "/remind/1", bytes.NewBufferString(test.body))
+			if test.contentType != "" {
+				req.Header.Set("Content-Type", test.contentType)
+			}
+			req = req.WithContext(context.WithValue(req.Context(), "userID", testUserID))
+			req = mux.SetURLVars(req, map[string]string{"id": "1"})
+
+			handler := http.HandlerFunc(server.PatchRemind)
+			handler.ServeHTTP(w, req)
+
+			require.Equal(t, test.expectedStatusCode, w.Code)
+		})
+	}
+}
+
 func TestControllers_GetReminds(t *testing.T) {
 	testCases := []struct {
 		name               string
@@ -282,7 +436,7 @@ func TestControllers_GetReminds(t *testing.T) {
 					Cursor: 0,
 					Limit:  10,
 				},
-				FilterByDate:  "createdAt",
+				FilterByDate:  "CreatedAt",
 				FilterBySort:  "ASC",
 				FilterByQuery: "all",
 			},
@@ -299,6 +453,163 @@ func TestControllers_GetReminds(t *testing.T) {
 			},
 			expectedStatusCode: 200,
 		},
+		{
+			name: "OK with occurrences range",
+			params: domain.FetchParams{
+				Page: utils.Page{
+					Cursor: 0,
+					Limit:  10,
+				},
+				FilterByDate:  "CreatedAt",
+				FilterBySort:  "ASC",
+				FilterByQuery: "current",
+				Occurrences: domain.TimeRangeFilter{
+					StartRange: "2023-04-01T00:00:00Z",
+					EndRange:   "2023-05-01T00:00:00Z",
+				},
+			},
+			userID: "rrdZH9ERxueDxj2m1e1T2vIQKBP2",
+			mockBehavior: func(store *mockdb.MockTodoRepository, params domain.FetchParams, userID string) {
+				store.EXPECT().GetReminds(context.Background(), params, userID).Return([]domain.Todo{}, 0, 0, nil).Times(1)
+			},
+			expectedStatusCode: 200,
+		},
+		{
+			name: "OK with all tags",
+			params: domain.FetchParams{
+				Page: utils.Page{
+					Cursor: 0,
+					Limit:  10,
+				},
+				FilterByDate:  "CreatedAt",
+				FilterBySort:  "ASC",
+				FilterByQuery: "all",
+				Tags:          []int{1, 2},
+				TagsMatch:     domain.TagsMatchAll,
+			},
+			userID: "rrdZH9ERxueDxj2m1e1T2vIQKBP2",
+			mockBehavior: func(store *mockdb.MockTodoRepository, params domain.FetchParams, userID string) {
+				store.EXPECT().GetReminds(context.Background(), params, userID).Return([]domain.Todo{}, 0, 0, nil).Times(1)
+			},
+			expectedStatusCode: 200,
+		},
+		{
+			name: "OK with project",
+			params: domain.FetchParams{
+				Page: utils.Page{
+					Cursor: 0,
+					Limit:  10,
+				},
+				FilterByDate:  "CreatedAt",
+				FilterBySort:  "ASC",
+				FilterByQuery: "all",
+				ProjectID:     3,
+			},
+			userID: "rrdZH9ERxueDxj2m1e1T2vIQKBP2",
+			mockBehavior: func(store *mockdb.MockTodoRepository, params domain.FetchParams, userID string) {
+				store.EXPECT().GetReminds(context.Background(), params, userID).Return([]domain.Todo{}, 0, 0, nil).Times(1)
+			},
+			expectedStatusCode: 200,
+		},
+		{
+			name: "OK with search by relevance",
+			params: domain.FetchParams{
+				Page: utils.Page{
+					Cursor: 0,
+					Limit:  10,
+				},
+				FilterByQuery: "current",
+				Search:        "buy milk",
+			},
+			userID: "rrdZH9ERxueDxj2m1e1T2vIQKBP2",
+			mockBehavior: func(store *mockdb.MockTodoRepository, params domain.FetchParams, userID string) {
+				store.EXPECT().GetReminds(context.Background(), params, userID).Return([]domain.Todo{}, 0, 0, nil).Times(1)
+			},
+			expectedStatusCode: 200,
+		},
+		{
+			name: "OK with search and sort",
+			params: domain.FetchParams{
+				Page: utils.Page{
+					Cursor: 0,
+					Limit:  10,
+				},
+				FilterByDate:  "DeadlineAt",
+				FilterBySort:  "ASC",
+				FilterByQuery: "all",
+				Search:        "milk",
+			},
+			userID: "rrdZH9ERxueDxj2m1e1T2vIQKBP2",
+			mockBehavior: func(store *mockdb.MockTodoRepository, params domain.FetchParams, userID string) {
+				store.EXPECT().GetReminds(context.Background(), params, userID).Return([]domain.Todo{}, 0, 0, nil).Times(1)
+			},
+			expectedStatusCode: 200,
+		},
+		{
+			name: "Error search with wrong sort",
+			params: domain.FetchParams{
+				Page: utils.Page{
+					Cursor: 0,
+					Limit:  10,
+				},
+				FilterByDate:  "Title",
+				FilterByQuery: "all",
+				Search:        "milk",
+			},
+			userID:             "rrdZH9ERxueDxj2m1e1T2vIQKBP2",
+			mockBehavior:       func(store *mockdb.MockTodoRepository, params domain.FetchParams, userID string) {},
+			expectedStatusCode: 400,
+		},
+		{
+			name: "Error too long search",
+			params: domain.FetchParams{
+				Page: utils.Page{
+					Cursor: 0,
+					Limit:  10,
+				},
+				FilterByQuery: "all",
+				Search:        strings.Repeat("milk ", 50),
+			},
+			userID:             "rrdZH9ERxueDxj2m1e1T2vIQKBP2",
+			mockBehavior:       func(store *mockdb.MockTodoRepository, params domain.FetchParams, userID string) {},
+			expectedStatusCode: 400,
+		},
+		{
+			name: "Error wrong tags match",
+			params: domain.FetchParams{
+				Page: utils.Page{
+					Cursor: 0,
+					Limit:  10,
+				},
+				FilterByDate:  "CreatedAt",
+				FilterBySort:  "ASC",
+				FilterByQuery: "all",
+				Tags:          []int{1},
+				TagsMatch:     "some",
+			},
+			userID:             "rrdZH9ERxueDxj2m1e1T2vIQKBP2",
+			mockBehavior:       func(store *mockdb.MockTodoRepository, params domain.FetchParams, userID string) {},
+			expectedStatusCode: 400,
+		},
+		{
+			name: "Error wrong occurrences range",
+			params: domain.FetchParams{
+				Page: utils.Page{
+					Cursor: 0,
+					Limit:  10,
+				},
+				FilterByDate:  "CreatedAt",
+				FilterBySort:  "ASC",
+				FilterByQuery: "current",
+				Occurrences: domain.TimeRangeFilter{
+					StartRange: "2023-05-01T00:00:00Z",
+					EndRange:   "2023-04-01",
+				},
+			},
+			userID:             "rrdZH9ERxueDxj2m1e1T2vIQKBP2",
+			mockBehavior:       func(store *mockdb.MockTodoRepository, params domain.FetchParams, userID string) {},
+			expectedStatusCode: 400,
+		},
 		{
 			name: "Error wrong filter",
 			params: domain.FetchParams{
@@ -329,6 +640,36 @@ func TestControllers_GetReminds(t *testing.T) {
 			mockBehavior:       func(store *mockdb.MockTodoRepository, params domain.FetchParams, userID string) {},
 			expectedStatusCode: 400,
 		},
+		{
+			name: "Error not allowed filter",
+			params: domain.FetchParams{
+				Page: utils.Page{
+					Cursor: 0,
+					Limit:  10,
+				},
+				FilterByDate:  "Title",
+				FilterBySort:  "ASC",
+				FilterByQuery: "all",
+			},
+			userID:             "rrdZH9ERxueDxj2m1e1T2vIQKBP2",
+			mockBehavior:       func(store *mockdb.MockTodoRepository, params domain.FetchParams, userID string) {},
+			expectedStatusCode: 400,
+		},
+		{
+			name: "Error lowercase filter option",
+			params: domain.FetchParams{
+				Page: utils.Page{
+					Cursor: 5,
+					Limit:  10,
+				},
+				FilterByDate:  "Priority",
+				FilterBySort:  "desc",
+				FilterByQuery: "all",
+			},
+			userID:             "rrdZH9ERxueDxj2m1e1T2vIQKBP2",
+			mockBehavior:       func(store *mockdb.MockTodoRepository, params domain.FetchParams, userID string) {},
+			expectedStatusCode: 400,
+		},
 		{
 			name: "Error wrong filter options",
 			params: domain.FetchParams{
@@ -371,6 +712,26 @@ func TestControllers_GetReminds(t *testing.T) {
 			q.Add("filter", fmt.Sprintf("%s", test.params.FilterByDate))
 			q.Add("filterOption", fmt.Sprintf("%s", test.params.FilterBySort))
 			q.Add("filterParams", fmt.Sprintf("%s", test.params.FilterByQuery))
+			if test.params.Occurrences.StartRange != "" {
+				q.Add("occurrencesFrom", test.params.Occurrences.StartRange)
+				q.Add("occurrencesTo", test.params.Occurrences.EndRange)
+			}
+			if len(test.params.Tags) > 0 {
+				tags := make([]string, len(test.params.Tags))
+				for i, tagID := range test.params.Tags {
+					tags[i] = strconv.Itoa(tagID)
+				}
+				q.Add("tags", strings.Join(tags, ","))
+			}
+			if test.params.TagsMatch != "" {
+				q.Add("tagsMatch", test.params.TagsMatch)
+			}
+			if test.params.ProjectID != 0 {
+				q.Add("project", strconv.Itoa(test.params.ProjectID))
+			}
+			if test.params.Search != "" {
+				q.Add("q", test.params.Search)
+			}
 			req.URL.RawQuery = q.Encode()
 
 			handler := http.HandlerFunc(server.GetReminds)
@@ -381,6 +742,67 @@ func TestControllers_GetReminds(t *testing.T) {
 	}
 }
 
+func TestControllers_GetRemindsHostileParams(t *testing.T) {
+	testCases := []struct {
+		name  string
+		query map[string]string
+	}{
+		{
+			name:  "sql in filter",
+			query: map[string]string{"filter": `CreatedAt" ASC; DROP TABLE reminder.todo; --`, "filterOption": "ASC"},
+		},
+		{
+			name:  "sql in filterOption",
+			query: map[string]string{"filter": "CreatedAt", "filterOption": "ASC; DELETE FROM reminder.todo"},
+		},
+		{
+			name:  "quoted column in filter",
+			query: map[string]string{"filter": `"User"`, "filterOption": "DESC"},
+		},
+		{
+			name:  "lower case filterOption",
+			query: map[string]string{"filter": "CreatedAt", "filterOption": "desc"},
+		},
+		{
+			name:  "sql in cursor",
+			query: map[string]string{"filter": "CreatedAt", "filterOption": "ASC", "cursor": "1 OR 1=1"},
+		},
+		{
+			name:  "sql in limit",
+			query: map[string]string{"filter": "CreatedAt", "filterOption": "ASC", "limit": "10; --"},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			configStore := mockdb.NewMockConfigRepository(c)
+			// no storage calls are expected, hostile values never reach the query
+			todoStore := mockdb.NewMockTodoRepository(c)
+
+			server := newTestServer(todoStore, configStore)
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodGet, "/reminds", http.NoBody)
+			req = req.WithContext(context.WithValue(req.Context(), "userID", testUserID))
+
+			q := req.URL.Query()
+			q.Add("filterParams", "all")
+			for key, value := range test.query {
+				q.Add(key, value)
+			}
+			req.URL.RawQuery = q.Encode()
+
+			handler := http.HandlerFunc(server.GetReminds)
+			handler.ServeHTTP(w, req)
+
+			require.Equal(t, http.StatusBadRequest, w.Code)
+		})
+	}
+}
+
 func Test_DeleteRemind(t *testing.T) {
 	testCases := []struct {
 		name           string
@@ -392,7 +814,7 @@ func Test_DeleteRemind(t *testing.T) {
 			name: "OK",
 			id:   1,
 			mockBehavior: func(store *mockdb.MockTodoRepository, id int) {
-				store.EXPECT().DeleteRemind(gomock.Any(), gomock.Eq(id)).Return(nil).Times(1)
+				store.EXPECT().DeleteRemind(gomock.Any(), gomock.Eq(id), testUserID).Return(nil).Times(1)
 			},
 			expectedStatus: 204,
 		},
@@ -400,7 +822,7 @@ func Test_DeleteRemind(t *testing.T) {
 			name: "InternalError",
 			id:   1,
 			mockBehavior: func(store *mockdb.MockTodoRepository, id int) {
-				store.EXPECT().DeleteRemind(gomock.Any(), gomock.Eq(id)).Return(sql.ErrConnDone).Times(1)
+				store.EXPECT().DeleteRemind(gomock.Any(), gomock.Eq(id), testUserID).Return(sql.ErrConnDone).Times(1)
 			},
 			expectedStatus: 500,
 		},
@@ -408,7 +830,7 @@ func Test_DeleteRemind(t *testing.T) {
 			name: "Error remind doesn't exist",
 			id:   1,
 			mockBehavior: func(store *mockdb.MockTodoRepository, id int) {
-				store.EXPECT().DeleteRemind(gomock.Any(), gomock.Eq(id)).Return(domain.ErrCantFindRemindWithID).Times(1)
+				store.EXPECT().DeleteRemind(gomock.Any(), gomock.Eq(id), testUserID).Return(domain.ErrCantFindRemindWithID).Times(1)
 			},
 			expectedStatus: 404,
 		},
@@ -427,6 +849,7 @@ func Test_DeleteRemind(t *testing.T) {
 
 			w := httptest.NewRecorder()
 			req, _ := http.NewRequest(http.MethodDelete, "/remind", http.NoBody)
+			req = req.WithContext(context.WithValue(req.Context(), "userID", testUserID))
 			req = mux.SetURLVars(req, map[string]string{"id": "1"})
 
 			handler := http.HandlerFunc(server.DeleteRemind)
@@ -455,7 +878,7 @@ func TestServer_UpdateUserConfig(t *testing.T) {
 				store.EXPECT().UpdateUserConfig(gomock.Any(), gomock.Eq(id), domain.UserConfigs{
 					Notification: true,
 					Period:       1,
-				}).Return(nil).Times(1)
+				}).Return(domain.UserConfigs{ID: id, Notification: true, Period: 1, Version: 2}, nil).Times(1)
 			},
 			expectedStatusCode: 200,
 		},
@@ -467,10 +890,63 @@ func TestServer_UpdateUserConfig(t *testing.T) {
 				store.EXPECT().UpdateUserConfig(gomock.Any(), gomock.Eq(id), domain.UserConfigs{
 					Notification: true,
 					Period:       1,
-				}).Return(errors.New("something went wrong")).Times(1)
+				}).Return(domain.UserConfigs{}, errors.New("something went wrong")).Times(1)
 			},
 			expectedStatusCode: 500,
 		},
+		{
+			name: "Error - configs not found",
+			id:   "rrdZH9ERxueDxj2m1e1T2vIQKBP2",
+			body: `{"notification": true, "period": 1}`,
+			mockBehavior: func(store *mockdb.MockConfigRepository, id string) {
+				store.EXPECT().UpdateUserConfig(gomock.Any(), gomock.Eq(id), domain.UserConfigs{
+					Notification: true,
+					Period:       1,
+				}).Return(domain.UserConfigs{}, domain.ErrCantFindUserConfigs).Times(1)
+			},
+			expectedStatusCode: 404,
+		},
+		{
+			name:               "Error - configs of another user",
+			id:                 "another user",
+			body:               `{"notification": true, "period": 1}`,
+			mockBehavior:       func(store *mockdb.MockConfigRepository, id string) {},
+			expectedStatusCode: 404,
+		},
+		{
+			name: "OK - channels",
+			id:   "rrdZH9ERxueDxj2m1e1T2vIQKBP2",
+			body: `{"notification": true, "period": 1, "channels": [{"channel": "email", "address": "me@example.com"}]}`,
+			mockBehavior: func(store *mockdb.MockConfigRepository, id string) {
+				store.EXPECT().UpdateUserConfig(gomock.Any(), gomock.Eq(id), domain.UserConfigs{
+					Notification: true,
+					Period:       1,
+					Channels:     []domain.ChannelPreference{{Channel: domain.ChannelEmail, Address: "me@example.com"}},
+				}).Return(domain.UserConfigs{ID: id, Notification: true, Period: 1, Version: 2}, nil).Times(1)
+			},
+			expectedStatusCode: 200,
+		},
+		{
+			name:               "Error - unknown channel",
+			id:                 "rrdZH9ERxueDxj2m1e1T2vIQKBP2",
+			body:               `{"channels": [{"channel": "pigeon", "address": "roof"}]}`,
+			mockBehavior:       func(store *mockdb.MockConfigRepository, id string) {},
+			expectedStatusCode: 422,
+		},
+		{
+			name:               "Error - invalid channel address",
+			id:                 "rrdZH9ERxueDxj2m1e1T2vIQKBP2",
+			body:               `{"channels": [{"channel": "email", "address": "not email"}]}`,
+			mockBehavior:       func(store *mockdb.MockConfigRepository, id string) {},
+			expectedStatusCode: 422,
+		},
+		{
+			name:               "Error - channel isn't enabled",
+			id:                 "rrdZH9ERxueDxj2m1e1T2vIQKBP2",
+			body:               `{"channels": [{"channel": "slack", "address": "https://hooks.slack.com/services/T/B/X"}]}`,
+			mockBehavior:       func(store *mockdb.MockConfigRepository, id string) {},
+			expectedStatusCode: 422,
+		},
 	}
 
 	for _, test := range testCases {
@@ -486,7 +962,8 @@ func TestServer_UpdateUserConfig(t *testing.T) {
 
 			w := httptest.NewRecorder()
 			req, _ := http.NewRequest(http.MethodPut, "/configs", bytes.NewBufferString(test.body))
-			req = mux.SetURLVars(req, map[string]string{"id": "rrdZH9ERxueDxj2m1e1T2vIQKBP2"})
+			req = req.WithContext(context.WithValue(req.Context(), "userID", testUserID))
+			req = mux.SetURLVars(req, map[string]string{"id": test.id})
 
 			handler := http.HandlerFunc(server.UpdateUserConfig)
 			handler.ServeHTTP(w, req)
@@ -496,6 +973,48 @@ func TestServer_UpdateUserConfig(t *testing.T) {
 	}
 }
 
+func TestServer_validateChannels(t *testing.T) {
+	server := newTestServer(nil, nil)
+	server.config.Channels.Webhook.Enabled = true
+	server.config.Channels.Slack.Enabled = true
+
+	testCases := []struct {
+		name    string
+		pref    domain.ChannelPreference
+		wantErr bool
+	}{
+		{name: "public webhook", pref: domain.ChannelPreference{Channel: domain.ChannelWebhook, Address: "https://example.com/hook"}},
+		{name: "slack", pref: domain.ChannelPreference{Channel: domain.ChannelSlack, Address: "https://hooks.slack.com/services/T/B/X"}},
+		{name: "loopback webhook", pref: domain.ChannelPreference{Channel: domain.ChannelWebhook, Address: "http://127.0.0.1:8000/hook"}, wantErr: true},
+		{name: "private webhook", pref: domain.ChannelPreference{Channel: domain.ChannelWebhook, Address: "http://10.0.0.5/hook"}, wantErr: true},
+		{name: "metadata webhook", pref: domain.ChannelPreference{Channel: domain.ChannelWebhook, Address: "http://169.254.169.254/latest/meta-data"}, wantErr: true},
+		{name: "IPv6 loopback webhook", pref: domain.ChannelPreference{Channel: domain.ChannelWebhook, Address: "http://[::1]/hook"}, wantErr: true},
+		{name: "localhost webhook", pref: domain.ChannelPreference{Channel: domain.ChannelWebhook, Address: "http://localhost:8000/hook"}, wantErr: true},
+		{name: "internal host webhook", pref: domain.ChannelPreference{Channel: domain.ChannelWebhook, Address: "http://metadata.google.internal/"}, wantErr: true},
+		{name: "slack on another host", pref: domain.ChannelPreference{Channel: domain.ChannelSlack, Address: "https://example.com/services/T/B/X"}, wantErr: true},
+		{name: "slack over http", pref: domain.ChannelPreference{Channel: domain.ChannelSlack, Address: "http://hooks.slack.com/services/T/B/X"}, wantErr: true},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			err := server.validateChannels([]domain.ChannelPreference{test.pref})
+			if test.wantErr {
+				require.ErrorIs(t, err, domain.ErrInvalidChannel)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+
+	t.Run("private networks are allowed", func(t *testing.T) {
+		server.config.Channels.AllowPrivateNetworks = true
+
+		require.NoError(t, server.validateChannels([]domain.ChannelPreference{
+			{Channel: domain.ChannelWebhook, Address: "http://localhost:8000/hook"},
+		}))
+	})
+}
+
 func TestServer_AuthMiddleware(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -518,6 +1037,38 @@ func TestServer_AuthMiddleware(t *testing.T) {
 			expectedStatus: http.StatusOK,
 			expectedBody:   "OK",
 		},
+		{
+			name:  "valid token with email",
+			token: "Bearer valid_token",
+			mockBehavior: func(store *mock_firestore.MockClient, token string) {
+				store.EXPECT().VerifyIDToken("valid_token").Return(&auth.Token{
+					UID: "user123",
+					Claims: map[string]interface{}{
+						"user_id":        "user123",
+						"email":          "user@example.com",
+						"email_verified": true,
+					},
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   "OK user@example.com",
+		},
+		{
+			name:  "valid token with not verified email",
+			token: "Bearer valid_token",
+			mockBehavior: func(store *mock_firestore.MockClient, token string) {
+				store.EXPECT().VerifyIDToken("valid_token").Return(&auth.Token{
+					UID: "user123",
+					Claims: map[string]interface{}{
+						"user_id":        "user123",
+						"email":          "user@example.com",
+						"email_verified": false,
+					},
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   "OK",
+		},
 		{
 			name:           "no authorization header",
 			token:          "",
@@ -568,6 +1119,9 @@ func TestServer_AuthMiddleware(t *testing.T) {
 			handler := server.AuthMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 				w.WriteHeader(http.StatusOK)
 				w.Write([]byte("OK"))
+				if email, ok := r.Context().Value("userEmail").(string); ok {
+					w.Write([]byte(" " + email))
+				}
 			}))
 
 			handler.ServeHTTP(rec, req)
@@ -579,6 +1133,9 @@ func TestServer_AuthMiddleware(t *testing.T) {
 			if !strings.Contains(rec.Body.String(), tt.expectedBody) {
 				t.Errorf("handler returned unexpected body: got %v want %v", rec.Body.String(), tt.expectedBody)
 			}
+			if tt.expectedStatus == http.StatusOK && rec.Body.String() != tt.expectedBody {
+				t.Errorf("handler returned unexpected body: got %v want %v", rec.Body.String(), tt.expectedBody)
+			}
 		})
 	}
 }
@@ -598,13 +1155,58 @@ func Test_UpdateCompleteStatus(t *testing.T) {
 			id:   1,
 			body: `{"completed": true}`,
 			mockBehavior: func(store *mockdb.MockTodoRepository, id int) {
-				store.EXPECT().UpdateStatus(gomock.Any(), gomock.Eq(id), domain.TodoUpdateStatusInput{
+				store.EXPECT().UpdateStatus(gomock.Any(), gomock.Eq(id), testUserID, domain.TodoUpdateStatusInput{
 					Completed:  true,
 					FinishedAt: &tn,
 				}).Return(nil).Times(1)
 			},
 			expectedStatusCode: 200,
 		},
+		{
+			name: "OK - complete children",
+			id:   1,
+			body: `{"completed": true, "children": "complete"}`,
+			mockBehavior: func(store *mockdb.MockTodoRepository, id int) {
+				store.EXPECT().UpdateStatus(gomock.Any(), gomock.Eq(id), testUserID, domain.TodoUpdateStatusInput{
+					Completed:  true,
+					FinishedAt: &tn,
+					Children:   domain.ChildrenComplete,
+				}).Return(nil).Times(1)
+			},
+			expectedStatusCode: 200,
+		},
+		{
+			name: "Error - incomplete children",
+			id:   1,
+			body: `{"completed": true, "children": "block"}`,
+			mockBehavior: func(store *mockdb.MockTodoRepository, id int) {
+				store.EXPECT().UpdateStatus(gomock.Any(), gomock.Eq(id), testUserID, domain.TodoUpdateStatusInput{
+					Completed:  true,
+					FinishedAt: &tn,
+					Children:   domain.ChildrenBlock,
+				}).Return(domain.ErrIncompleteItems).Times(1)
+			},
+			expectedStatusCode: 409,
+		},
+		{
+			name: "Error - remind of another user",
+			id:   1,
+			body: `{"completed": true}`,
+			mockBehavior: func(store *mockdb.MockTodoRepository, id int) {
+				store.EXPECT().UpdateStatus(gomock.Any(), gomock.Eq(id), testUserID, domain.TodoUpdateStatusInput{
+					Completed:  true,
+					FinishedAt: &tn,
+				}).Return(domain.ErrCantFindRemindWithID).Times(1)
+			},
+			expectedStatusCode: 404,
+		},
+		{
+			name:               "Error - wrong children value",
+			id:                 1,
+			body:               `{"completed": true, "children": "ignore"}`,
+			mockBehavior:       func(store *mockdb.MockTodoRepository, id int) {},
+			expectedStatusCode: 422,
+		},
 		{
 			name:               "Error - Missed Body",
 			id:                 1,
@@ -617,7 +1219,7 @@ func Test_UpdateCompleteStatus(t *testing.T) {
 			id:   1,
 			body: `{"completed": true}`,
 			mockBehavior: func(store *mockdb.MockTodoRepository, id int) {
-				store.EXPECT().UpdateStatus(gomock.Any(), gomock.Eq(id), domain.TodoUpdateStatusInput{
+				store.EXPECT().UpdateStatus(gomock.Any(), gomock.Eq(id), testUserID, domain.TodoUpdateStatusInput{
 					Completed:  true,
 					FinishedAt: &tn,
 				}).Return(errors.New("remind not found")).Times(1)
@@ -639,7 +1241,7 @@ func Test_UpdateCompleteStatus(t *testing.T) {
 
 			w := httptest.NewRecorder()
 			req, _ := http.NewRequest(http.MethodPut, "/status", bytes.NewBufferString(test.body))
-
+			req = req.WithContext(context.WithValue(req.Context(), "userID", testUserID))
 			req = mux.SetURLVars(req, map[string]string{"id": strconv.Itoa(test.id)})
 
 			handler := http.HandlerFunc(server.UpdateCompleteStatus)
@@ -650,6 +1252,68 @@ func Test_UpdateCompleteStatus(t *testing.T) {
 	}
 }
 
+func TestServer_DismissOverdue(t *testing.T) {
+	testCases := []struct {
+		name               string
+		id                 string
+		mockBehavior       func(store *mockdb.MockTodoRepository)
+		expectedStatusCode int
+	}{
+		{
+			name: "OK",
+			id:   "1",
+			mockBehavior: func(store *mockdb.MockTodoRepository) {
+				store.EXPECT().DismissOverdue(gomock.Any(), 1, testUserID).Return(nil)
+			},
+			expectedStatusCode: 200,
+		},
+		{
+			name: "Error - not overdue",
+			id:   "1",
+			mockBehavior: func(store *mockdb.MockTodoRepository) {
+				store.EXPECT().DismissOverdue(gomock.Any(), 1, testUserID).Return(domain.ErrRemindNotOverdue)
+			},
+			expectedStatusCode: 422,
+		},
+		{
+			name: "Error - remind of another user",
+			id:   "1",
+			mockBehavior: func(store *mockdb.MockTodoRepository) {
+				store.EXPECT().DismissOverdue(gomock.Any(), 1, testUserID).Return(domain.ErrCantFindRemindWithID)
+			},
+			expectedStatusCode: 404,
+		},
+		{
+			name:               "Error - wrong id",
+			id:                 "first",
+			mockBehavior:       func(store *mockdb.MockTodoRepository) {},
+			expectedStatusCode: 400,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			todoStore := mockdb.NewMockTodoRepository(c)
+			test.mockBehavior(todoStore)
+
+			server := newTestServer(todoStore, mockdb.NewMockConfigRepository(c))
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodPut, "/remind/"+test.id+"/dismiss", http.NoBody)
+			req = req.WithContext(context.WithValue(req.Context(), "userID", testUserID))
+			req = mux.SetURLVars(req, map[string]string{"id": test.id})
+
+			handler := http.HandlerFunc(server.DismissOverdue)
+			handler.ServeHTTP(w, req)
+
+			require.Equal(t, test.expectedStatusCode, w.Code)
+		})
+	}
+}
+
 func TestServer_GetOrCreateUserConfig(t *testing.T) {
 	tn := time.Now()
 
@@ -678,7 +1342,14 @@ func TestServer_GetOrCreateUserConfig(t *testing.T) {
 			id:   "",
 			mockBehavior: func(store *mockdb.MockConfigRepository, id string) {
 			},
-			expectedStatusCode: 500,
+			expectedStatusCode: 404,
+		},
+		{
+			name: "Error - configs of another user",
+			id:   "another user",
+			mockBehavior: func(store *mockdb.MockConfigRepository, id string) {
+			},
+			expectedStatusCode: 404,
 		},
 		{
 			name: "Error - GetUserConfigs Error",
@@ -712,6 +1383,7 @@ func TestServer_GetOrCreateUserConfig(t *testing.T) {
 
 			w := httptest.NewRecorder()
 			req, _ := http.NewRequest(http.MethodGet, "/configs", http.NoBody)
+			req = req.WithContext(context.WithValue(req.Context(), "userID", testUserID))
 			req = mux.SetURLVars(req, map[string]string{"id": test.id})
 
 			handler := http.HandlerFunc(server.GetOrCreateUserConfig)
@@ -721,3 +1393,81 @@ func TestServer_GetOrCreateUserConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestServer_GetOrCreateUserConfig_ResolveInvites(t *testing.T) {
+	testCases := []struct {
+		name               string
+		userID             string
+		email              string
+		mockBehavior       func(configStore *mockdb.MockConfigRepository, todoStore *mockdb.MockTodoRepository)
+		expectedStatusCode int
+	}{
+		{
+			name:   "OK - invites resolved on first login",
+			userID: testUserID,
+			email:  "user@example.com",
+			mockBehavior: func(configStore *mockdb.MockConfigRepository, todoStore *mockdb.MockTodoRepository) {
+				configStore.EXPECT().GetUserConfigs(gomock.Any(), testUserID).Return(domain.UserConfigs{}, nil)
+				configStore.EXPECT().CreateUserConfigs(gomock.Any(), testUserID).Return(domain.UserConfigs{ID: testUserID}, nil)
+				todoStore.EXPECT().ResolveInvites(gomock.Any(), testUserID, "user@example.com").Return(nil)
+			},
+			expectedStatusCode: 200,
+		},
+		{
+			name:   "OK - existing configs",
+			userID: testUserID,
+			email:  "user@example.com",
+			mockBehavior: func(configStore *mockdb.MockConfigRepository, todoStore *mockdb.MockTodoRepository) {
+				configStore.EXPECT().GetUserConfigs(gomock.Any(), testUserID).Return(domain.UserConfigs{ID: testUserID}, nil)
+			},
+			expectedStatusCode: 200,
+		},
+		{
+			name:   "OK - no email in token",
+			userID: testUserID,
+			mockBehavior: func(configStore *mockdb.MockConfigRepository, todoStore *mockdb.MockTodoRepository) {
+				configStore.EXPECT().GetUserConfigs(gomock.Any(), testUserID).Return(domain.UserConfigs{}, nil)
+				configStore.EXPECT().CreateUserConfigs(gomock.Any(), testUserID).Return(domain.UserConfigs{ID: testUserID}, nil)
+			},
+			expectedStatusCode: 200,
+		},
+		{
+			name:   "Error - ResolveInvites error",
+			userID: testUserID,
+			email:  "user@example.com",
+			mockBehavior: func(configStore *mockdb.MockConfigRepository, todoStore *mockdb.MockTodoRepository) {
+				configStore.EXPECT().GetUserConfigs(gomock.Any(), testUserID).Return(domain.UserConfigs{}, nil)
+				configStore.EXPECT().CreateUserConfigs(gomock.Any(), testUserID).Return(domain.UserConfigs{ID: testUserID}, nil)
+				todoStore.EXPECT().ResolveInvites(gomock.Any(), testUserID, "user@example.com").Return(errors.New("something went wrong"))
+			},
+			expectedStatusCode: 500,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			configStore := mockdb.NewMockConfigRepository(c)
+			todoStore := mockdb.NewMockTodoRepository(c)
+			test.mockBehavior(configStore, todoStore)
+
+			server := newTestServer(todoStore, configStore)
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodGet, "/configs", http.NoBody)
+			ctx := context.WithValue(req.Context(), "userID", test.userID)
+			if test.email != "" {
+				ctx = context.WithValue(ctx, "userEmail", test.email)
+			}
+			req = req.WithContext(ctx)
+			req = mux.SetURLVars(req, map[string]string{"id": testUserID})
+
+			handler := http.HandlerFunc(server.GetOrCreateUserConfig)
+			handler.ServeHTTP(w, req)
+
+			require.Equal(t, test.expectedStatusCode, w.Code)
+		})
+	}
+}
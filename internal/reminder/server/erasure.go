@@ -0,0 +1,66 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	model "github.com/red-rocket-software/reminder-go/internal/reminder/domain"
+	"github.com/red-rocket-software/reminder-go/pkg/utils"
+)
+
+// RequestErasure schedule erasure of all data of user
+//
+//	@Description	RequestErasure
+//	@Summary		schedule deletion of all reminds, tags, projects and configs of user after grace period
+//	@Tags			account
+//	@Produce		json
+//	@Success		202	{object}	domain.Erasure
+//
+//	@Failure		404	{object}	utils.HTTPError
+//	@Failure		500	{object}	utils.HTTPError
+//
+//	@Router			/account/erasure [post]
+func (server *Server) RequestErasure(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+
+	eraseAt := time.Now().AddDate(0, 0, server.config.Erasure.GraceDays).Truncate(time.Second)
+
+	erasure, err := server.ConfigsStorage.RequestErasure(server.ctx, userID, eraseAt)
+	if err != nil {
+		erasureError(w, err)
+		return
+	}
+
+	utils.JSONFormat(w, http.StatusAccepted, erasure)
+}
+
+// CancelErasure cancel scheduled erasure of user data
+//
+//	@Description	CancelErasure
+//	@Summary		cancel scheduled deletion of user data during grace period
+//	@Tags			account
+//	@Success		204
+//
+//	@Failure		404	{object}	utils.HTTPError
+//	@Failure		500	{object}	utils.HTTPError
+//
+//	@Router			/account/erasure [delete]
+func (server *Server) CancelErasure(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+
+	if err := server.ConfigsStorage.CancelErasure(server.ctx, userID); err != nil {
+		erasureError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func erasureError(w http.ResponseWriter, err error) {
+	if errors.Is(err, model.ErrCantFindUserConfigs) || errors.Is(err, model.ErrErasureNotRequested) {
+		utils.JSONError(w, http.StatusNotFound, err)
+		return
+	}
+	utils.JSONError(w, http.StatusInternalServerError, err)
+}
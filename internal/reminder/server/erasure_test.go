@@ -0,0 +1,112 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/red-rocket-software/reminder-go/internal/reminder/domain"
+	mockdb "github.com/red-rocket-software/reminder-go/internal/reminder/domain/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServer_RequestErasure(t *testing.T) {
+	testCases := []struct {
+		name               string
+		mockBehavior       func(store *mockdb.MockConfigRepository)
+		expectedStatusCode int
+	}{
+		{
+			name: "OK - erasure after grace period",
+			mockBehavior: func(store *mockdb.MockConfigRepository) {
+				store.EXPECT().RequestErasure(gomock.Any(), testUserID, gomock.Any()).DoAndReturn(func(_ context.Context, userID string, eraseAt time.Time) (domain.Erasure, error) {
+					require.WithinDuration(t, time.Now().AddDate(0, 0, 14), eraseAt, time.Minute)
+					return domain.Erasure{UserID: userID, RequestedAt: time.Now(), EraseAt: eraseAt}, nil
+				})
+			},
+			expectedStatusCode: 202,
+		},
+		{
+			name: "Error - no configs",
+			mockBehavior: func(store *mockdb.MockConfigRepository) {
+				store.EXPECT().RequestErasure(gomock.Any(), testUserID, gomock.Any()).Return(domain.Erasure{}, domain.ErrCantFindUserConfigs)
+			},
+			expectedStatusCode: 404,
+		},
+		{
+			name: "Error - storage",
+			mockBehavior: func(store *mockdb.MockConfigRepository) {
+				store.EXPECT().RequestErasure(gomock.Any(), testUserID, gomock.Any()).Return(domain.Erasure{}, errors.New("something went wrong"))
+			},
+			expectedStatusCode: 500,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			todoStore := mockdb.NewMockTodoRepository(c)
+			configStore := mockdb.NewMockConfigRepository(c)
+			test.mockBehavior(configStore)
+
+			server := newTestServer(todoStore, configStore)
+			server.config.Erasure.GraceDays = 14
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodPost, "/account/erasure", http.NoBody)
+			req = req.WithContext(context.WithValue(req.Context(), "userID", testUserID))
+
+			handler := http.HandlerFunc(server.RequestErasure)
+			handler.ServeHTTP(w, req)
+
+			require.Equal(t, test.expectedStatusCode, w.Code)
+
+			if test.expectedStatusCode == 202 {
+				var erasure domain.Erasure
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &erasure))
+				require.Equal(t, testUserID, erasure.UserID)
+			}
+		})
+	}
+}
+
+func TestServer_CancelErasure(t *testing.T) {
+	testCases := []struct {
+		name               string
+		storageErr         error
+		expectedStatusCode int
+	}{
+		{name: "OK", expectedStatusCode: 204},
+		{name: "Error - not requested", storageErr: domain.ErrErasureNotRequested, expectedStatusCode: 404},
+		{name: "Error - storage", storageErr: errors.New("something went wrong"), expectedStatusCode: 500},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			todoStore := mockdb.NewMockTodoRepository(c)
+			configStore := mockdb.NewMockConfigRepository(c)
+			configStore.EXPECT().CancelErasure(gomock.Any(), testUserID).Return(test.storageErr)
+
+			server := newTestServer(todoStore, configStore)
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodDelete, "/account/erasure", http.NoBody)
+			req = req.WithContext(context.WithValue(req.Context(), "userID", testUserID))
+
+			handler := http.HandlerFunc(server.CancelErasure)
+			handler.ServeHTTP(w, req)
+
+			require.Equal(t, test.expectedStatusCode, w.Code)
+		})
+	}
+}
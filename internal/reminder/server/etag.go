@@ -0,0 +1,33 @@
+package server
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// setETag sets version of remind or configs as strong ETag of response
+func setETag(w http.ResponseWriter, version int) {
+	w.Header().Set("ETag", strconv.Quote(strconv.Itoa(version)))
+}
+
+// ifMatch returns version from If-Match header. Missing header and "*" give 0 which matches any version.
+// ok is false when header isn't a version ETag, weak ETags are never matched as If-Match requires
+func ifMatch(r *http.Request) (version int, ok bool) {
+	header := strings.TrimSpace(r.Header.Get("If-Match"))
+	if header == "" || header == "*" {
+		return 0, true
+	}
+
+	tag, err := strconv.Unquote(header)
+	if err != nil {
+		return 0, false
+	}
+
+	version, err = strconv.Atoi(tag)
+	if err != nil || version <= 0 {
+		return 0, false
+	}
+
+	return version, true
+}
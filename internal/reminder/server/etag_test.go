@@ -0,0 +1,180 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/red-rocket-software/reminder-go/internal/reminder/domain"
+	mockdb "github.com/red-rocket-software/reminder-go/internal/reminder/domain/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIfMatch(t *testing.T) {
+	testCases := []struct {
+		header          string
+		expectedVersion int
+		expectedOK      bool
+	}{
+		{header: "", expectedVersion: 0, expectedOK: true},
+		{header: "*", expectedVersion: 0, expectedOK: true},
+		{header: `"3"`, expectedVersion: 3, expectedOK: true},
+		{header: `W/"3"`, expectedOK: false},
+		{header: `3`, expectedOK: false},
+		{header: `"three"`, expectedOK: false},
+		{header: `"0"`, expectedOK: false},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.header, func(t *testing.T) {
+			req, _ := http.NewRequest(http.MethodPut, "/remind/1", http.NoBody)
+			req.Header.Set("If-Match", test.header)
+
+			version, ok := ifMatch(req)
+			require.Equal(t, test.expectedOK, ok)
+			require.Equal(t, test.expectedVersion, version)
+		})
+	}
+}
+
+func TestServer_Versions(t *testing.T) {
+	testCases := []struct {
+		name               string
+		handler            func(server *Server) http.HandlerFunc
+		id                 string
+		body               string
+		ifMatch            string
+		mockBehavior       func(todoStore *mockdb.MockTodoRepository, configStore *mockdb.MockConfigRepository)
+		expectedStatusCode int
+		expectedETag       string
+	}{
+		{
+			name:    "GetRemindByID returns ETag",
+			handler: func(server *Server) http.HandlerFunc { return server.GetRemindByID },
+			id:      "1",
+			mockBehavior: func(todoStore *mockdb.MockTodoRepository, configStore *mockdb.MockConfigRepository) {
+				todoStore.EXPECT().GetRemindByID(gomock.Any(), 1, testUserID).Return(domain.Todo{ID: 1, Version: 2}, nil)
+			},
+			expectedStatusCode: 200,
+			expectedETag:       `"2"`,
+		},
+		{
+			name:    "UpdateRemind with current version",
+			handler: func(server *Server) http.HandlerFunc { return server.UpdateRemind },
+			id:      "1",
+			body:    `{"description":"new test", "title":"new test"}`,
+			ifMatch: `"2"`,
+			mockBehavior: func(todoStore *mockdb.MockTodoRepository, configStore *mockdb.MockConfigRepository) {
+				todoStore.EXPECT().UpdateRemind(gomock.Any(), 1, testUserID, domain.TodoUpdateInput{
+					Description: "new test",
+					Title:       "new test",
+					Version:     2,
+				}).Return(domain.Todo{ID: 1, Version: 3}, nil)
+			},
+			expectedStatusCode: 200,
+			expectedETag:       `"3"`,
+		},
+		{
+			name:    "UpdateRemind with stale version",
+			handler: func(server *Server) http.HandlerFunc { return server.UpdateRemind },
+			id:      "1",
+			body:    `{"description":"new test", "title":"new test"}`,
+			ifMatch: `"1"`,
+			mockBehavior: func(todoStore *mockdb.MockTodoRepository, configStore *mockdb.MockConfigRepository) {
+				todoStore.EXPECT().UpdateRemind(gomock.Any(), 1, testUserID, gomock.Any()).Return(domain.Todo{}, domain.ErrVersionMismatch)
+			},
+			expectedStatusCode: 412,
+		},
+		{
+			name:               "UpdateRemind with weak ETag",
+			handler:            func(server *Server) http.HandlerFunc { return server.UpdateRemind },
+			id:                 "1",
+			body:               `{"description":"new test", "title":"new test"}`,
+			ifMatch:            `W/"2"`,
+			mockBehavior:       func(todoStore *mockdb.MockTodoRepository, configStore *mockdb.MockConfigRepository) {},
+			expectedStatusCode: 412,
+		},
+		{
+			name:    "UpdateCompleteStatus with stale version",
+			handler: func(server *Server) http.HandlerFunc { return server.UpdateCompleteStatus },
+			id:      "1",
+			body:    `{"completed": false}`,
+			ifMatch: `"1"`,
+			mockBehavior: func(todoStore *mockdb.MockTodoRepository, configStore *mockdb.MockConfigRepository) {
+				todoStore.EXPECT().UpdateStatus(gomock.Any(), 1, testUserID, domain.TodoUpdateStatusInput{Version: 1}).Return(domain.ErrVersionMismatch)
+			},
+			expectedStatusCode: 412,
+		},
+		{
+			name:    "GetOrCreateUserConfig returns ETag",
+			handler: func(server *Server) http.HandlerFunc { return server.GetOrCreateUserConfig },
+			id:      testUserID,
+			mockBehavior: func(todoStore *mockdb.MockTodoRepository, configStore *mockdb.MockConfigRepository) {
+				configStore.EXPECT().GetUserConfigs(gomock.Any(), testUserID).Return(domain.UserConfigs{ID: testUserID, Version: 4}, nil)
+			},
+			expectedStatusCode: 200,
+			expectedETag:       `"4"`,
+		},
+		{
+			name:    "UpdateUserConfig takes version only from If-Match",
+			handler: func(server *Server) http.HandlerFunc { return server.UpdateUserConfig },
+			id:      testUserID,
+			body:    `{"notification": true, "period": 1, "version": 1}`,
+			ifMatch: `"4"`,
+			mockBehavior: func(todoStore *mockdb.MockTodoRepository, configStore *mockdb.MockConfigRepository) {
+				configStore.EXPECT().UpdateUserConfig(gomock.Any(), testUserID, domain.UserConfigs{
+					Notification: true,
+					Period:       1,
+					Version:      4,
+				}).Return(domain.UserConfigs{}, domain.ErrVersionMismatch)
+			},
+			expectedStatusCode: 412,
+		},
+		{
+			name:    "UpdateUserConfig returns new ETag",
+			handler: func(server *Server) http.HandlerFunc { return server.UpdateUserConfig },
+			id:      testUserID,
+			body:    `{"notification": true, "period": 1}`,
+			ifMatch: `"4"`,
+			mockBehavior: func(todoStore *mockdb.MockTodoRepository, configStore *mockdb.MockConfigRepository) {
+				configStore.EXPECT().UpdateUserConfig(gomock.Any(), testUserID, domain.UserConfigs{
+					Notification: true,
+					Period:       1,
+					Version:      4,
+				}).Return(domain.UserConfigs{ID: testUserID, Notification: true, Period: 1, Version: 5}, nil)
+			},
+			expectedStatusCode: 200,
+			expectedETag:       `"5"`,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			configStore := mockdb.NewMockConfigRepository(c)
+			todoStore := mockdb.NewMockTodoRepository(c)
+			test.mockBehavior(todoStore, configStore)
+
+			server := newTestServer(todoStore, configStore)
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodPut, "/", bytes.NewBufferString(test.body))
+			if test.ifMatch != "" {
+				req.Header.Set("If-Match", test.ifMatch)
+			}
+			req = req.WithContext(context.WithValue(req.Context(), "userID", testUserID))
+			req = mux.SetURLVars(req, map[string]string{"id": test.id})
+
+			test.handler(server).ServeHTTP(w, req)
+
+			require.Equal(t, test.expectedStatusCode, w.Code)
+			require.Equal(t, test.expectedETag, w.Header().Get("ETag"))
+		})
+	}
+}
@@ -0,0 +1,239 @@
+package server
+
+import (
+	"archive/zip"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	model "github.com/red-rocket-software/reminder-go/internal/reminder/domain"
+	"github.com/red-rocket-software/reminder-go/pkg/utils"
+)
+
+// remindsCSVHeader are columns of reminds.csv in account export, names match remind JSON fields
+var remindsCSVHeader = []string{
+	"id", "title", "description", "created_at", "deadline_at", "finished_at", "completed", "deadline_notify",
+	"notify_period", "priority", "project_id", "rrule", "recur_from_completion", "tags", "deleted_at", "version",
+}
+
+// accountExport is data of the user which is known before the archive is started
+type accountExport struct {
+	configs  *model.UserConfigs
+	tags     []model.Tag
+	projects []model.Project
+	views    []model.View
+}
+
+// ExportAccount return all data of the user as zip archive
+//
+//	@Description	ExportAccount
+//	@Summary		download zip archive with reminds (JSON and CSV), checklist items, tags, projects, saved views and configs of the user
+//	@Tags			account
+//	@Produce		application/zip
+//	@Success		200	{string}	string	"zip archive"
+//
+//	@Failure		500	{object}	utils.HTTPError
+//
+//	@Router			/account/export [get]
+func (server *Server) ExportAccount(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+
+	var export accountExport
+
+	configs, err := server.ConfigsStorage.GetUserConfigs(server.ctx, userID)
+	if err != nil && !errors.Is(err, model.ErrCantFindUserConfigs) {
+		utils.JSONError(w, http.StatusInternalServerError, err)
+		return
+	}
+	if err == nil {
+		export.configs = &configs
+	}
+
+	if export.tags, err = server.TagStorage.GetTags(server.ctx, userID); err != nil {
+		utils.JSONError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	if export.projects, err = server.ProjectStorage.GetProjects(server.ctx, userID, true); err != nil {
+		utils.JSONError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	if export.views, err = server.ViewStorage.GetViews(server.ctx, userID); err != nil {
+		utils.JSONError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	// reminds are streamed to the archive while they are read from database, so errors after this point
+	// can't change the status and leave the archive without central directory, which clients reject
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="reminder-export-%s.zip"`, time.Now().Format("2006-01-02")))
+	w.WriteHeader(http.StatusOK)
+
+	if err := server.writeAccountExport(w, userID, export); err != nil {
+		server.Logger.Errorf("unable to write account export: %v", err)
+	}
+}
+
+func (server *Server) writeAccountExport(w io.Writer, userID string, export accountExport) error {
+	archive := zip.NewWriter(w)
+
+	tags := make(map[int]model.Tag, len(export.tags))
+	for _, tag := range export.tags {
+		tags[tag.ID] = tag
+	}
+
+	// exported reminds have only ids of their tags
+	withTags := func(remind model.Todo) model.Todo {
+		for i, tag := range remind.Tags {
+			if t, ok := tags[tag.ID]; ok {
+				remind.Tags[i] = t
+			}
+		}
+		return remind
+	}
+
+	if err := writeJSONFile(archive, "reminds.json", func(add func(any) error) error {
+		return server.TodoStorage.ExportReminds(server.ctx, userID, func(remind model.Todo) error {
+			return add(withTags(remind))
+		})
+	}); err != nil {
+		return err
+	}
+
+	file, err := archive.Create("reminds.csv")
+	if err != nil {
+		return err
+	}
+	writer := csv.NewWriter(file)
+	if err := writer.Write(remindsCSVHeader); err != nil {
+		return err
+	}
+	if err := server.TodoStorage.ExportReminds(server.ctx, userID, func(remind model.Todo) error {
+		return writer.Write(remindCSVRecord(withTags(remind)))
+	}); err != nil {
+		return err
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return err
+	}
+
+	if err := writeJSONFile(archive, "items.json", func(add func(any) error) error {
+		return server.TodoStorage.ExportItems(server.ctx, userID, func(item model.TodoItem) error {
+			return add(item)
+		})
+	}); err != nil {
+		return err
+	}
+
+	files := []struct {
+		name  string
+		value any
+	}{
+		{"tags.json", export.tags},
+		{"projects.json", export.projects},
+		{"views.json", export.views},
+		{"configs.json", export.configs},
+	}
+	for _, f := range files {
+		file, err := archive.Create(f.name)
+		if err != nil {
+			return err
+		}
+		if err := json.NewEncoder(file).Encode(f.value); err != nil {
+			return err
+		}
+	}
+
+	return archive.Close()
+}
+
+// writeJSONFile writes JSON array to the archive, stream calls add for each element of the array
+func writeJSONFile(archive *zip.Writer, name string, stream func(add func(any) error) error) error {
+	file, err := archive.Create(name)
+	if err != nil {
+		return err
+	}
+
+	separator := "["
+	if err := stream(func(value any) error {
+		data, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		if _, err := io.WriteString(file, separator+"\n"); err != nil {
+			return err
+		}
+		separator = ","
+		_, err = file.Write(data)
+		return err
+	}); err != nil {
+		return err
+	}
+
+	if separator == "[" {
+		_, err = io.WriteString(file, "[]\n")
+		return err
+	}
+	_, err = io.WriteString(file, "\n]\n")
+	return err
+}
+
+// remindCSVRecord returns values of remind in order of remindsCSVHeader, lists are separated by ";"
+func remindCSVRecord(remind model.Todo) []string {
+	formatTime := func(t *time.Time) string {
+		if t == nil {
+			return ""
+		}
+		return t.Format(time.RFC3339)
+	}
+
+	notifyPeriod := make([]string, 0, len(remind.NotifyPeriod))
+	for _, period := range remind.NotifyPeriod {
+		if !period.IsZero() {
+			notifyPeriod = append(notifyPeriod, period.Format(time.RFC3339))
+		}
+	}
+
+	tags := make([]string, len(remind.Tags))
+	for i, tag := range remind.Tags {
+		tags[i] = strconv.Itoa(tag.ID)
+	}
+
+	var deadlineNotify, projectID, rule string
+	if remind.DeadlineNotify != nil {
+		deadlineNotify = strconv.FormatBool(*remind.DeadlineNotify)
+	}
+	if remind.ProjectID != nil {
+		projectID = strconv.Itoa(*remind.ProjectID)
+	}
+	if remind.RRule != nil {
+		rule = *remind.RRule
+	}
+
+	return []string{
+		strconv.Itoa(remind.ID),
+		remind.Title,
+		remind.Description,
+		remind.CreatedAt.Format(time.RFC3339),
+		remind.DeadlineAt.Format(time.RFC3339),
+		formatTime(remind.FinishedAt),
+		strconv.FormatBool(remind.Completed),
+		deadlineNotify,
+		strings.Join(notifyPeriod, ";"),
+		remind.Priority.String(),
+		projectID,
+		rule,
+		strconv.FormatBool(remind.RecurFromCompletion),
+		strings.Join(tags, ";"),
+		formatTime(remind.DeletedAt),
+		strconv.Itoa(remind.Version),
+	}
+}
@@ -0,0 +1,132 @@
+package server
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/red-rocket-software/reminder-go/internal/reminder/domain"
+	mockdb "github.com/red-rocket-software/reminder-go/internal/reminder/domain/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func readExportFile(t *testing.T, archive *zip.Reader, name string) []byte {
+	file, err := archive.Open(name)
+	require.NoError(t, err)
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	require.NoError(t, err)
+	return data
+}
+
+func TestServer_ExportAccount(t *testing.T) {
+	deadline := time.Date(2023, time.April, 15, 16, 0, 0, 0, time.UTC)
+	reminds := []domain.Todo{
+		{ID: 1, Title: "Call mom", Description: "about weekend", UserID: testUserID, DeadlineAt: deadline, NotifyPeriod: []time.Time{deadline.Add(-time.Hour)}, Priority: domain.PriorityHigh, Tags: []domain.Tag{{ID: 3}}, Version: 1},
+		{ID: 2, Title: "Meeting", Description: "with team", UserID: testUserID, DeadlineAt: deadline, Completed: true, Version: 2},
+	}
+	tags := []domain.Tag{{ID: 3, UserID: testUserID, Name: "home"}}
+
+	t.Run("OK", func(t *testing.T) {
+		c := gomock.NewController(t)
+		defer c.Finish()
+
+		todoStore := mockdb.NewMockTodoRepository(c)
+		configStore := mockdb.NewMockConfigRepository(c)
+		tagStore := mockdb.NewMockTagRepository(c)
+		projectStore := mockdb.NewMockProjectRepository(c)
+		viewStore := mockdb.NewMockViewRepository(c)
+
+		configStore.EXPECT().GetUserConfigs(gomock.Any(), testUserID).Return(domain.UserConfigs{ID: testUserID, Period: 2, Version: 1}, nil)
+		tagStore.EXPECT().GetTags(gomock.Any(), testUserID).Return(tags, nil)
+		projectStore.EXPECT().GetProjects(gomock.Any(), testUserID, true).Return([]domain.Project{}, nil)
+		viewStore.EXPECT().GetViews(gomock.Any(), testUserID).Return([]domain.View{{ID: 1, UserID: testUserID, Name: "work"}}, nil)
+
+		// reminds are read twice, for JSON and for CSV
+		todoStore.EXPECT().ExportReminds(gomock.Any(), testUserID, gomock.Any()).Times(2).DoAndReturn(func(_ context.Context, _ string, fn func(domain.Todo) error) error {
+			for _, remind := range reminds {
+				remind.Tags = append([]domain.Tag(nil), remind.Tags...)
+				if err := fn(remind); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		todoStore.EXPECT().ExportItems(gomock.Any(), testUserID, gomock.Any()).Return(nil)
+
+		server := newTestServer(todoStore, configStore)
+		server.TagStorage = tagStore
+		server.ProjectStorage = projectStore
+		server.ViewStorage = viewStore
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/account/export", http.NoBody)
+		req = req.WithContext(context.WithValue(req.Context(), "userID", testUserID))
+
+		handler := http.HandlerFunc(server.ExportAccount)
+		handler.ServeHTTP(w, req)
+
+		require.Equal(t, 200, w.Code)
+		require.Equal(t, "application/zip", w.Header().Get("Content-Type"))
+		require.Contains(t, w.Header().Get("Content-Disposition"), "attachment")
+
+		archive, err := zip.NewReader(bytes.NewReader(w.Body.Bytes()), int64(w.Body.Len()))
+		require.NoError(t, err)
+
+		var exported []domain.Todo
+		require.NoError(t, json.Unmarshal(readExportFile(t, archive, "reminds.json"), &exported))
+		require.Len(t, exported, 2)
+		require.Equal(t, tags, exported[0].Tags)
+		require.True(t, exported[1].Completed)
+
+		records, err := csv.NewReader(bytes.NewReader(readExportFile(t, archive, "reminds.csv"))).ReadAll()
+		require.NoError(t, err)
+		require.Len(t, records, 3)
+		require.Equal(t, remindsCSVHeader, records[0])
+		require.Equal(t, []string{
+			"1", "Call mom", "about weekend", "0001-01-01T00:00:00Z", "2023-04-15T16:00:00Z", "", "false", "",
+			"2023-04-15T15:00:00Z", "high", "", "", "false", "3", "", "1",
+		}, records[1])
+
+		require.JSONEq(t, "[]", string(readExportFile(t, archive, "items.json")))
+		require.JSONEq(t, "[]", string(readExportFile(t, archive, "projects.json")))
+
+		var views []domain.View
+		require.NoError(t, json.Unmarshal(readExportFile(t, archive, "views.json"), &views))
+		require.Len(t, views, 1)
+
+		var configs domain.UserConfigs
+		require.NoError(t, json.Unmarshal(readExportFile(t, archive, "configs.json"), &configs))
+		require.Equal(t, 2, configs.Period)
+	})
+
+	t.Run("Error - configs", func(t *testing.T) {
+		c := gomock.NewController(t)
+		defer c.Finish()
+
+		todoStore := mockdb.NewMockTodoRepository(c)
+		configStore := mockdb.NewMockConfigRepository(c)
+		configStore.EXPECT().GetUserConfigs(gomock.Any(), testUserID).Return(domain.UserConfigs{}, errors.New("db is down"))
+
+		server := newTestServer(todoStore, configStore)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/account/export", http.NoBody)
+		req = req.WithContext(context.WithValue(req.Context(), "userID", testUserID))
+
+		handler := http.HandlerFunc(server.ExportAccount)
+		handler.ServeHTTP(w, req)
+
+		require.Equal(t, 500, w.Code)
+	})
+}
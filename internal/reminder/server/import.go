@@ -0,0 +1,471 @@
+package server
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	model "github.com/red-rocket-software/reminder-go/internal/reminder/domain"
+	"github.com/red-rocket-software/reminder-go/pkg/ical"
+	"github.com/red-rocket-software/reminder-go/pkg/utils"
+)
+
+const (
+	// maxImportSize limits size of imported file in bytes
+	maxImportSize = 5 << 20
+	// maxImportRows limits number of reminds imported by one request
+	maxImportRows = 1000
+)
+
+// importRow is remind input parsed from one CSV row or iCalendar component
+type importRow struct {
+	input model.TodoInput
+	// skip is a reason why row isn't imported, skipped rows aren't errors
+	skip string
+	err  error
+}
+
+// ImportReminds create reminds from iCalendar or CSV file
+//
+//	@Description	ImportReminds
+//	@Summary		import reminds from .ics (VTODO and VEVENT) or .csv file in one transaction
+//	@Tags			reminds
+//	@Accept			mpfd
+//	@Accept			text/calendar
+//	@Accept			text/csv
+//	@Produce		json
+//	@Param			file	formData	file	false	"iCalendar or CSV file, can be sent as body with text/calendar or text/csv content type instead"
+//	@Param			dryRun	query		bool	false	"check file without creating reminds"
+//	@Success		200		{object}	domain.ImportReport
+//
+//	@Failure		400		{object}	utils.HTTPError
+//	@Failure		413		{object}	utils.HTTPError
+//	@Failure		415		{object}	utils.HTTPError
+//	@Failure		422		{object}	utils.HTTPError
+//	@Failure		500		{object}	utils.HTTPError
+//
+//	@Router			/reminds/import [post]
+func (server *Server) ImportReminds(w http.ResponseWriter, r *http.Request) {
+	dryRun := false
+	if value := r.URL.Query().Get("dryRun"); value != "" {
+		var err error
+		if dryRun, err = strconv.ParseBool(value); err != nil {
+			utils.JSONError(w, http.StatusBadRequest, err)
+			return
+		}
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxImportSize)
+
+	rows, err := readImport(r)
+	if err != nil {
+		importError(w, err)
+		return
+	}
+
+	if len(rows) > maxImportRows {
+		utils.JSONError(w, http.StatusUnprocessableEntity, fmt.Errorf("no more than %d reminds can be imported at once", maxImportRows))
+		return
+	}
+
+	userID := r.Context().Value("userID").(string)
+	createdAt := time.Now().Format("02.01.2006, 15:04:05")
+
+	report := model.ImportReport{DryRun: dryRun, Rows: make([]model.ImportRow, len(rows))}
+
+	// reminds which passed validation and positions of their rows in report
+	var reminds []model.Todo
+	var positions []int
+
+	for i, row := range rows {
+		report.Rows[i] = model.ImportRow{Row: i + 1, Title: row.input.Title}
+
+		if row.skip != "" {
+			report.Rows[i].Status = model.ImportSkipped
+			report.Rows[i].Reason = row.skip
+			continue
+		}
+
+		err := row.err
+		if err == nil {
+			row.input.CreatedAt = createdAt
+
+			var todo model.Todo
+			if todo, err = todoFromInput(row.input, userID); err == nil {
+				reminds = append(reminds, todo)
+				positions = append(positions, i)
+				continue
+			}
+		}
+
+		report.Rows[i].Status = model.ImportFailed
+		report.Rows[i].Reason = err.Error()
+	}
+
+	if len(reminds) > 0 {
+		results, err := server.TodoStorage.ImportReminds(server.ctx, reminds, dryRun)
+		if err != nil {
+			utils.JSONError(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		for i, result := range results {
+			row := &report.Rows[positions[i]]
+			if result.Success {
+				row.Status = model.ImportImported
+				row.ID = result.ID
+			} else {
+				row.Status = model.ImportFailed
+				row.Reason = result.Error
+			}
+		}
+	}
+
+	for _, row := range report.Rows {
+		switch row.Status {
+		case model.ImportImported:
+			report.Imported++
+		case model.ImportSkipped:
+			report.Skipped++
+		case model.ImportFailed:
+			report.Failed++
+		}
+	}
+
+	utils.JSONFormat(w, http.StatusOK, report)
+}
+
+// readImport parses file sent as "file" field of multipart form or as request body
+func readImport(r *http.Request) ([]importRow, error) {
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil {
+		return nil, model.ErrUnsupportedImportFormat
+	}
+
+	if mediaType != "multipart/form-data" {
+		return parseImport(mediaType, r.Body)
+	}
+
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	// browsers don't know .ics and .csv types well, so extension of the file is checked first
+	switch strings.ToLower(filepath.Ext(header.Filename)) {
+	case ".ics":
+		mediaType = "text/calendar"
+	case ".csv":
+		mediaType = "text/csv"
+	default:
+		mediaType, _, _ = mime.ParseMediaType(header.Header.Get("Content-Type"))
+	}
+
+	return parseImport(mediaType, file)
+}
+
+func parseImport(mediaType string, data io.Reader) ([]importRow, error) {
+	switch mediaType {
+	case "text/calendar":
+		return parseICSImport(data)
+	case "text/csv":
+		return parseCSVImport(data)
+	default:
+		return nil, model.ErrUnsupportedImportFormat
+	}
+}
+
+// parseICSImport returns row for each VTODO and VEVENT of the calendar
+func parseICSImport(data io.Reader) ([]importRow, error) {
+	calendar, err := ical.Decode(data)
+	if err != nil {
+		return nil, err
+	}
+	if calendar.Name != "VCALENDAR" {
+		return nil, fmt.Errorf("%w: VCALENDAR expected", ical.ErrInvalidCalendar)
+	}
+
+	var rows []importRow
+	for _, component := range calendar.Components {
+		if component.Name == "VTODO" || component.Name == "VEVENT" {
+			rows = append(rows, icsImportRow(component))
+		}
+	}
+
+	return rows, nil
+}
+
+// icsImportRow maps component to remind input. Deadline is DUE of todo or DTSTART of event,
+// alarms become notify period
+func icsImportRow(component *ical.Component) importRow {
+	row := importRow{input: model.TodoInput{
+		Title:       component.Text("SUMMARY"),
+		Description: component.Text("DESCRIPTION"),
+	}}
+
+	if _, completed := component.Get("COMPLETED"); completed || strings.EqualFold(component.Text("STATUS"), "COMPLETED") {
+		row.skip = "remind is completed"
+		return row
+	}
+	if strings.EqualFold(component.Text("STATUS"), "CANCELLED") {
+		row.skip = "remind is cancelled"
+		return row
+	}
+
+	deadlineProperty, ok := component.Get("DTSTART")
+	if component.Name == "VTODO" {
+		if due, hasDue := component.Get("DUE"); hasDue {
+			deadlineProperty, ok = due, true
+		}
+	}
+	// remind without deadline fails validation as any other remind
+	if !ok {
+		return row
+	}
+
+	deadline, err := ical.ParseTime(deadlineProperty)
+	if err != nil {
+		row.err = fmt.Errorf("wrong %s: %w", deadlineProperty.Name, err)
+		return row
+	}
+	row.input.DeadlineAt = deadline.Format(time.RFC3339)
+
+	for _, alarm := range component.Components {
+		if alarm.Name != "VALARM" {
+			continue
+		}
+
+		notifyAt, err := icsAlarmTime(component, alarm, deadline)
+		if err != nil {
+			row.err = err
+			return row
+		}
+		row.input.NotifyPeriod = append(row.input.NotifyPeriod, notifyAt.Format(time.RFC3339))
+	}
+
+	if property, ok := component.Get("PRIORITY"); ok {
+		if row.input.Priority, err = icsPriority(property.Value); err != nil {
+			row.err = err
+			return row
+		}
+	}
+
+	if property, ok := component.Get("RRULE"); ok {
+		rule := property.Value
+		row.input.RRule = &rule
+	}
+
+	return row
+}
+
+// icsAlarmTime returns time of alarm. Relative trigger is counted from start of component by default,
+// or from its end with RELATED=END, which is DUE of todo and DTEND of event
+func icsAlarmTime(component, alarm *ical.Component, deadline time.Time) (time.Time, error) {
+	trigger, ok := alarm.Get("TRIGGER")
+	if !ok {
+		return time.Time{}, errors.New("alarm without TRIGGER")
+	}
+
+	if trigger.Params["VALUE"] == "DATE-TIME" {
+		return ical.ParseTime(trigger)
+	}
+
+	offset, err := ical.ParseDuration(trigger.Value)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	var related string
+	switch {
+	case component.Name == "VEVENT" && trigger.Params["RELATED"] == "END":
+		related = "DTEND"
+	case component.Name == "VTODO" && trigger.Params["RELATED"] != "END":
+		related = "DTSTART"
+	}
+
+	base := deadline
+	if property, ok := component.Get(related); ok {
+		if base, err = ical.ParseTime(property); err != nil {
+			return time.Time{}, fmt.Errorf("wrong %s: %w", related, err)
+		}
+	}
+
+	return base.Add(offset), nil
+}
+
+// icsPriority maps iCalendar PRIORITY to remind priority, it's reverse of icalPriorities
+func icsPriority(value string) (model.Priority, error) {
+	priority, err := strconv.Atoi(value)
+	if err != nil || priority < 0 || priority > 9 {
+		return model.PriorityNone, fmt.Errorf("%w: wrong PRIORITY %q", ical.ErrInvalidCalendar, value)
+	}
+
+	switch {
+	case priority == 0:
+		return model.PriorityNone, nil
+	case priority == 1:
+		return model.PriorityCritical, nil
+	case priority <= 4:
+		return model.PriorityHigh, nil
+	case priority == 5:
+		return model.PriorityMedium, nil
+	default:
+		return model.PriorityLow, nil
+	}
+}
+
+// csvImportColumns are columns of CSV file named as fields of remind input,
+// notify_period and tags are lists separated by ";"
+var csvImportColumns = map[string]bool{
+	"title":                 true,
+	"description":           true,
+	"deadline_at":           true,
+	"notify_period":         false,
+	"deadline_notify":       false,
+	"priority":              false,
+	"project_id":            false,
+	"rrule":                 false,
+	"recur_from_completion": false,
+	"tags":                  false,
+}
+
+// parseCSVImport returns row for each CSV record after the header
+func parseCSVImport(data io.Reader) ([]importRow, error) {
+	reader := csv.NewReader(data)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, errors.New("csv header is empty")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	columns := map[string]int{}
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if _, ok := csvImportColumns[name]; !ok {
+			return nil, fmt.Errorf("unknown csv column %q", name)
+		}
+		columns[name] = i
+	}
+	for name, required := range csvImportColumns {
+		if _, ok := columns[name]; required && !ok {
+			return nil, errors.New("csv should have title, description and deadline_at columns")
+		}
+	}
+
+	var rows []importRow
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return rows, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		rows = append(rows, csvImportRow(columns, header, record))
+	}
+}
+
+func csvImportRow(columns map[string]int, header, record []string) importRow {
+	value := func(name string) string {
+		i, ok := columns[name]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	row := importRow{input: model.TodoInput{
+		Title:       value("title"),
+		Description: value("description"),
+		DeadlineAt:  value("deadline_at"),
+	}}
+
+	if len(record) != len(header) {
+		row.err = fmt.Errorf("row has %d fields, header has %d", len(record), len(header))
+		return row
+	}
+
+	if v := value("notify_period"); v != "" {
+		for _, period := range strings.Split(v, ";") {
+			row.input.NotifyPeriod = append(row.input.NotifyPeriod, strings.TrimSpace(period))
+		}
+	}
+
+	var err error
+
+	if v := value("deadline_notify"); v != "" {
+		notify, err := strconv.ParseBool(v)
+		if err != nil {
+			row.err = fmt.Errorf("wrong deadline_notify: %w", err)
+			return row
+		}
+		row.input.DeadlineNotify = &notify
+	}
+
+	if v := value("priority"); v != "" {
+		if row.input.Priority, err = model.ParsePriority(v); err != nil {
+			row.err = err
+			return row
+		}
+	}
+
+	if v := value("project_id"); v != "" {
+		projectID, err := strconv.Atoi(v)
+		if err != nil {
+			row.err = fmt.Errorf("wrong project_id: %w", err)
+			return row
+		}
+		row.input.ProjectID = &projectID
+	}
+
+	if v := value("rrule"); v != "" {
+		row.input.RRule = &v
+	}
+
+	if v := value("recur_from_completion"); v != "" {
+		if row.input.RecurFromCompletion, err = strconv.ParseBool(v); err != nil {
+			row.err = fmt.Errorf("wrong recur_from_completion: %w", err)
+			return row
+		}
+	}
+
+	if v := value("tags"); v != "" {
+		for _, tag := range strings.Split(v, ";") {
+			tagID, err := strconv.Atoi(strings.TrimSpace(tag))
+			if err != nil {
+				row.err = fmt.Errorf("wrong tags: %w", err)
+				return row
+			}
+			row.input.Tags = append(row.input.Tags, tagID)
+		}
+	}
+
+	return row
+}
+
+func importError(w http.ResponseWriter, err error) {
+	var maxBytesErr *http.MaxBytesError
+
+	switch {
+	case errors.Is(err, model.ErrUnsupportedImportFormat):
+		utils.JSONError(w, http.StatusUnsupportedMediaType, err)
+	case errors.As(err, &maxBytesErr):
+		utils.JSONError(w, http.StatusRequestEntityTooLarge, err)
+	default:
+		utils.JSONError(w, http.StatusUnprocessableEntity, err)
+	}
+}
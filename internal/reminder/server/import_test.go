@@ -0,0 +1,217 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/red-rocket-software/reminder-go/internal/reminder/domain"
+	mockdb "github.com/red-rocket-software/reminder-go/internal/reminder/domain/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+const testICSImport = "BEGIN:VCALENDAR\r\n" +
+	"VERSION:2.0\r\n" +
+	"BEGIN:VTODO\r\n" +
+	"SUMMARY:Call mom\r\n" +
+	"DESCRIPTION:about weekend\r\n" +
+	"DUE:20230415T160000Z\r\n" +
+	"PRIORITY:1\r\n" +
+	"RRULE:FREQ=WEEKLY;BYDAY=MO\r\n" +
+	"BEGIN:VALARM\r\n" +
+	"ACTION:DISPLAY\r\n" +
+	"TRIGGER;RELATED=END:-PT1H\r\n" +
+	"END:VALARM\r\n" +
+	"END:VTODO\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"SUMMARY:Meeting\r\n" +
+	"DTSTART:20230416T090000Z\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VTODO\r\n" +
+	"SUMMARY:Done\r\n" +
+	"DESCRIPTION:already\r\n" +
+	"STATUS:COMPLETED\r\n" +
+	"END:VTODO\r\n" +
+	"END:VCALENDAR\r\n"
+
+const testCSVImport = "title,description,deadline_at,notify_period,priority,tags\n" +
+	"Call mom,about weekend,2023-04-15T16:00:00Z,2023-04-15T15:00:00Z,high,1;2\n" +
+	"Meeting,with team,tomorrow,,,\n"
+
+func multipartImport(t *testing.T, filename, data string) (io.Reader, string) {
+	var body bytes.Buffer
+
+	writer := multipart.NewWriter(&body)
+	part, err := writer.CreateFormFile("file", filename)
+	require.NoError(t, err)
+	_, err = part.Write([]byte(data))
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+
+	return &body, writer.FormDataContentType()
+}
+
+func TestServer_ImportReminds(t *testing.T) {
+	deadline := time.Date(2023, time.April, 15, 16, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name               string
+		query              string
+		body               func(t *testing.T) (io.Reader, string)
+		mockBehavior       func(store *mockdb.MockTodoRepository)
+		expectedStatusCode int
+		expectedReport     domain.ImportReport
+	}{
+		{
+			name: "OK - iCalendar body",
+			body: func(t *testing.T) (io.Reader, string) {
+				return strings.NewReader(testICSImport), "text/calendar; charset=utf-8"
+			},
+			mockBehavior: func(store *mockdb.MockTodoRepository) {
+				store.EXPECT().ImportReminds(gomock.Any(), gomock.Any(), false).DoAndReturn(func(_ context.Context, reminds []domain.Todo, _ bool) ([]domain.BulkResult, error) {
+					require.Len(t, reminds, 1)
+					require.Equal(t, "Call mom", reminds[0].Title)
+					require.Equal(t, testUserID, reminds[0].UserID)
+					require.True(t, reminds[0].DeadlineAt.Equal(deadline))
+					require.Contains(t, reminds[0].NotifyPeriod, deadline.Add(-time.Hour))
+					require.Equal(t, domain.PriorityCritical, reminds[0].Priority)
+					require.Equal(t, "FREQ=WEEKLY;BYDAY=MO", *reminds[0].RRule)
+					return []domain.BulkResult{{ID: 10, Success: true}}, nil
+				})
+			},
+			expectedStatusCode: 200,
+			expectedReport: domain.ImportReport{
+				Imported: 1,
+				Skipped:  1,
+				Failed:   1,
+				Rows: []domain.ImportRow{
+					{Row: 1, Title: "Call mom", Status: domain.ImportImported, ID: 10},
+					{Row: 2, Title: "Meeting", Status: domain.ImportFailed, Reason: "nothing to save"},
+					{Row: 3, Title: "Done", Status: domain.ImportSkipped, Reason: "remind is completed"},
+				},
+			},
+		},
+		{
+			name:  "OK - CSV file dry run",
+			query: "?dryRun=true",
+			body: func(t *testing.T) (io.Reader, string) {
+				return multipartImport(t, "reminds.csv", testCSVImport)
+			},
+			mockBehavior: func(store *mockdb.MockTodoRepository) {
+				store.EXPECT().ImportReminds(gomock.Any(), gomock.Any(), true).DoAndReturn(func(_ context.Context, reminds []domain.Todo, _ bool) ([]domain.BulkResult, error) {
+					require.Len(t, reminds, 1)
+					require.Equal(t, domain.PriorityHigh, reminds[0].Priority)
+					require.Equal(t, []domain.Tag{{ID: 1}, {ID: 2}}, reminds[0].Tags)
+					return []domain.BulkResult{{Success: true}}, nil
+				})
+			},
+			expectedStatusCode: 200,
+			expectedReport: domain.ImportReport{
+				DryRun:   true,
+				Imported: 1,
+				Failed:   1,
+				Rows: []domain.ImportRow{
+					{Row: 1, Title: "Call mom", Status: domain.ImportImported},
+					{Row: 2, Title: "Meeting", Status: domain.ImportFailed, Reason: `parsing time "tomorrow" as "2006-01-02T15:04:05Z07:00": cannot parse "tomorrow" as "2006"`},
+				},
+			},
+		},
+		{
+			name: "OK - remind failed in storage",
+			body: func(t *testing.T) (io.Reader, string) {
+				return strings.NewReader("title,description,deadline_at,project_id\nCall mom,about weekend,2023-04-15T16:00:00Z,5\n"), "text/csv"
+			},
+			mockBehavior: func(store *mockdb.MockTodoRepository) {
+				store.EXPECT().ImportReminds(gomock.Any(), gomock.Any(), false).Return([]domain.BulkResult{{Error: domain.ErrCantFindProjectWithID.Error()}}, nil)
+			},
+			expectedStatusCode: 200,
+			expectedReport: domain.ImportReport{
+				Failed: 1,
+				Rows: []domain.ImportRow{
+					{Row: 1, Title: "Call mom", Status: domain.ImportFailed, Reason: domain.ErrCantFindProjectWithID.Error()},
+				},
+			},
+		},
+		{
+			name: "OK - nothing to import",
+			body: func(t *testing.T) (io.Reader, string) {
+				return strings.NewReader("title,description,deadline_at\n"), "text/csv"
+			},
+			mockBehavior:       func(store *mockdb.MockTodoRepository) {},
+			expectedStatusCode: 200,
+			expectedReport:     domain.ImportReport{Rows: []domain.ImportRow{}},
+		},
+		{
+			name: "Error - unsupported format",
+			body: func(t *testing.T) (io.Reader, string) {
+				return strings.NewReader(`{"title":"Call mom"}`), "application/json"
+			},
+			mockBehavior:       func(store *mockdb.MockTodoRepository) {},
+			expectedStatusCode: 415,
+		},
+		{
+			name: "Error - unknown csv column",
+			body: func(t *testing.T) (io.Reader, string) {
+				return multipartImport(t, "reminds.csv", "title,description,deadline,colour\n")
+			},
+			mockBehavior:       func(store *mockdb.MockTodoRepository) {},
+			expectedStatusCode: 422,
+		},
+		{
+			name: "Error - broken calendar",
+			body: func(t *testing.T) (io.Reader, string) {
+				return strings.NewReader("BEGIN:VCALENDAR\r\nBEGIN:VTODO\r\n"), "text/calendar"
+			},
+			mockBehavior:       func(store *mockdb.MockTodoRepository) {},
+			expectedStatusCode: 422,
+		},
+		{
+			name:  "Error - wrong dry run",
+			query: "?dryRun=maybe",
+			body: func(t *testing.T) (io.Reader, string) {
+				return strings.NewReader(testCSVImport), "text/csv"
+			},
+			mockBehavior:       func(store *mockdb.MockTodoRepository) {},
+			expectedStatusCode: 400,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			todoStore := mockdb.NewMockTodoRepository(c)
+			configStore := mockdb.NewMockConfigRepository(c)
+			test.mockBehavior(todoStore)
+
+			server := newTestServer(todoStore, configStore)
+
+			body, contentType := test.body(t)
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodPost, "/reminds/import"+test.query, body)
+			req.Header.Set("Content-Type", contentType)
+			req = req.WithContext(context.WithValue(req.Context(), "userID", testUserID))
+
+			handler := http.HandlerFunc(server.ImportReminds)
+			handler.ServeHTTP(w, req)
+
+			require.Equal(t, test.expectedStatusCode, w.Code)
+
+			if test.expectedStatusCode == 200 {
+				var report domain.ImportReport
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &report))
+				require.Equal(t, test.expectedReport, report)
+			}
+		})
+	}
+}
@@ -33,6 +33,28 @@ func (server *Server) AuthMiddleware(next http.Handler) http.Handler {
 
 		ctx := context.WithValue(r.Context(), "userID", userID)
 
+		// email is used to resolve invites sent before the user signed up, so it must be verified, otherwise
+		// anyone could sign up with address of the invitee and get their reminds
+		if email, ok := verifyToken.Claims["email"].(string); ok && verifyToken.Claims["email_verified"] == true {
+			ctx = context.WithValue(ctx, "userEmail", email)
+		}
+
 		next.ServeHTTP(w, r.WithContext(ctx))
 	})
 }
+
+// AdminMiddleware lets through only users who are admins in config, it is used after AuthMiddleware
+func (server *Server) AdminMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userID, _ := r.Context().Value("userID").(string)
+
+		for _, adminID := range server.config.Admin.UserIDs {
+			if userID != "" && userID == adminID {
+				next.ServeHTTP(w, r)
+				return
+			}
+		}
+
+		utils.JSONError(w, http.StatusForbidden, errors.New("admin access is required"))
+	})
+}
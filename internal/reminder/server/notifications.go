@@ -0,0 +1,153 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+	model "github.com/red-rocket-software/reminder-go/internal/reminder/domain"
+	"github.com/red-rocket-software/reminder-go/pkg/utils"
+)
+
+// maxDeadNotifications limits number of dead notifications returned at once
+const maxDeadNotifications = 500
+
+// GetDeadNotifications get notifications which weren't sent after all attempts
+//
+//	@Description	GetDeadNotifications
+//	@Summary		get dead notifications of all users, the last dead first, available to admins only
+//	@Tags			admin
+//	@Produce		json
+//	@Param			limit	query		int	false	"limit, 100 by default"
+//	@Success		200		{array}		domain.Notification
+//
+//	@Failure		400		{object}	utils.HTTPError
+//	@Failure		403		{object}	utils.HTTPError
+//	@Failure		500		{object}	utils.HTTPError
+//
+//	@Router			/admin/notifications/dead [get]
+func (server *Server) GetDeadNotifications(w http.ResponseWriter, r *http.Request) {
+	limitStr := r.URL.Query().Get("limit")
+	limit, err := strconv.Atoi(limitStr)
+	if (err != nil && limitStr != "") || limit < 0 || limit > maxDeadNotifications {
+		utils.JSONError(w, http.StatusBadRequest, errors.New("limit parameter is invalid, should be positive integer up to 500"))
+		return
+	}
+
+	// by default limit = 100
+	if limit == 0 {
+		limit = 100
+	}
+
+	notifications, err := server.TodoStorage.GetDeadNotifications(server.ctx, limit)
+	if err != nil {
+		notificationsError(w, err)
+		return
+	}
+
+	utils.JSONFormat(w, http.StatusOK, notifications)
+}
+
+// RetryNotification make notification due now
+//
+//	@Description	RetryNotification
+//	@Summary		send dead or failed notification again on the next run of the worker, available to admins only
+//	@Tags			admin
+//	@Param			id	path	int	true	"notification id"
+//	@Success		202
+//
+//	@Failure		400	{object}	utils.HTTPError
+//	@Failure		403	{object}	utils.HTTPError
+//	@Failure		404	{object}	utils.HTTPError
+//	@Failure		500	{object}	utils.HTTPError
+//
+//	@Router			/admin/notifications/{id}/retry [post]
+func (server *Server) RetryNotification(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		utils.JSONError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := server.TodoStorage.RetryNotification(server.ctx, id, time.Now()); err != nil {
+		notificationsError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// GetDeadOutbox get deliveries of notifications which failed after all attempts
+//
+//	@Description	GetDeadOutbox
+//	@Summary		get dead outbox messages of all users, the last dead first, available to admins only
+//	@Tags			admin
+//	@Produce		json
+//	@Param			limit	query		int	false	"limit, 100 by default"
+//	@Success		200		{array}		domain.OutboxMessage
+//
+//	@Failure		400		{object}	utils.HTTPError
+//	@Failure		403		{object}	utils.HTTPError
+//	@Failure		500		{object}	utils.HTTPError
+//
+//	@Router			/admin/outbox/dead [get]
+func (server *Server) GetDeadOutbox(w http.ResponseWriter, r *http.Request) {
+	limitStr := r.URL.Query().Get("limit")
+	limit, err := strconv.Atoi(limitStr)
+	if (err != nil && limitStr != "") || limit < 0 || limit > maxDeadNotifications {
+		utils.JSONError(w, http.StatusBadRequest, errors.New("limit parameter is invalid, should be positive integer up to 500"))
+		return
+	}
+
+	// by default limit = 100
+	if limit == 0 {
+		limit = 100
+	}
+
+	outbox, err := server.TodoStorage.GetDeadOutbox(server.ctx, limit)
+	if err != nil {
+		notificationsError(w, err)
+		return
+	}
+
+	utils.JSONFormat(w, http.StatusOK, outbox)
+}
+
+// RetryOutbox make outbox message due now
+//
+//	@Description	RetryOutbox
+//	@Summary		deliver dead or failed outbox message again on the next run of the worker, available to admins only
+//	@Tags			admin
+//	@Param			id	path	int	true	"outbox message id"
+//	@Success		202
+//
+//	@Failure		400	{object}	utils.HTTPError
+//	@Failure		403	{object}	utils.HTTPError
+//	@Failure		404	{object}	utils.HTTPError
+//	@Failure		500	{object}	utils.HTTPError
+//
+//	@Router			/admin/outbox/{id}/retry [post]
+func (server *Server) RetryOutbox(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		utils.JSONError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := server.TodoStorage.RetryOutbox(server.ctx, id, time.Now()); err != nil {
+		notificationsError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func notificationsError(w http.ResponseWriter, err error) {
+	if errors.Is(err, model.ErrCantFindNotificationWithID) || errors.Is(err, model.ErrCantFindOutboxMessageWithID) {
+		utils.JSONError(w, http.StatusNotFound, err)
+		return
+	}
+	utils.JSONError(w, http.StatusInternalServerError, err)
+}
@@ -0,0 +1,300 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/red-rocket-software/reminder-go/internal/reminder/domain"
+	mockdb "github.com/red-rocket-software/reminder-go/internal/reminder/domain/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServer_AdminMiddleware(t *testing.T) {
+	testCases := []struct {
+		name               string
+		userID             string
+		expectedStatusCode int
+	}{
+		{name: "OK - admin", userID: testUserID, expectedStatusCode: 200},
+		{name: "Error - not admin", userID: "other", expectedStatusCode: 403},
+		{name: "Error - no user", expectedStatusCode: 403},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			server := newTestServer(mockdb.NewMockTodoRepository(c), mockdb.NewMockConfigRepository(c))
+			server.config.Admin.UserIDs = []string{testUserID}
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodGet, "/admin/notifications/dead", http.NoBody)
+			if test.userID != "" {
+				req = req.WithContext(context.WithValue(req.Context(), "userID", test.userID))
+			}
+
+			handler := server.AdminMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+			handler.ServeHTTP(w, req)
+
+			require.Equal(t, test.expectedStatusCode, w.Code)
+		})
+	}
+}
+
+func TestServer_GetDeadNotifications(t *testing.T) {
+	deadAt := time.Now()
+
+	testCases := []struct {
+		name               string
+		limit              string
+		mockBehavior       func(store *mockdb.MockTodoRepository)
+		expectedStatusCode int
+	}{
+		{
+			name: "OK - default limit",
+			mockBehavior: func(store *mockdb.MockTodoRepository) {
+				store.EXPECT().GetDeadNotifications(gomock.Any(), 100).Return([]domain.Notification{
+					{ID: 10, Kind: domain.NotificationDeadline, Attempts: 5, LastError: "connection refused", DeadAt: &deadAt},
+				}, nil)
+			},
+			expectedStatusCode: 200,
+		},
+		{
+			name:  "OK - limit",
+			limit: "10",
+			mockBehavior: func(store *mockdb.MockTodoRepository) {
+				store.EXPECT().GetDeadNotifications(gomock.Any(), 10).Return([]domain.Notification{}, nil)
+			},
+			expectedStatusCode: 200,
+		},
+		{
+			name:               "Error - invalid limit",
+			limit:              "many",
+			mockBehavior:       func(store *mockdb.MockTodoRepository) {},
+			expectedStatusCode: 400,
+		},
+		{
+			name: "Error - storage",
+			mockBehavior: func(store *mockdb.MockTodoRepository) {
+				store.EXPECT().GetDeadNotifications(gomock.Any(), 100).Return(nil, errors.New("something went wrong"))
+			},
+			expectedStatusCode: 500,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			todoStore := mockdb.NewMockTodoRepository(c)
+			test.mockBehavior(todoStore)
+
+			server := newTestServer(todoStore, mockdb.NewMockConfigRepository(c))
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodGet, "/admin/notifications/dead?limit="+test.limit, http.NoBody)
+
+			handler := http.HandlerFunc(server.GetDeadNotifications)
+			handler.ServeHTTP(w, req)
+
+			require.Equal(t, test.expectedStatusCode, w.Code)
+
+			if test.name == "OK - default limit" {
+				var notifications []domain.Notification
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &notifications))
+				require.Len(t, notifications, 1)
+				require.Equal(t, "connection refused", notifications[0].LastError)
+			}
+		})
+	}
+}
+
+func TestServer_RetryNotification(t *testing.T) {
+	testCases := []struct {
+		name               string
+		id                 string
+		mockBehavior       func(store *mockdb.MockTodoRepository)
+		expectedStatusCode int
+	}{
+		{
+			name: "OK",
+			id:   "10",
+			mockBehavior: func(store *mockdb.MockTodoRepository) {
+				store.EXPECT().RetryNotification(gomock.Any(), int64(10), gomock.Any()).DoAndReturn(func(_ context.Context, _ int64, now time.Time) error {
+					require.WithinDuration(t, time.Now(), now, time.Minute)
+					return nil
+				})
+			},
+			expectedStatusCode: 202,
+		},
+		{
+			name:               "Error - invalid id",
+			id:                 "ten",
+			mockBehavior:       func(store *mockdb.MockTodoRepository) {},
+			expectedStatusCode: 400,
+		},
+		{
+			name: "Error - not found",
+			id:   "10",
+			mockBehavior: func(store *mockdb.MockTodoRepository) {
+				store.EXPECT().RetryNotification(gomock.Any(), int64(10), gomock.Any()).Return(domain.ErrCantFindNotificationWithID)
+			},
+			expectedStatusCode: 404,
+		},
+		{
+			name: "Error - storage",
+			id:   "10",
+			mockBehavior: func(store *mockdb.MockTodoRepository) {
+				store.EXPECT().RetryNotification(gomock.Any(), int64(10), gomock.Any()).Return(errors.New("something went wrong"))
+			},
+			expectedStatusCode: 500,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			todoStore := mockdb.NewMockTodoRepository(c)
+			test.mockBehavior(todoStore)
+
+			server := newTestServer(todoStore, mockdb.NewMockConfigRepository(c))
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodPost, "/admin/notifications/"+test.id+"/retry", http.NoBody)
+			req = mux.SetURLVars(req, map[string]string{"id": test.id})
+
+			handler := http.HandlerFunc(server.RetryNotification)
+			handler.ServeHTTP(w, req)
+
+			require.Equal(t, test.expectedStatusCode, w.Code)
+		})
+	}
+}
+
+func TestServer_GetDeadOutbox(t *testing.T) {
+	deadAt := time.Now()
+
+	testCases := []struct {
+		name               string
+		limit              string
+		mockBehavior       func(store *mockdb.MockTodoRepository)
+		expectedStatusCode int
+	}{
+		{
+			name: "OK",
+			mockBehavior: func(store *mockdb.MockTodoRepository) {
+				store.EXPECT().GetDeadOutbox(gomock.Any(), 100).Return([]domain.OutboxMessage{
+					{ID: 1, IdempotencyKey: "notification-10/user/webhook/0", Channel: domain.ChannelWebhook, Payload: []byte(`{"recipient": {"Address": "https://example.com/secret"}}`), Attempts: 5, DeadAt: &deadAt},
+				}, nil)
+			},
+			expectedStatusCode: 200,
+		},
+		{
+			name:               "Error - invalid limit",
+			limit:              "-1",
+			mockBehavior:       func(store *mockdb.MockTodoRepository) {},
+			expectedStatusCode: 400,
+		},
+		{
+			name: "Error - storage",
+			mockBehavior: func(store *mockdb.MockTodoRepository) {
+				store.EXPECT().GetDeadOutbox(gomock.Any(), 100).Return(nil, errors.New("something went wrong"))
+			},
+			expectedStatusCode: 500,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			todoStore := mockdb.NewMockTodoRepository(c)
+			test.mockBehavior(todoStore)
+
+			server := newTestServer(todoStore, mockdb.NewMockConfigRepository(c))
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodGet, "/admin/outbox/dead?limit="+test.limit, http.NoBody)
+
+			handler := http.HandlerFunc(server.GetDeadOutbox)
+			handler.ServeHTTP(w, req)
+
+			require.Equal(t, test.expectedStatusCode, w.Code)
+
+			if test.expectedStatusCode == 200 {
+				// addresses of the user aren't returned
+				require.NotContains(t, w.Body.String(), "secret")
+
+				var outbox []domain.OutboxMessage
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &outbox))
+				require.Len(t, outbox, 1)
+				require.Equal(t, "notification-10/user/webhook/0", outbox[0].IdempotencyKey)
+			}
+		})
+	}
+}
+
+func TestServer_RetryOutbox(t *testing.T) {
+	testCases := []struct {
+		name               string
+		id                 string
+		mockBehavior       func(store *mockdb.MockTodoRepository)
+		expectedStatusCode int
+	}{
+		{
+			name: "OK",
+			id:   "1",
+			mockBehavior: func(store *mockdb.MockTodoRepository) {
+				store.EXPECT().RetryOutbox(gomock.Any(), int64(1), gomock.Any()).Return(nil)
+			},
+			expectedStatusCode: 202,
+		},
+		{
+			name:               "Error - invalid id",
+			id:                 "one",
+			mockBehavior:       func(store *mockdb.MockTodoRepository) {},
+			expectedStatusCode: 400,
+		},
+		{
+			name: "Error - not found",
+			id:   "1",
+			mockBehavior: func(store *mockdb.MockTodoRepository) {
+				store.EXPECT().RetryOutbox(gomock.Any(), int64(1), gomock.Any()).Return(domain.ErrCantFindOutboxMessageWithID)
+			},
+			expectedStatusCode: 404,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			todoStore := mockdb.NewMockTodoRepository(c)
+			test.mockBehavior(todoStore)
+
+			server := newTestServer(todoStore, mockdb.NewMockConfigRepository(c))
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodPost, "/admin/outbox/"+test.id+"/retry", http.NoBody)
+			req = mux.SetURLVars(req, map[string]string{"id": test.id})
+
+			handler := http.HandlerFunc(server.RetryOutbox)
+			handler.ServeHTTP(w, req)
+
+			require.Equal(t, test.expectedStatusCode, w.Code)
+		})
+	}
+}
@@ -0,0 +1,198 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/mux"
+	model "github.com/red-rocket-software/reminder-go/internal/reminder/domain"
+	"github.com/red-rocket-software/reminder-go/pkg/utils"
+)
+
+// GetProjects return user projects
+//
+//	@Description	GetProjects
+//	@Summary		return projects of user ordered by position
+//	@Tags			projects
+//	@Accept			json
+//	@Produce		json
+//	@Param			archived	query		bool	false	"include archived projects"
+//	@Success		200			{array}		domain.Project
+//
+//	@Failure		400			{object}	utils.HTTPError
+//	@Failure		500			{object}	utils.HTTPError
+//
+//	@Router			/projects [get]
+func (server *Server) GetProjects(w http.ResponseWriter, r *http.Request) {
+	var withArchived bool
+
+	if archivedStr := r.URL.Query().Get("archived"); archivedStr != "" {
+		var err error
+		withArchived, err = strconv.ParseBool(archivedStr)
+		if err != nil {
+			utils.JSONError(w, http.StatusBadRequest, errors.New("archived parameter is invalid, should be true or false"))
+			return
+		}
+	}
+
+	userID := r.Context().Value("userID").(string)
+
+	projects, err := server.ProjectStorage.GetProjects(server.ctx, userID, withArchived)
+	if err != nil {
+		utils.JSONError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	utils.JSONFormat(w, http.StatusOK, projects)
+}
+
+// AddProject create a new project
+//
+//	@Description	AddProject
+//	@Summary		create a new project
+//	@Tags			projects
+//	@Accept			json
+//	@Produce		json
+//	@Param			input	body		domain.ProjectInput	true	"project info"
+//	@Success		201		{object}	domain.Project
+//
+//	@Failure		422		{object}	utils.HTTPError
+//	@Failure		500		{object}	utils.HTTPError
+//
+//	@Router			/project [post]
+func (server *Server) AddProject(w http.ResponseWriter, r *http.Request) {
+	var input model.ProjectInput
+
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		utils.JSONError(w, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	if err := validateProjectInput(&input); err != nil {
+		utils.JSONError(w, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	userID := r.Context().Value("userID").(string)
+
+	project, err := server.ProjectStorage.CreateProject(server.ctx, userID, input)
+	if err != nil {
+		utils.JSONError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	utils.JSONFormat(w, http.StatusCreated, project)
+}
+
+// UpdateProject update project, archive or unarchive it
+//
+//	@Description	UpdateProject
+//	@Summary		update project with given fields
+//	@Tags			projects
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		int					true	"id"
+//	@Param			input	body		domain.ProjectInput	true	"project info"
+//	@Success		200		{object}	domain.Project
+//
+//	@Failure		400		{object}	utils.HTTPError
+//	@Failure		404		{object}	utils.HTTPError
+//	@Failure		422		{object}	utils.HTTPError
+//	@Failure		500		{object}	utils.HTTPError
+//
+//	@Router			/project/{id} [put]
+func (server *Server) UpdateProject(w http.ResponseWriter, r *http.Request) {
+	projectID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.JSONError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	var input model.ProjectInput
+
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		utils.JSONError(w, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	if err := validateProjectInput(&input); err != nil {
+		utils.JSONError(w, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	userID := r.Context().Value("userID").(string)
+
+	project, err := server.ProjectStorage.UpdateProject(server.ctx, projectID, userID, input)
+	if err != nil {
+		if errors.Is(err, model.ErrCantFindProjectWithID) {
+			utils.JSONError(w, http.StatusNotFound, err)
+			return
+		}
+		utils.JSONError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	utils.JSONFormat(w, http.StatusOK, project)
+}
+
+// DeleteProject delete project
+//
+//	@Description	DeleteProject
+//	@Summary		delete project. Its reminds are kept without project unless reminds=delete is passed
+//	@Tags			projects
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		int		true	"id"
+//	@Param			reminds	query		string	false	"keep (default) or delete"
+//	@Success		204		{string}	string	"project successfully deleted"
+//
+//	@Failure		400		{object}	utils.HTTPError
+//	@Failure		404		{object}	utils.HTTPError
+//	@Failure		500		{object}	utils.HTTPError
+//
+//	@Router			/project/{id} [delete]
+func (server *Server) DeleteProject(w http.ResponseWriter, r *http.Request) {
+	projectID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.JSONError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	var withReminds bool
+
+	switch r.URL.Query().Get("reminds") {
+	case "", "keep":
+	case "delete":
+		withReminds = true
+	default:
+		utils.JSONError(w, http.StatusBadRequest, errors.New("reminds parameter is invalid, should be keep or delete"))
+		return
+	}
+
+	userID := r.Context().Value("userID").(string)
+
+	if err := server.ProjectStorage.DeleteProject(server.ctx, projectID, userID, withReminds); err != nil {
+		if errors.Is(err, model.ErrCantFindProjectWithID) {
+			utils.JSONError(w, http.StatusNotFound, err)
+			return
+		}
+		utils.JSONError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	utils.JSONFormat(w, http.StatusNoContent, "project successfully deleted")
+}
+
+func validateProjectInput(input *model.ProjectInput) error {
+	input.Name = strings.TrimSpace(input.Name)
+	if input.Name == "" {
+		return errors.New("name is empty")
+	}
+	if input.Position != nil && *input.Position < 0 {
+		return errors.New("position can't be negative")
+	}
+	return nil
+}
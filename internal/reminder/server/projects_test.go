@@ -0,0 +1,238 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/red-rocket-software/reminder-go/internal/reminder/domain"
+	mockdb "github.com/red-rocket-software/reminder-go/internal/reminder/domain/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServer_GetProjects(t *testing.T) {
+	testCases := []struct {
+		name               string
+		query              string
+		mockBehavior       func(store *mockdb.MockProjectRepository)
+		expectedStatusCode int
+	}{
+		{
+			name: "OK",
+			mockBehavior: func(store *mockdb.MockProjectRepository) {
+				store.EXPECT().GetProjects(gomock.Any(), testUserID, false).Return([]domain.Project{{ID: 1, Name: "client"}}, nil)
+			},
+			expectedStatusCode: 200,
+		},
+		{
+			name:  "OK with archived",
+			query: "?archived=true",
+			mockBehavior: func(store *mockdb.MockProjectRepository) {
+				store.EXPECT().GetProjects(gomock.Any(), testUserID, true).Return([]domain.Project{}, nil)
+			},
+			expectedStatusCode: 200,
+		},
+		{
+			name:               "Error - wrong archived",
+			query:              "?archived=maybe",
+			mockBehavior:       func(store *mockdb.MockProjectRepository) {},
+			expectedStatusCode: 400,
+		},
+		{
+			name: "Error - internal error",
+			mockBehavior: func(store *mockdb.MockProjectRepository) {
+				store.EXPECT().GetProjects(gomock.Any(), testUserID, false).Return(nil, errors.New("something went wrong"))
+			},
+			expectedStatusCode: 500,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			projectStore := mockdb.NewMockProjectRepository(c)
+			test.mockBehavior(projectStore)
+
+			server := newTestServer(mockdb.NewMockTodoRepository(c), mockdb.NewMockConfigRepository(c))
+			server.ProjectStorage = projectStore
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodGet, "/projects"+test.query, http.NoBody)
+			req = req.WithContext(context.WithValue(req.Context(), "userID", testUserID))
+
+			handler := http.HandlerFunc(server.GetProjects)
+			handler.ServeHTTP(w, req)
+
+			require.Equal(t, test.expectedStatusCode, w.Code)
+		})
+	}
+}
+
+func TestServer_AddProject(t *testing.T) {
+	testCases := []struct {
+		name               string
+		body               string
+		mockBehavior       func(store *mockdb.MockProjectRepository)
+		expectedStatusCode int
+	}{
+		{
+			name: "OK",
+			body: `{"name": "client"}`,
+			mockBehavior: func(store *mockdb.MockProjectRepository) {
+				store.EXPECT().CreateProject(gomock.Any(), testUserID, domain.ProjectInput{Name: "client"}).Return(domain.Project{ID: 1, Name: "client"}, nil)
+			},
+			expectedStatusCode: 201,
+		},
+		{
+			name:               "Error - empty name",
+			body:               `{"name": ""}`,
+			mockBehavior:       func(store *mockdb.MockProjectRepository) {},
+			expectedStatusCode: 422,
+		},
+		{
+			name:               "Error - negative position",
+			body:               `{"name": "client", "position": -1}`,
+			mockBehavior:       func(store *mockdb.MockProjectRepository) {},
+			expectedStatusCode: 422,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			projectStore := mockdb.NewMockProjectRepository(c)
+			test.mockBehavior(projectStore)
+
+			server := newTestServer(mockdb.NewMockTodoRepository(c), mockdb.NewMockConfigRepository(c))
+			server.ProjectStorage = projectStore
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodPost, "/project", bytes.NewBufferString(test.body))
+			req = req.WithContext(context.WithValue(req.Context(), "userID", testUserID))
+
+			handler := http.HandlerFunc(server.AddProject)
+			handler.ServeHTTP(w, req)
+
+			require.Equal(t, test.expectedStatusCode, w.Code)
+		})
+	}
+}
+
+func TestServer_UpdateProject(t *testing.T) {
+	testCases := []struct {
+		name               string
+		body               string
+		mockBehavior       func(store *mockdb.MockProjectRepository)
+		expectedStatusCode int
+	}{
+		{
+			name: "OK archive",
+			body: `{"name": "client", "archived": true}`,
+			mockBehavior: func(store *mockdb.MockProjectRepository) {
+				store.EXPECT().UpdateProject(gomock.Any(), 1, testUserID, domain.ProjectInput{Name: "client", Archived: true}).Return(domain.Project{ID: 1}, nil)
+			},
+			expectedStatusCode: 200,
+		},
+		{
+			name: "Error - not found",
+			body: `{"name": "client"}`,
+			mockBehavior: func(store *mockdb.MockProjectRepository) {
+				store.EXPECT().UpdateProject(gomock.Any(), 1, testUserID, domain.ProjectInput{Name: "client"}).Return(domain.Project{}, domain.ErrCantFindProjectWithID)
+			},
+			expectedStatusCode: 404,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			projectStore := mockdb.NewMockProjectRepository(c)
+			test.mockBehavior(projectStore)
+
+			server := newTestServer(mockdb.NewMockTodoRepository(c), mockdb.NewMockConfigRepository(c))
+			server.ProjectStorage = projectStore
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodPut, "/project/1", bytes.NewBufferString(test.body))
+			req = req.WithContext(context.WithValue(req.Context(), "userID", testUserID))
+			req = mux.SetURLVars(req, map[string]string{"id": "1"})
+
+			handler := http.HandlerFunc(server.UpdateProject)
+			handler.ServeHTTP(w, req)
+
+			require.Equal(t, test.expectedStatusCode, w.Code)
+		})
+	}
+}
+
+func TestServer_DeleteProject(t *testing.T) {
+	testCases := []struct {
+		name               string
+		query              string
+		mockBehavior       func(store *mockdb.MockProjectRepository)
+		expectedStatusCode int
+	}{
+		{
+			name: "OK keep reminds",
+			mockBehavior: func(store *mockdb.MockProjectRepository) {
+				store.EXPECT().DeleteProject(gomock.Any(), 1, testUserID, false).Return(nil)
+			},
+			expectedStatusCode: 204,
+		},
+		{
+			name:  "OK delete reminds",
+			query: "?reminds=delete",
+			mockBehavior: func(store *mockdb.MockProjectRepository) {
+				store.EXPECT().DeleteProject(gomock.Any(), 1, testUserID, true).Return(nil)
+			},
+			expectedStatusCode: 204,
+		},
+		{
+			name:               "Error - wrong reminds",
+			query:              "?reminds=archive",
+			mockBehavior:       func(store *mockdb.MockProjectRepository) {},
+			expectedStatusCode: 400,
+		},
+		{
+			name: "Error - not found",
+			mockBehavior: func(store *mockdb.MockProjectRepository) {
+				store.EXPECT().DeleteProject(gomock.Any(), 1, testUserID, false).Return(domain.ErrCantFindProjectWithID)
+			},
+			expectedStatusCode: 404,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			projectStore := mockdb.NewMockProjectRepository(c)
+			test.mockBehavior(projectStore)
+
+			server := newTestServer(mockdb.NewMockTodoRepository(c), mockdb.NewMockConfigRepository(c))
+			server.ProjectStorage = projectStore
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodDelete, "/project/1"+test.query, http.NoBody)
+			req = req.WithContext(context.WithValue(req.Context(), "userID", testUserID))
+			req = mux.SetURLVars(req, map[string]string{"id": "1"})
+
+			handler := http.HandlerFunc(server.DeleteProject)
+			handler.ServeHTTP(w, req)
+
+			require.Equal(t, test.expectedStatusCode, w.Code)
+		})
+	}
+}
@@ -15,20 +15,76 @@ func (server *Server) ConfigureReminderRouter() *mux.Router {
 
 	router.HandleFunc("/health", server.HealthCheck).Methods("GET")
 
+	// calendar clients can't authenticate, so the feed is protected by its secret token
+	router.HandleFunc("/calendar/feed/{token}", server.GetCalendarFeed).Methods("GET")
+
 	// private routes
 	privateRoute := router.PathPrefix("").Subrouter()
 	privateRoute.Use(server.AuthMiddleware)
 
 	privateRoute.HandleFunc("/reminds", server.GetReminds).Methods("GET", "OPTIONS")
+	privateRoute.HandleFunc("/reminds/bulk", server.BulkReminds).Methods("POST", "OPTIONS")
+	privateRoute.HandleFunc("/reminds/import", server.ImportReminds).Methods("POST", "OPTIONS")
 	privateRoute.HandleFunc("/remind/{id}", server.GetRemindByID).Methods("GET")
 	privateRoute.HandleFunc("/remind", server.AddRemind).Methods("POST", "OPTIONS")
 	privateRoute.HandleFunc("/remind/{id}", server.DeleteRemind).Methods("DELETE", "OPTIONS")
 	privateRoute.HandleFunc("/remind/{id}", server.UpdateRemind).Methods("PUT")
+	privateRoute.HandleFunc("/remind/{id}", server.PatchRemind).Methods("PATCH")
+
+	privateRoute.HandleFunc("/trash", server.GetTrash).Methods("GET")
+	privateRoute.HandleFunc("/trash/{id}", server.RestoreRemind).Methods("PUT", "OPTIONS")
+	privateRoute.HandleFunc("/trash/{id}", server.PurgeRemind).Methods("DELETE", "OPTIONS")
+
+	privateRoute.HandleFunc("/remind/{id}/history", server.GetHistory).Methods("GET")
+
+	privateRoute.HandleFunc("/remind/{id}/items", server.GetItems).Methods("GET")
+	privateRoute.HandleFunc("/remind/{id}/items", server.AddItem).Methods("POST", "OPTIONS")
+	privateRoute.HandleFunc("/remind/{id}/items/{itemID}", server.UpdateItem).Methods("PUT")
+	privateRoute.HandleFunc("/remind/{id}/items/{itemID}", server.DeleteItem).Methods("DELETE", "OPTIONS")
+
+	privateRoute.HandleFunc("/remind/{id}/members", server.GetMembers).Methods("GET")
+	privateRoute.HandleFunc("/remind/{id}/members", server.AddMember).Methods("POST", "OPTIONS")
+	privateRoute.HandleFunc("/remind/{id}/members/{memberID}", server.UpdateMember).Methods("PUT")
+	privateRoute.HandleFunc("/remind/{id}/members/{memberID}", server.DeleteMember).Methods("DELETE", "OPTIONS")
+
+	privateRoute.HandleFunc("/tags", server.GetTags).Methods("GET")
+	privateRoute.HandleFunc("/tag", server.AddTag).Methods("POST", "OPTIONS")
+	privateRoute.HandleFunc("/tag/{id}", server.UpdateTag).Methods("PUT")
+	privateRoute.HandleFunc("/tag/{id}", server.DeleteTag).Methods("DELETE", "OPTIONS")
+
+	privateRoute.HandleFunc("/projects", server.GetProjects).Methods("GET")
+	privateRoute.HandleFunc("/project", server.AddProject).Methods("POST", "OPTIONS")
+	privateRoute.HandleFunc("/project/{id}", server.UpdateProject).Methods("PUT")
+	privateRoute.HandleFunc("/project/{id}", server.DeleteProject).Methods("DELETE", "OPTIONS")
+
+	privateRoute.HandleFunc("/views", server.GetViews).Methods("GET")
+	privateRoute.HandleFunc("/view", server.AddView).Methods("POST", "OPTIONS")
+	privateRoute.HandleFunc("/view/{id}", server.UpdateView).Methods("PUT")
+	privateRoute.HandleFunc("/view/{id}", server.DeleteView).Methods("DELETE", "OPTIONS")
+	privateRoute.HandleFunc("/views/{id}/reminds", server.GetViewReminds).Methods("GET")
+
+	privateRoute.HandleFunc("/calendar/export", server.ExportCalendar).Methods("GET")
+	privateRoute.HandleFunc("/calendar/feed", server.CreateCalendarFeed).Methods("POST", "OPTIONS")
+	privateRoute.HandleFunc("/calendar/feed", server.DeleteCalendarFeed).Methods("DELETE")
+
+	privateRoute.HandleFunc("/account/export", server.ExportAccount).Methods("GET")
+	privateRoute.HandleFunc("/account/erasure", server.RequestErasure).Methods("POST", "OPTIONS")
+	privateRoute.HandleFunc("/account/erasure", server.CancelErasure).Methods("DELETE")
 
 	privateRoute.HandleFunc("/status/{id}", server.UpdateCompleteStatus).Methods("PUT", "OPTIONS")
+	privateRoute.HandleFunc("/remind/{id}/dismiss", server.DismissOverdue).Methods("PUT", "OPTIONS")
 	privateRoute.HandleFunc("/configs/{id}", server.GetOrCreateUserConfig).Methods("GET", "OPTIONS")
 	privateRoute.HandleFunc("/configs/{id}", server.UpdateUserConfig).Methods("PUT", "OPTIONS")
 
+	// admin routes
+	adminRoute := privateRoute.PathPrefix("/admin").Subrouter()
+	adminRoute.Use(server.AdminMiddleware)
+
+	adminRoute.HandleFunc("/notifications/dead", server.GetDeadNotifications).Methods("GET")
+	adminRoute.HandleFunc("/notifications/{id}/retry", server.RetryNotification).Methods("POST", "OPTIONS")
+	adminRoute.HandleFunc("/outbox/dead", server.GetDeadOutbox).Methods("GET")
+	adminRoute.HandleFunc("/outbox/{id}/retry", server.RetryOutbox).Methods("POST", "OPTIONS")
+
 	router.PathPrefix("/swagger/").Handler(httpSwagger.WrapHandler)
 
 	return router
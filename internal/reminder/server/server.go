@@ -21,18 +21,24 @@ type Server struct {
 	Logger         logging.Logger
 	TodoStorage    model.TodoRepository
 	ConfigsStorage model.ConfigRepository
+	TagStorage     model.TagRepository
+	ProjectStorage model.ProjectRepository
+	ViewStorage    model.ViewRepository
 	FireClient     firestore.Client
 	ctx            context.Context
 	config         config.Config
 }
 
 // New returns new Server.
-func New(ctx context.Context, logger logging.Logger, todoStorage model.TodoRepository, configsStorage model.ConfigRepository, fireClient firestore.Client, cfg config.Config) *Server {
+func New(ctx context.Context, logger logging.Logger, todoStorage model.TodoRepository, configsStorage model.ConfigRepository, tagStorage model.TagRepository, projectStorage model.ProjectRepository, viewStorage model.ViewRepository, fireClient firestore.Client, cfg config.Config) *Server {
 	server := &Server{
 		ctx:            ctx,
 		Logger:         logger,
 		TodoStorage:    todoStorage,
 		ConfigsStorage: configsStorage,
+		TagStorage:     tagStorage,
+		ProjectStorage: projectStorage,
+		ViewStorage:    viewStorage,
 		FireClient:     fireClient,
 		config:         cfg,
 	}
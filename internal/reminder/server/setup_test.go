@@ -20,7 +20,7 @@ func newTestServer(todoStorage model.TodoRepository, configsStorage model.Config
 	opt := option.WithCredentialsFile("serviceAccountKey.json")
 	fireClient, _ := firestore.NewClient(context.Background(), opt)
 
-	server := New(context.Background(), logger, todoStorage, configsStorage, fireClient, cfg)
+	server := New(context.Background(), logger, todoStorage, configsStorage, nil, nil, nil, fireClient, cfg)
 
 	return server
 }
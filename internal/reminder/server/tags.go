@@ -0,0 +1,170 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/mux"
+	model "github.com/red-rocket-software/reminder-go/internal/reminder/domain"
+	"github.com/red-rocket-software/reminder-go/pkg/utils"
+)
+
+// GetTags return all user tags
+//
+//	@Description	GetTags
+//	@Summary		return tags of user
+//	@Tags			tags
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{array}		domain.Tag
+//
+//	@Failure		500	{object}	utils.HTTPError
+//
+//	@Router			/tags [get]
+func (server *Server) GetTags(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+
+	tags, err := server.TagStorage.GetTags(server.ctx, userID)
+	if err != nil {
+		utils.JSONError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	utils.JSONFormat(w, http.StatusOK, tags)
+}
+
+// AddTag create a new tag
+//
+//	@Description	AddTag
+//	@Summary		create a new tag
+//	@Tags			tags
+//	@Accept			json
+//	@Produce		json
+//	@Param			input	body		domain.TagInput	true	"tag info"
+//	@Success		201		{object}	domain.Tag
+//
+//	@Failure		409		{object}	utils.HTTPError
+//	@Failure		422		{object}	utils.HTTPError
+//	@Failure		500		{object}	utils.HTTPError
+//
+//	@Router			/tag [post]
+func (server *Server) AddTag(w http.ResponseWriter, r *http.Request) {
+	var input model.TagInput
+
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		utils.JSONError(w, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	input.Name = strings.TrimSpace(input.Name)
+	if input.Name == "" {
+		utils.JSONError(w, http.StatusUnprocessableEntity, errors.New("name is empty"))
+		return
+	}
+
+	userID := r.Context().Value("userID").(string)
+
+	tag, err := server.TagStorage.CreateTag(server.ctx, userID, input)
+	if err != nil {
+		tagsError(w, err)
+		return
+	}
+
+	utils.JSONFormat(w, http.StatusCreated, tag)
+}
+
+// UpdateTag update tag name and color
+//
+//	@Description	UpdateTag
+//	@Summary		update tag with given fields
+//	@Tags			tags
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		int				true	"id"
+//	@Param			input	body		domain.TagInput	true	"tag info"
+//	@Success		200		{object}	domain.Tag
+//
+//	@Failure		400		{object}	utils.HTTPError
+//	@Failure		404		{object}	utils.HTTPError
+//	@Failure		409		{object}	utils.HTTPError
+//	@Failure		422		{object}	utils.HTTPError
+//	@Failure		500		{object}	utils.HTTPError
+//
+//	@Router			/tag/{id} [put]
+func (server *Server) UpdateTag(w http.ResponseWriter, r *http.Request) {
+	tagID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.JSONError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	var input model.TagInput
+
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		utils.JSONError(w, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	input.Name = strings.TrimSpace(input.Name)
+	if input.Name == "" {
+		utils.JSONError(w, http.StatusUnprocessableEntity, errors.New("name is empty"))
+		return
+	}
+
+	userID := r.Context().Value("userID").(string)
+
+	tag, err := server.TagStorage.UpdateTag(server.ctx, tagID, userID, input)
+	if err != nil {
+		tagsError(w, err)
+		return
+	}
+
+	utils.JSONFormat(w, http.StatusOK, tag)
+}
+
+// DeleteTag delete tag, reminds with this tag are kept
+//
+//	@Description	DeleteTag
+//	@Summary		delete tag
+//	@Tags			tags
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		int		true	"id"
+//	@Success		204	{string}	string	"tag successfully deleted"
+//
+//	@Failure		400	{object}	utils.HTTPError
+//	@Failure		404	{object}	utils.HTTPError
+//	@Failure		500	{object}	utils.HTTPError
+//
+//	@Router			/tag/{id} [delete]
+func (server *Server) DeleteTag(w http.ResponseWriter, r *http.Request) {
+	tagID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.JSONError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	userID := r.Context().Value("userID").(string)
+
+	if err := server.TagStorage.DeleteTag(server.ctx, tagID, userID); err != nil {
+		tagsError(w, err)
+		return
+	}
+
+	utils.JSONFormat(w, http.StatusNoContent, "tag successfully deleted")
+}
+
+func tagsError(w http.ResponseWriter, err error) {
+	if errors.Is(err, model.ErrCantFindTagWithID) {
+		utils.JSONError(w, http.StatusNotFound, err)
+		return
+	}
+	if errors.Is(err, model.ErrTagAlreadyExists) {
+		utils.JSONError(w, http.StatusConflict, err)
+		return
+	}
+	utils.JSONError(w, http.StatusInternalServerError, err)
+}
@@ -0,0 +1,228 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/red-rocket-software/reminder-go/internal/reminder/domain"
+	mockdb "github.com/red-rocket-software/reminder-go/internal/reminder/domain/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServer_GetTags(t *testing.T) {
+	testCases := []struct {
+		name               string
+		mockBehavior       func(store *mockdb.MockTagRepository)
+		expectedStatusCode int
+	}{
+		{
+			name: "OK",
+			mockBehavior: func(store *mockdb.MockTagRepository) {
+				store.EXPECT().GetTags(gomock.Any(), testUserID).Return([]domain.Tag{{ID: 1, Name: "work"}}, nil)
+			},
+			expectedStatusCode: 200,
+		},
+		{
+			name: "Error - internal error",
+			mockBehavior: func(store *mockdb.MockTagRepository) {
+				store.EXPECT().GetTags(gomock.Any(), testUserID).Return(nil, errors.New("something went wrong"))
+			},
+			expectedStatusCode: 500,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			tagStore := mockdb.NewMockTagRepository(c)
+			test.mockBehavior(tagStore)
+
+			server := newTestServer(mockdb.NewMockTodoRepository(c), mockdb.NewMockConfigRepository(c))
+			server.TagStorage = tagStore
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodGet, "/tags", http.NoBody)
+			req = req.WithContext(context.WithValue(req.Context(), "userID", testUserID))
+
+			handler := http.HandlerFunc(server.GetTags)
+			handler.ServeHTTP(w, req)
+
+			require.Equal(t, test.expectedStatusCode, w.Code)
+		})
+	}
+}
+
+func TestServer_AddTag(t *testing.T) {
+	color := "#ff0000"
+
+	testCases := []struct {
+		name               string
+		body               string
+		mockBehavior       func(store *mockdb.MockTagRepository)
+		expectedStatusCode int
+	}{
+		{
+			name: "OK",
+			body: `{"name": " work ", "color": "#ff0000"}`,
+			mockBehavior: func(store *mockdb.MockTagRepository) {
+				store.EXPECT().CreateTag(gomock.Any(), testUserID, domain.TagInput{Name: "work", Color: &color}).Return(domain.Tag{ID: 1, Name: "work"}, nil)
+			},
+			expectedStatusCode: 201,
+		},
+		{
+			name:               "Error - empty name",
+			body:               `{"name": " "}`,
+			mockBehavior:       func(store *mockdb.MockTagRepository) {},
+			expectedStatusCode: 422,
+		},
+		{
+			name:               "Error - wrong body",
+			body:               `{"name": 1}`,
+			mockBehavior:       func(store *mockdb.MockTagRepository) {},
+			expectedStatusCode: 422,
+		},
+		{
+			name: "Error - already exists",
+			body: `{"name": "work"}`,
+			mockBehavior: func(store *mockdb.MockTagRepository) {
+				store.EXPECT().CreateTag(gomock.Any(), testUserID, domain.TagInput{Name: "work"}).Return(domain.Tag{}, domain.ErrTagAlreadyExists)
+			},
+			expectedStatusCode: 409,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			tagStore := mockdb.NewMockTagRepository(c)
+			test.mockBehavior(tagStore)
+
+			server := newTestServer(mockdb.NewMockTodoRepository(c), mockdb.NewMockConfigRepository(c))
+			server.TagStorage = tagStore
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodPost, "/tag", bytes.NewBufferString(test.body))
+			req = req.WithContext(context.WithValue(req.Context(), "userID", testUserID))
+
+			handler := http.HandlerFunc(server.AddTag)
+			handler.ServeHTTP(w, req)
+
+			require.Equal(t, test.expectedStatusCode, w.Code)
+		})
+	}
+}
+
+func TestServer_UpdateTag(t *testing.T) {
+	testCases := []struct {
+		name               string
+		id                 string
+		body               string
+		mockBehavior       func(store *mockdb.MockTagRepository)
+		expectedStatusCode int
+	}{
+		{
+			name: "OK",
+			id:   "1",
+			body: `{"name": "home"}`,
+			mockBehavior: func(store *mockdb.MockTagRepository) {
+				store.EXPECT().UpdateTag(gomock.Any(), 1, testUserID, domain.TagInput{Name: "home"}).Return(domain.Tag{ID: 1, Name: "home"}, nil)
+			},
+			expectedStatusCode: 200,
+		},
+		{
+			name:               "Error - wrong id",
+			id:                 "one",
+			body:               `{"name": "home"}`,
+			mockBehavior:       func(store *mockdb.MockTagRepository) {},
+			expectedStatusCode: 400,
+		},
+		{
+			name: "Error - not found",
+			id:   "1",
+			body: `{"name": "home"}`,
+			mockBehavior: func(store *mockdb.MockTagRepository) {
+				store.EXPECT().UpdateTag(gomock.Any(), 1, testUserID, domain.TagInput{Name: "home"}).Return(domain.Tag{}, domain.ErrCantFindTagWithID)
+			},
+			expectedStatusCode: 404,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			tagStore := mockdb.NewMockTagRepository(c)
+			test.mockBehavior(tagStore)
+
+			server := newTestServer(mockdb.NewMockTodoRepository(c), mockdb.NewMockConfigRepository(c))
+			server.TagStorage = tagStore
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodPut, "/tag/"+test.id, bytes.NewBufferString(test.body))
+			req = req.WithContext(context.WithValue(req.Context(), "userID", testUserID))
+			req = mux.SetURLVars(req, map[string]string{"id": test.id})
+
+			handler := http.HandlerFunc(server.UpdateTag)
+			handler.ServeHTTP(w, req)
+
+			require.Equal(t, test.expectedStatusCode, w.Code)
+		})
+	}
+}
+
+func TestServer_DeleteTag(t *testing.T) {
+	testCases := []struct {
+		name               string
+		mockBehavior       func(store *mockdb.MockTagRepository)
+		expectedStatusCode int
+	}{
+		{
+			name: "OK",
+			mockBehavior: func(store *mockdb.MockTagRepository) {
+				store.EXPECT().DeleteTag(gomock.Any(), 1, testUserID).Return(nil)
+			},
+			expectedStatusCode: 204,
+		},
+		{
+			name: "Error - not found",
+			mockBehavior: func(store *mockdb.MockTagRepository) {
+				store.EXPECT().DeleteTag(gomock.Any(), 1, testUserID).Return(domain.ErrCantFindTagWithID)
+			},
+			expectedStatusCode: 404,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			tagStore := mockdb.NewMockTagRepository(c)
+			test.mockBehavior(tagStore)
+
+			server := newTestServer(mockdb.NewMockTodoRepository(c), mockdb.NewMockConfigRepository(c))
+			server.TagStorage = tagStore
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodDelete, "/tag/1", http.NoBody)
+			req = req.WithContext(context.WithValue(req.Context(), "userID", testUserID))
+			req = mux.SetURLVars(req, map[string]string{"id": "1"})
+
+			handler := http.HandlerFunc(server.DeleteTag)
+			handler.ServeHTTP(w, req)
+
+			require.Equal(t, test.expectedStatusCode, w.Code)
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	model "github.com/red-rocket-software/reminder-go/internal/reminder/domain"
+	"github.com/red-rocket-software/reminder-go/pkg/utils"
+)
+
+// GetHistory return changes of remind
+//
+//	@Description	GetHistory
+//	@Summary		return changes of remind with their actor, time and changed fields, oldest first
+//	@Tags			reminds
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		int	true	"remind id"
+//	@Success		200	{array}		domain.HistoryEntry
+//
+//	@Failure		400	{object}	utils.HTTPError
+//	@Failure		404	{object}	utils.HTTPError
+//	@Failure		500	{object}	utils.HTTPError
+//
+//	@Router			/remind/{id}/history [get]
+func (server *Server) GetHistory(w http.ResponseWriter, r *http.Request) {
+	rID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.JSONError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	userID := r.Context().Value("userID").(string)
+
+	history, err := server.TodoStorage.GetHistory(server.ctx, rID, userID)
+	if err != nil {
+		if errors.Is(err, model.ErrCantFindRemindWithID) {
+			utils.JSONError(w, http.StatusNotFound, err)
+			return
+		}
+		utils.JSONError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	utils.JSONFormat(w, http.StatusOK, history)
+}
@@ -0,0 +1,94 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/red-rocket-software/reminder-go/internal/reminder/domain"
+	mockdb "github.com/red-rocket-software/reminder-go/internal/reminder/domain/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServer_GetHistory(t *testing.T) {
+	history := []domain.HistoryEntry{
+		{ID: 1, TodoID: 1, Actor: testUserID, Action: domain.HistoryCreate, Changes: map[string]domain.FieldChange{"title": {To: "title"}}, CreatedAt: time.Now()},
+		{ID: 2, TodoID: 1, Actor: testUserID, Action: domain.HistoryUpdate, Changes: map[string]domain.FieldChange{"title": {From: "title", To: "new title"}}, CreatedAt: time.Now()},
+	}
+
+	testCases := []struct {
+		name               string
+		id                 string
+		mockBehavior       func(store *mockdb.MockTodoRepository)
+		expectedStatusCode int
+		expectedLen        int
+	}{
+		{
+			name: "OK",
+			id:   "1",
+			mockBehavior: func(store *mockdb.MockTodoRepository) {
+				store.EXPECT().GetHistory(gomock.Any(), 1, testUserID).Return(history, nil)
+			},
+			expectedStatusCode: 200,
+			expectedLen:        2,
+		},
+		{
+			name:               "Error - wrong id",
+			id:                 "one",
+			mockBehavior:       func(store *mockdb.MockTodoRepository) {},
+			expectedStatusCode: 400,
+		},
+		{
+			name: "Error - remind of another user",
+			id:   "1",
+			mockBehavior: func(store *mockdb.MockTodoRepository) {
+				store.EXPECT().GetHistory(gomock.Any(), 1, testUserID).Return(nil, domain.ErrCantFindRemindWithID)
+			},
+			expectedStatusCode: 404,
+		},
+		{
+			name: "Error - internal error",
+			id:   "1",
+			mockBehavior: func(store *mockdb.MockTodoRepository) {
+				store.EXPECT().GetHistory(gomock.Any(), 1, testUserID).Return(nil, errors.New("something went wrong"))
+			},
+			expectedStatusCode: 500,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			configStore := mockdb.NewMockConfigRepository(c)
+			todoStore := mockdb.NewMockTodoRepository(c)
+			test.mockBehavior(todoStore)
+
+			server := newTestServer(todoStore, configStore)
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodGet, "/remind/"+test.id+"/history", http.NoBody)
+			req = req.WithContext(context.WithValue(req.Context(), "userID", testUserID))
+			req = mux.SetURLVars(req, map[string]string{"id": test.id})
+
+			handler := http.HandlerFunc(server.GetHistory)
+			handler.ServeHTTP(w, req)
+
+			require.Equal(t, test.expectedStatusCode, w.Code)
+
+			if test.expectedStatusCode == 200 {
+				var got []domain.HistoryEntry
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &got))
+				require.Len(t, got, test.expectedLen)
+				require.Equal(t, "new title", got[1].Changes["title"].To)
+			}
+		})
+	}
+}
@@ -0,0 +1,213 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+	model "github.com/red-rocket-software/reminder-go/internal/reminder/domain"
+	"github.com/red-rocket-software/reminder-go/pkg/utils"
+)
+
+// GetItems return checklist of remind
+//
+//	@Description	GetItems
+//	@Summary		return checklist items of remind
+//	@Tags			checklist
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		int	true	"remind id"
+//	@Success		200	{array}		domain.TodoItem
+//
+//	@Failure		400	{object}	utils.HTTPError
+//	@Failure		404	{object}	utils.HTTPError
+//	@Failure		500	{object}	utils.HTTPError
+//
+//	@Router			/remind/{id}/items [get]
+func (server *Server) GetItems(w http.ResponseWriter, r *http.Request) {
+	rID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.JSONError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	userID := r.Context().Value("userID").(string)
+
+	items, err := server.TodoStorage.GetItems(server.ctx, rID, userID)
+	if err != nil {
+		itemsError(w, err)
+		return
+	}
+
+	utils.JSONFormat(w, http.StatusOK, items)
+}
+
+// AddItem add item to remind checklist
+//
+//	@Description	AddItem
+//	@Summary		add checklist item to remind
+//	@Tags			checklist
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		int						true	"remind id"
+//	@Param			input	body		domain.TodoItemInput	true	"item info"
+//	@Success		201		{object}	domain.TodoItem
+//
+//	@Failure		400		{object}	utils.HTTPError
+//	@Failure		404		{object}	utils.HTTPError
+//	@Failure		422		{object}	utils.HTTPError
+//	@Failure		500		{object}	utils.HTTPError
+//
+//	@Router			/remind/{id}/items [post]
+func (server *Server) AddItem(w http.ResponseWriter, r *http.Request) {
+	rID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.JSONError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	var input model.TodoItemInput
+
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		utils.JSONError(w, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	if err := validateItemInput(input); err != nil {
+		utils.JSONError(w, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	userID := r.Context().Value("userID").(string)
+
+	item, err := server.TodoStorage.CreateItem(server.ctx, rID, userID, input)
+	if err != nil {
+		itemsError(w, err)
+		return
+	}
+
+	utils.JSONFormat(w, http.StatusCreated, item)
+}
+
+// UpdateItem update checklist item
+//
+//	@Description	UpdateItem
+//	@Summary		update checklist item with given fields
+//	@Tags			checklist
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		int						true	"remind id"
+//	@Param			itemID	path		int						true	"item id"
+//	@Param			input	body		domain.TodoItemInput	true	"item info"
+//	@Success		200		{object}	domain.TodoItem
+//
+//	@Failure		400		{object}	utils.HTTPError
+//	@Failure		404		{object}	utils.HTTPError
+//	@Failure		422		{object}	utils.HTTPError
+//	@Failure		500		{object}	utils.HTTPError
+//
+//	@Router			/remind/{id}/items/{itemID} [put]
+func (server *Server) UpdateItem(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	rID, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		utils.JSONError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	itemID, err := strconv.Atoi(vars["itemID"])
+	if err != nil {
+		utils.JSONError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	var input model.TodoItemInput
+
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		utils.JSONError(w, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	if err := validateItemInput(input); err != nil {
+		utils.JSONError(w, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	userID := r.Context().Value("userID").(string)
+
+	item, err := server.TodoStorage.UpdateItem(server.ctx, rID, itemID, userID, input)
+	if err != nil {
+		itemsError(w, err)
+		return
+	}
+
+	utils.JSONFormat(w, http.StatusOK, item)
+}
+
+// DeleteItem delete checklist item
+//
+//	@Description	DeleteItem
+//	@Summary		delete checklist item
+//	@Tags			checklist
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		int		true	"remind id"
+//	@Param			itemID	path		int		true	"item id"
+//	@Success		204		{string}	string	"item successfully deleted"
+//
+//	@Failure		400		{object}	utils.HTTPError
+//	@Failure		404		{object}	utils.HTTPError
+//	@Failure		500		{object}	utils.HTTPError
+//
+//	@Router			/remind/{id}/items/{itemID} [delete]
+func (server *Server) DeleteItem(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	rID, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		utils.JSONError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	itemID, err := strconv.Atoi(vars["itemID"])
+	if err != nil {
+		utils.JSONError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	userID := r.Context().Value("userID").(string)
+
+	if err := server.TodoStorage.DeleteItem(server.ctx, rID, itemID, userID); err != nil {
+		itemsError(w, err)
+		return
+	}
+
+	utils.JSONFormat(w, http.StatusNoContent, "item successfully deleted")
+}
+
+func validateItemInput(input model.TodoItemInput) error {
+	if input.Title == "" {
+		return errors.New("title is empty")
+	}
+	if input.DeadlineAt != nil {
+		if _, err := time.Parse(time.RFC3339, *input.DeadlineAt); err != nil {
+			return err
+		}
+	}
+	if input.Position != nil && *input.Position < 0 {
+		return errors.New("position can't be negative")
+	}
+	return nil
+}
+
+func itemsError(w http.ResponseWriter, err error) {
+	if errors.Is(err, model.ErrCantFindRemindWithID) || errors.Is(err, model.ErrCantFindItemWithID) {
+		utils.JSONError(w, http.StatusNotFound, err)
+		return
+	}
+	utils.JSONError(w, http.StatusInternalServerError, err)
+}
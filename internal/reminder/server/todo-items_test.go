@@ -0,0 +1,255 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/red-rocket-software/reminder-go/internal/reminder/domain"
+	mockdb "github.com/red-rocket-software/reminder-go/internal/reminder/domain/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+const testUserID = "rrdZH9ERxueDxj2m1e1T2vIQKBP2"
+
+func TestServer_GetItems(t *testing.T) {
+	testCases := []struct {
+		name               string
+		mockBehavior       func(store *mockdb.MockTodoRepository)
+		expectedStatusCode int
+	}{
+		{
+			name: "OK",
+			mockBehavior: func(store *mockdb.MockTodoRepository) {
+				store.EXPECT().GetItems(gomock.Any(), 1, testUserID).Return([]domain.TodoItem{{ID: 1, TodoID: 1, Title: "item"}}, nil)
+			},
+			expectedStatusCode: 200,
+		},
+		{
+			name: "Error - remind not found",
+			mockBehavior: func(store *mockdb.MockTodoRepository) {
+				store.EXPECT().GetItems(gomock.Any(), 1, testUserID).Return(nil, domain.ErrCantFindRemindWithID)
+			},
+			expectedStatusCode: 404,
+		},
+		{
+			name: "Error - internal error",
+			mockBehavior: func(store *mockdb.MockTodoRepository) {
+				store.EXPECT().GetItems(gomock.Any(), 1, testUserID).Return(nil, errors.New("something went wrong"))
+			},
+			expectedStatusCode: 500,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			configStore := mockdb.NewMockConfigRepository(c)
+			todoStore := mockdb.NewMockTodoRepository(c)
+			test.mockBehavior(todoStore)
+
+			server := newTestServer(todoStore, configStore)
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodGet, "/remind/1/items", http.NoBody)
+			req = req.WithContext(context.WithValue(req.Context(), "userID", testUserID))
+			req = mux.SetURLVars(req, map[string]string{"id": "1"})
+
+			handler := http.HandlerFunc(server.GetItems)
+			handler.ServeHTTP(w, req)
+
+			require.Equal(t, test.expectedStatusCode, w.Code)
+		})
+	}
+}
+
+func TestServer_AddItem(t *testing.T) {
+	position := 2
+	deadline := "2023-04-15T16:27:00+02:00"
+
+	testCases := []struct {
+		name               string
+		body               string
+		mockBehavior       func(store *mockdb.MockTodoRepository)
+		expectedStatusCode int
+	}{
+		{
+			name: "OK",
+			body: `{"title": "item"}`,
+			mockBehavior: func(store *mockdb.MockTodoRepository) {
+				store.EXPECT().CreateItem(gomock.Any(), 1, testUserID, domain.TodoItemInput{Title: "item"}).Return(domain.TodoItem{ID: 1}, nil)
+			},
+			expectedStatusCode: 201,
+		},
+		{
+			name: "OK with deadline and position",
+			body: `{"title": "item", "deadline_at": "2023-04-15T16:27:00+02:00", "position": 2}`,
+			mockBehavior: func(store *mockdb.MockTodoRepository) {
+				store.EXPECT().CreateItem(gomock.Any(), 1, testUserID, domain.TodoItemInput{
+					Title:      "item",
+					DeadlineAt: &deadline,
+					Position:   &position,
+				}).Return(domain.TodoItem{ID: 1}, nil)
+			},
+			expectedStatusCode: 201,
+		},
+		{
+			name:               "Error - empty title",
+			body:               `{"title": ""}`,
+			mockBehavior:       func(store *mockdb.MockTodoRepository) {},
+			expectedStatusCode: 422,
+		},
+		{
+			name:               "Error - wrong deadline",
+			body:               `{"title": "item", "deadline_at": "2023-04-15"}`,
+			mockBehavior:       func(store *mockdb.MockTodoRepository) {},
+			expectedStatusCode: 422,
+		},
+		{
+			name: "Error - remind not found",
+			body: `{"title": "item"}`,
+			mockBehavior: func(store *mockdb.MockTodoRepository) {
+				store.EXPECT().CreateItem(gomock.Any(), 1, testUserID, domain.TodoItemInput{Title: "item"}).Return(domain.TodoItem{}, domain.ErrCantFindRemindWithID)
+			},
+			expectedStatusCode: 404,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			configStore := mockdb.NewMockConfigRepository(c)
+			todoStore := mockdb.NewMockTodoRepository(c)
+			test.mockBehavior(todoStore)
+
+			server := newTestServer(todoStore, configStore)
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodPost, "/remind/1/items", bytes.NewBufferString(test.body))
+			req = req.WithContext(context.WithValue(req.Context(), "userID", testUserID))
+			req = mux.SetURLVars(req, map[string]string{"id": "1"})
+
+			handler := http.HandlerFunc(server.AddItem)
+			handler.ServeHTTP(w, req)
+
+			require.Equal(t, test.expectedStatusCode, w.Code)
+		})
+	}
+}
+
+func TestServer_UpdateItem(t *testing.T) {
+	testCases := []struct {
+		name               string
+		body               string
+		mockBehavior       func(store *mockdb.MockTodoRepository)
+		expectedStatusCode int
+	}{
+		{
+			name: "OK",
+			body: `{"title": "item", "completed": true}`,
+			mockBehavior: func(store *mockdb.MockTodoRepository) {
+				store.EXPECT().UpdateItem(gomock.Any(), 1, 2, testUserID, domain.TodoItemInput{Title: "item", Completed: true}).Return(domain.TodoItem{ID: 2}, nil)
+			},
+			expectedStatusCode: 200,
+		},
+		{
+			name:               "Error - negative position",
+			body:               `{"title": "item", "position": -1}`,
+			mockBehavior:       func(store *mockdb.MockTodoRepository) {},
+			expectedStatusCode: 422,
+		},
+		{
+			name: "Error - item not found",
+			body: `{"title": "item"}`,
+			mockBehavior: func(store *mockdb.MockTodoRepository) {
+				store.EXPECT().UpdateItem(gomock.Any(), 1, 2, testUserID, domain.TodoItemInput{Title: "item"}).Return(domain.TodoItem{}, domain.ErrCantFindItemWithID)
+			},
+			expectedStatusCode: 404,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			configStore := mockdb.NewMockConfigRepository(c)
+			todoStore := mockdb.NewMockTodoRepository(c)
+			test.mockBehavior(todoStore)
+
+			server := newTestServer(todoStore, configStore)
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodPut, "/remind/1/items/2", bytes.NewBufferString(test.body))
+			req = req.WithContext(context.WithValue(req.Context(), "userID", testUserID))
+			req = mux.SetURLVars(req, map[string]string{"id": "1", "itemID": "2"})
+
+			handler := http.HandlerFunc(server.UpdateItem)
+			handler.ServeHTTP(w, req)
+
+			require.Equal(t, test.expectedStatusCode, w.Code)
+		})
+	}
+}
+
+func TestServer_DeleteItem(t *testing.T) {
+	testCases := []struct {
+		name               string
+		mockBehavior       func(store *mockdb.MockTodoRepository)
+		expectedStatusCode int
+	}{
+		{
+			name: "OK",
+			mockBehavior: func(store *mockdb.MockTodoRepository) {
+				store.EXPECT().DeleteItem(gomock.Any(), 1, 2, testUserID).Return(nil)
+			},
+			expectedStatusCode: 204,
+		},
+		{
+			name: "Error - item not found",
+			mockBehavior: func(store *mockdb.MockTodoRepository) {
+				store.EXPECT().DeleteItem(gomock.Any(), 1, 2, testUserID).Return(domain.ErrCantFindItemWithID)
+			},
+			expectedStatusCode: 404,
+		},
+		{
+			name: "Error - internal error",
+			mockBehavior: func(store *mockdb.MockTodoRepository) {
+				store.EXPECT().DeleteItem(gomock.Any(), 1, 2, testUserID).Return(domain.ErrDeleteFailed)
+			},
+			expectedStatusCode: 500,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			configStore := mockdb.NewMockConfigRepository(c)
+			todoStore := mockdb.NewMockTodoRepository(c)
+			test.mockBehavior(todoStore)
+
+			server := newTestServer(todoStore, configStore)
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodDelete, "/remind/1/items/2", http.NoBody)
+			req = req.WithContext(context.WithValue(req.Context(), "userID", testUserID))
+			req = mux.SetURLVars(req, map[string]string{"id": "1", "itemID": "2"})
+
+			handler := http.HandlerFunc(server.DeleteItem)
+			handler.ServeHTTP(w, req)
+
+			require.Equal(t, test.expectedStatusCode, w.Code)
+		})
+	}
+}
@@ -0,0 +1,239 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/mail"
+	"strconv"
+
+	"firebase.google.com/go/auth"
+	"github.com/gorilla/mux"
+	model "github.com/red-rocket-software/reminder-go/internal/reminder/domain"
+	"github.com/red-rocket-software/reminder-go/pkg/utils"
+)
+
+// GetMembers return users the remind is shared with
+//
+//	@Description	GetMembers
+//	@Summary		return members of remind
+//	@Tags			members
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		int	true	"remind id"
+//	@Success		200	{array}		domain.Member
+//
+//	@Failure		400	{object}	utils.HTTPError
+//	@Failure		404	{object}	utils.HTTPError
+//	@Failure		500	{object}	utils.HTTPError
+//
+//	@Router			/remind/{id}/members [get]
+func (server *Server) GetMembers(w http.ResponseWriter, r *http.Request) {
+	rID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.JSONError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	userID := r.Context().Value("userID").(string)
+
+	members, err := server.TodoStorage.GetMembers(server.ctx, rID, userID)
+	if err != nil {
+		membersError(w, err)
+		return
+	}
+
+	utils.JSONFormat(w, http.StatusOK, members)
+}
+
+// AddMember share remind with user by id or email. Unknown email is kept as invite
+//
+//	@Description	AddMember
+//	@Summary		share remind with user
+//	@Tags			members
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		int					true	"remind id"
+//	@Param			input	body		domain.MemberInput	true	"member info"
+//	@Success		201		{object}	domain.Member
+//
+//	@Failure		400		{object}	utils.HTTPError
+//	@Failure		404		{object}	utils.HTTPError
+//	@Failure		409		{object}	utils.HTTPError
+//	@Failure		422		{object}	utils.HTTPError
+//	@Failure		500		{object}	utils.HTTPError
+//
+//	@Router			/remind/{id}/members [post]
+func (server *Server) AddMember(w http.ResponseWriter, r *http.Request) {
+	rID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.JSONError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	var input model.MemberInput
+
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		utils.JSONError(w, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	if err := validateMemberInput(input); err != nil {
+		utils.JSONError(w, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	userID := r.Context().Value("userID").(string)
+
+	// registered user is added by id, so the remind is shared with them right away
+	if input.UserID == nil {
+		user, err := server.FireClient.GetUserByEmail(*input.Email)
+		if err != nil && !auth.IsUserNotFound(err) {
+			utils.JSONError(w, http.StatusInternalServerError, err)
+			return
+		}
+		if err == nil {
+			input.UserID = &user.UID
+		}
+	}
+
+	if input.UserID != nil && *input.UserID == userID {
+		utils.JSONError(w, http.StatusUnprocessableEntity, errors.New("can't share remind with yourself"))
+		return
+	}
+
+	member, err := server.TodoStorage.AddMember(server.ctx, rID, userID, input)
+	if err != nil {
+		membersError(w, err)
+		return
+	}
+
+	utils.JSONFormat(w, http.StatusCreated, member)
+}
+
+// UpdateMember change member role and notifications
+//
+//	@Description	UpdateMember
+//	@Summary		update member role and notifications
+//	@Tags			members
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path		int					true	"remind id"
+//	@Param			memberID	path		int					true	"member id"
+//	@Param			input		body		domain.MemberInput	true	"member info"
+//	@Success		200			{object}	domain.Member
+//
+//	@Failure		400			{object}	utils.HTTPError
+//	@Failure		404			{object}	utils.HTTPError
+//	@Failure		422			{object}	utils.HTTPError
+//	@Failure		500			{object}	utils.HTTPError
+//
+//	@Router			/remind/{id}/members/{memberID} [put]
+func (server *Server) UpdateMember(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	rID, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		utils.JSONError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	memberID, err := strconv.Atoi(vars["memberID"])
+	if err != nil {
+		utils.JSONError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	var input model.MemberInput
+
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		utils.JSONError(w, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	if !input.Role.Valid() {
+		utils.JSONError(w, http.StatusUnprocessableEntity, model.ErrInvalidRole)
+		return
+	}
+
+	userID := r.Context().Value("userID").(string)
+
+	member, err := server.TodoStorage.UpdateMember(server.ctx, rID, memberID, userID, input)
+	if err != nil {
+		membersError(w, err)
+		return
+	}
+
+	utils.JSONFormat(w, http.StatusOK, member)
+}
+
+// DeleteMember stop sharing remind with member, members can leave remind themselves
+//
+//	@Description	DeleteMember
+//	@Summary		delete member of remind
+//	@Tags			members
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path		int		true	"remind id"
+//	@Param			memberID	path		int		true	"member id"
+//	@Success		204			{string}	string	"member successfully deleted"
+//
+//	@Failure		400			{object}	utils.HTTPError
+//	@Failure		404			{object}	utils.HTTPError
+//	@Failure		500			{object}	utils.HTTPError
+//
+//	@Router			/remind/{id}/members/{memberID} [delete]
+func (server *Server) DeleteMember(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	rID, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		utils.JSONError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	memberID, err := strconv.Atoi(vars["memberID"])
+	if err != nil {
+		utils.JSONError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	userID := r.Context().Value("userID").(string)
+
+	if err := server.TodoStorage.DeleteMember(server.ctx, rID, memberID, userID); err != nil {
+		membersError(w, err)
+		return
+	}
+
+	utils.JSONFormat(w, http.StatusNoContent, "member successfully deleted")
+}
+
+func validateMemberInput(input model.MemberInput) error {
+	if !input.Role.Valid() {
+		return model.ErrInvalidRole
+	}
+	if input.UserID == nil && input.Email == nil {
+		return errors.New("user_id or email is required")
+	}
+	if input.UserID != nil && *input.UserID == "" {
+		return errors.New("user_id is empty")
+	}
+	if input.Email != nil {
+		if _, err := mail.ParseAddress(*input.Email); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func membersError(w http.ResponseWriter, err error) {
+	if errors.Is(err, model.ErrCantFindRemindWithID) || errors.Is(err, model.ErrCantFindMemberWithID) {
+		utils.JSONError(w, http.StatusNotFound, err)
+		return
+	}
+	if errors.Is(err, model.ErrMemberAlreadyExists) {
+		utils.JSONError(w, http.StatusConflict, err)
+		return
+	}
+	utils.JSONError(w, http.StatusInternalServerError, err)
+}
@@ -0,0 +1,284 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"firebase.google.com/go/auth"
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/red-rocket-software/reminder-go/config"
+	"github.com/red-rocket-software/reminder-go/internal/reminder/domain"
+	mockdb "github.com/red-rocket-software/reminder-go/internal/reminder/domain/mocks"
+	mock_firestore "github.com/red-rocket-software/reminder-go/pkg/firestore/mocks"
+	"github.com/red-rocket-software/reminder-go/pkg/logging"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServer_GetMembers(t *testing.T) {
+	testCases := []struct {
+		name               string
+		mockBehavior       func(store *mockdb.MockTodoRepository)
+		expectedStatusCode int
+	}{
+		{
+			name: "OK",
+			mockBehavior: func(store *mockdb.MockTodoRepository) {
+				store.EXPECT().GetMembers(gomock.Any(), 1, testUserID).Return([]domain.Member{{ID: 1, TodoID: 1, Role: domain.RoleViewer}}, nil)
+			},
+			expectedStatusCode: 200,
+		},
+		{
+			name: "Error - remind not found",
+			mockBehavior: func(store *mockdb.MockTodoRepository) {
+				store.EXPECT().GetMembers(gomock.Any(), 1, testUserID).Return(nil, domain.ErrCantFindRemindWithID)
+			},
+			expectedStatusCode: 404,
+		},
+		{
+			name: "Error - internal error",
+			mockBehavior: func(store *mockdb.MockTodoRepository) {
+				store.EXPECT().GetMembers(gomock.Any(), 1, testUserID).Return(nil, errors.New("something went wrong"))
+			},
+			expectedStatusCode: 500,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			configStore := mockdb.NewMockConfigRepository(c)
+			todoStore := mockdb.NewMockTodoRepository(c)
+			test.mockBehavior(todoStore)
+
+			server := newTestServer(todoStore, configStore)
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodGet, "/remind/1/members", http.NoBody)
+			req = req.WithContext(context.WithValue(req.Context(), "userID", testUserID))
+			req = mux.SetURLVars(req, map[string]string{"id": "1"})
+
+			handler := http.HandlerFunc(server.GetMembers)
+			handler.ServeHTTP(w, req)
+
+			require.Equal(t, test.expectedStatusCode, w.Code)
+		})
+	}
+}
+
+func TestServer_AddMember(t *testing.T) {
+	memberID := "member"
+	email := "member@example.com"
+
+	testCases := []struct {
+		name               string
+		body               string
+		mockBehavior       func(store *mockdb.MockTodoRepository, fireClient *mock_firestore.MockClient)
+		expectedStatusCode int
+	}{
+		{
+			name: "OK - by user id",
+			body: `{"user_id": "member", "role": "editor"}`,
+			mockBehavior: func(store *mockdb.MockTodoRepository, fireClient *mock_firestore.MockClient) {
+				store.EXPECT().AddMember(gomock.Any(), 1, testUserID, domain.MemberInput{UserID: &memberID, Role: domain.RoleEditor}).Return(domain.Member{ID: 1}, nil)
+			},
+			expectedStatusCode: 201,
+		},
+		{
+			name: "OK - registered email is resolved to user",
+			body: `{"email": "member@example.com", "role": "viewer"}`,
+			mockBehavior: func(store *mockdb.MockTodoRepository, fireClient *mock_firestore.MockClient) {
+				fireClient.EXPECT().GetUserByEmail(email).Return(&auth.UserRecord{UserInfo: &auth.UserInfo{UID: memberID}}, nil)
+				store.EXPECT().AddMember(gomock.Any(), 1, testUserID, domain.MemberInput{UserID: &memberID, Email: &email, Role: domain.RoleViewer}).Return(domain.Member{ID: 1}, nil)
+			},
+			expectedStatusCode: 201,
+		},
+		{
+			name:               "Error - wrong role",
+			body:               `{"user_id": "member", "role": "admin"}`,
+			mockBehavior:       func(store *mockdb.MockTodoRepository, fireClient *mock_firestore.MockClient) {},
+			expectedStatusCode: 422,
+		},
+		{
+			name:               "Error - no user",
+			body:               `{"role": "viewer"}`,
+			mockBehavior:       func(store *mockdb.MockTodoRepository, fireClient *mock_firestore.MockClient) {},
+			expectedStatusCode: 422,
+		},
+		{
+			name:               "Error - wrong email",
+			body:               `{"email": "member", "role": "viewer"}`,
+			mockBehavior:       func(store *mockdb.MockTodoRepository, fireClient *mock_firestore.MockClient) {},
+			expectedStatusCode: 422,
+		},
+		{
+			name:               "Error - share with yourself",
+			body:               `{"user_id": "` + testUserID + `", "role": "viewer"}`,
+			mockBehavior:       func(store *mockdb.MockTodoRepository, fireClient *mock_firestore.MockClient) {},
+			expectedStatusCode: 422,
+		},
+		{
+			name: "Error - firebase error",
+			body: `{"email": "member@example.com", "role": "viewer"}`,
+			mockBehavior: func(store *mockdb.MockTodoRepository, fireClient *mock_firestore.MockClient) {
+				fireClient.EXPECT().GetUserByEmail(email).Return(nil, errors.New("something went wrong"))
+			},
+			expectedStatusCode: 500,
+		},
+		{
+			name: "Error - already shared",
+			body: `{"user_id": "member", "role": "viewer"}`,
+			mockBehavior: func(store *mockdb.MockTodoRepository, fireClient *mock_firestore.MockClient) {
+				store.EXPECT().AddMember(gomock.Any(), 1, testUserID, domain.MemberInput{UserID: &memberID, Role: domain.RoleViewer}).Return(domain.Member{}, domain.ErrMemberAlreadyExists)
+			},
+			expectedStatusCode: 409,
+		},
+		{
+			name: "Error - remind not found",
+			body: `{"user_id": "member", "role": "viewer"}`,
+			mockBehavior: func(store *mockdb.MockTodoRepository, fireClient *mock_firestore.MockClient) {
+				store.EXPECT().AddMember(gomock.Any(), 1, testUserID, domain.MemberInput{UserID: &memberID, Role: domain.RoleViewer}).Return(domain.Member{}, domain.ErrCantFindRemindWithID)
+			},
+			expectedStatusCode: 404,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			todoStore := mockdb.NewMockTodoRepository(c)
+			fireClient := mock_firestore.NewMockClient(c)
+			test.mockBehavior(todoStore, fireClient)
+
+			server := New(context.Background(), logging.GetLogger(), todoStore, nil, nil, nil, nil, fireClient, config.Config{})
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodPost, "/remind/1/members", bytes.NewBufferString(test.body))
+			req = req.WithContext(context.WithValue(req.Context(), "userID", testUserID))
+			req = mux.SetURLVars(req, map[string]string{"id": "1"})
+
+			handler := http.HandlerFunc(server.AddMember)
+			handler.ServeHTTP(w, req)
+
+			require.Equal(t, test.expectedStatusCode, w.Code)
+		})
+	}
+}
+
+func TestServer_UpdateMember(t *testing.T) {
+	notify := false
+
+	testCases := []struct {
+		name               string
+		body               string
+		mockBehavior       func(store *mockdb.MockTodoRepository)
+		expectedStatusCode int
+	}{
+		{
+			name: "OK",
+			body: `{"role": "owner", "notify": false}`,
+			mockBehavior: func(store *mockdb.MockTodoRepository) {
+				store.EXPECT().UpdateMember(gomock.Any(), 1, 2, testUserID, domain.MemberInput{Role: domain.RoleOwner, Notify: &notify}).Return(domain.Member{ID: 2}, nil)
+			},
+			expectedStatusCode: 200,
+		},
+		{
+			name:               "Error - wrong role",
+			body:               `{"role": ""}`,
+			mockBehavior:       func(store *mockdb.MockTodoRepository) {},
+			expectedStatusCode: 422,
+		},
+		{
+			name: "Error - member not found",
+			body: `{"role": "viewer"}`,
+			mockBehavior: func(store *mockdb.MockTodoRepository) {
+				store.EXPECT().UpdateMember(gomock.Any(), 1, 2, testUserID, domain.MemberInput{Role: domain.RoleViewer}).Return(domain.Member{}, domain.ErrCantFindMemberWithID)
+			},
+			expectedStatusCode: 404,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			configStore := mockdb.NewMockConfigRepository(c)
+			todoStore := mockdb.NewMockTodoRepository(c)
+			test.mockBehavior(todoStore)
+
+			server := newTestServer(todoStore, configStore)
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodPut, "/remind/1/members/2", bytes.NewBufferString(test.body))
+			req = req.WithContext(context.WithValue(req.Context(), "userID", testUserID))
+			req = mux.SetURLVars(req, map[string]string{"id": "1", "memberID": "2"})
+
+			handler := http.HandlerFunc(server.UpdateMember)
+			handler.ServeHTTP(w, req)
+
+			require.Equal(t, test.expectedStatusCode, w.Code)
+		})
+	}
+}
+
+func TestServer_DeleteMember(t *testing.T) {
+	testCases := []struct {
+		name               string
+		mockBehavior       func(store *mockdb.MockTodoRepository)
+		expectedStatusCode int
+	}{
+		{
+			name: "OK",
+			mockBehavior: func(store *mockdb.MockTodoRepository) {
+				store.EXPECT().DeleteMember(gomock.Any(), 1, 2, testUserID).Return(nil)
+			},
+			expectedStatusCode: 204,
+		},
+		{
+			name: "Error - member not found",
+			mockBehavior: func(store *mockdb.MockTodoRepository) {
+				store.EXPECT().DeleteMember(gomock.Any(), 1, 2, testUserID).Return(domain.ErrCantFindMemberWithID)
+			},
+			expectedStatusCode: 404,
+		},
+		{
+			name: "Error - internal error",
+			mockBehavior: func(store *mockdb.MockTodoRepository) {
+				store.EXPECT().DeleteMember(gomock.Any(), 1, 2, testUserID).Return(domain.ErrDeleteFailed)
+			},
+			expectedStatusCode: 500,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			configStore := mockdb.NewMockConfigRepository(c)
+			todoStore := mockdb.NewMockTodoRepository(c)
+			test.mockBehavior(todoStore)
+
+			server := newTestServer(todoStore, configStore)
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodDelete, "/remind/1/members/2", http.NoBody)
+			req = req.WithContext(context.WithValue(req.Context(), "userID", testUserID))
+			req = mux.SetURLVars(req, map[string]string{"id": "1", "memberID": "2"})
+
+			handler := http.HandlerFunc(server.DeleteMember)
+			handler.ServeHTTP(w, req)
+
+			require.Equal(t, test.expectedStatusCode, w.Code)
+		})
+	}
+}
@@ -0,0 +1,107 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	model "github.com/red-rocket-software/reminder-go/internal/reminder/domain"
+	"github.com/red-rocket-software/reminder-go/pkg/utils"
+)
+
+// GetTrash return deleted reminds of user
+//
+//	@Description	GetTrash
+//	@Summary		return reminds moved to trash, recently deleted first
+//	@Tags			trash
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{array}		domain.Todo
+//
+//	@Failure		500	{object}	utils.HTTPError
+//
+//	@Router			/trash [get]
+func (server *Server) GetTrash(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+
+	reminds, err := server.TodoStorage.GetTrash(server.ctx, userID)
+	if err != nil {
+		utils.JSONError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	utils.JSONFormat(w, http.StatusOK, reminds)
+}
+
+// RestoreRemind move remind back from trash
+//
+//	@Description	RestoreRemind
+//	@Summary		restore remind from trash
+//	@Tags			trash
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		int		true	"remind id"
+//	@Success		200	{string}	string	"remind restored"
+//
+//	@Failure		400	{object}	utils.HTTPError
+//	@Failure		404	{object}	utils.HTTPError
+//	@Failure		500	{object}	utils.HTTPError
+//
+//	@Router			/trash/{id} [put]
+func (server *Server) RestoreRemind(w http.ResponseWriter, r *http.Request) {
+	rID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.JSONError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	userID := r.Context().Value("userID").(string)
+
+	if err := server.TodoStorage.RestoreRemind(server.ctx, rID, userID); err != nil {
+		trashError(w, err)
+		return
+	}
+
+	utils.JSONFormat(w, http.StatusOK, "remind restored")
+}
+
+// PurgeRemind delete remind from trash permanently
+//
+//	@Description	PurgeRemind
+//	@Summary		delete remind from trash permanently
+//	@Tags			trash
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		int		true	"remind id"
+//	@Success		204	{string}	string	"remind purged"
+//
+//	@Failure		400	{object}	utils.HTTPError
+//	@Failure		404	{object}	utils.HTTPError
+//	@Failure		500	{object}	utils.HTTPError
+//
+//	@Router			/trash/{id} [delete]
+func (server *Server) PurgeRemind(w http.ResponseWriter, r *http.Request) {
+	rID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.JSONError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	userID := r.Context().Value("userID").(string)
+
+	if err := server.TodoStorage.PurgeRemind(server.ctx, rID, userID); err != nil {
+		trashError(w, err)
+		return
+	}
+
+	utils.JSONFormat(w, http.StatusNoContent, "remind purged")
+}
+
+func trashError(w http.ResponseWriter, err error) {
+	if errors.Is(err, model.ErrCantFindRemindWithID) {
+		utils.JSONError(w, http.StatusNotFound, err)
+		return
+	}
+	utils.JSONError(w, http.StatusInternalServerError, err)
+}
@@ -0,0 +1,179 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/red-rocket-software/reminder-go/internal/reminder/domain"
+	mockdb "github.com/red-rocket-software/reminder-go/internal/reminder/domain/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServer_GetTrash(t *testing.T) {
+	deletedAt := time.Now()
+
+	testCases := []struct {
+		name               string
+		mockBehavior       func(store *mockdb.MockTodoRepository)
+		expectedStatusCode int
+	}{
+		{
+			name: "OK",
+			mockBehavior: func(store *mockdb.MockTodoRepository) {
+				store.EXPECT().GetTrash(gomock.Any(), testUserID).Return([]domain.Todo{{ID: 1, DeletedAt: &deletedAt}}, nil)
+			},
+			expectedStatusCode: 200,
+		},
+		{
+			name: "Error - internal error",
+			mockBehavior: func(store *mockdb.MockTodoRepository) {
+				store.EXPECT().GetTrash(gomock.Any(), testUserID).Return(nil, errors.New("something went wrong"))
+			},
+			expectedStatusCode: 500,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			configStore := mockdb.NewMockConfigRepository(c)
+			todoStore := mockdb.NewMockTodoRepository(c)
+			test.mockBehavior(todoStore)
+
+			server := newTestServer(todoStore, configStore)
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodGet, "/trash", http.NoBody)
+			req = req.WithContext(context.WithValue(req.Context(), "userID", testUserID))
+
+			handler := http.HandlerFunc(server.GetTrash)
+			handler.ServeHTTP(w, req)
+
+			require.Equal(t, test.expectedStatusCode, w.Code)
+		})
+	}
+}
+
+func TestServer_RestoreRemind(t *testing.T) {
+	testCases := []struct {
+		name               string
+		id                 string
+		mockBehavior       func(store *mockdb.MockTodoRepository)
+		expectedStatusCode int
+	}{
+		{
+			name: "OK",
+			id:   "1",
+			mockBehavior: func(store *mockdb.MockTodoRepository) {
+				store.EXPECT().RestoreRemind(gomock.Any(), 1, testUserID).Return(nil)
+			},
+			expectedStatusCode: 200,
+		},
+		{
+			name:               "Error - wrong id",
+			id:                 "one",
+			mockBehavior:       func(store *mockdb.MockTodoRepository) {},
+			expectedStatusCode: 400,
+		},
+		{
+			name: "Error - remind isn't in trash",
+			id:   "1",
+			mockBehavior: func(store *mockdb.MockTodoRepository) {
+				store.EXPECT().RestoreRemind(gomock.Any(), 1, testUserID).Return(domain.ErrCantFindRemindWithID)
+			},
+			expectedStatusCode: 404,
+		},
+		{
+			name: "Error - internal error",
+			id:   "1",
+			mockBehavior: func(store *mockdb.MockTodoRepository) {
+				store.EXPECT().RestoreRemind(gomock.Any(), 1, testUserID).Return(errors.New("something went wrong"))
+			},
+			expectedStatusCode: 500,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			configStore := mockdb.NewMockConfigRepository(c)
+			todoStore := mockdb.NewMockTodoRepository(c)
+			test.mockBehavior(todoStore)
+
+			server := newTestServer(todoStore, configStore)
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodPut, "/trash/"+test.id, http.NoBody)
+			req = req.WithContext(context.WithValue(req.Context(), "userID", testUserID))
+			req = mux.SetURLVars(req, map[string]string{"id": test.id})
+
+			handler := http.HandlerFunc(server.RestoreRemind)
+			handler.ServeHTTP(w, req)
+
+			require.Equal(t, test.expectedStatusCode, w.Code)
+		})
+	}
+}
+
+func TestServer_PurgeRemind(t *testing.T) {
+	testCases := []struct {
+		name               string
+		mockBehavior       func(store *mockdb.MockTodoRepository)
+		expectedStatusCode int
+	}{
+		{
+			name: "OK",
+			mockBehavior: func(store *mockdb.MockTodoRepository) {
+				store.EXPECT().PurgeRemind(gomock.Any(), 1, testUserID).Return(nil)
+			},
+			expectedStatusCode: 204,
+		},
+		{
+			name: "Error - remind isn't in trash",
+			mockBehavior: func(store *mockdb.MockTodoRepository) {
+				store.EXPECT().PurgeRemind(gomock.Any(), 1, testUserID).Return(domain.ErrCantFindRemindWithID)
+			},
+			expectedStatusCode: 404,
+		},
+		{
+			name: "Error - internal error",
+			mockBehavior: func(store *mockdb.MockTodoRepository) {
+				store.EXPECT().PurgeRemind(gomock.Any(), 1, testUserID).Return(domain.ErrDeleteFailed)
+			},
+			expectedStatusCode: 500,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			configStore := mockdb.NewMockConfigRepository(c)
+			todoStore := mockdb.NewMockTodoRepository(c)
+			test.mockBehavior(todoStore)
+
+			server := newTestServer(todoStore, configStore)
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodDelete, "/trash/1", http.NoBody)
+			req = req.WithContext(context.WithValue(req.Context(), "userID", testUserID))
+			req = mux.SetURLVars(req, map[string]string{"id": "1"})
+
+			handler := http.HandlerFunc(server.PurgeRemind)
+			handler.ServeHTTP(w, req)
+
+			require.Equal(t, test.expectedStatusCode, w.Code)
+		})
+	}
+}
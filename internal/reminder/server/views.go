@@ -0,0 +1,331 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gorilla/mux"
+	model "github.com/red-rocket-software/reminder-go/internal/reminder/domain"
+	"github.com/red-rocket-software/reminder-go/pkg/utils"
+)
+
+// GetViews return built-in and saved views of the user
+//
+//	@Description	GetViews
+//	@Summary		return built-in views and saved views of user
+//	@Tags			views
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{array}		domain.View
+//
+//	@Failure		500	{object}	utils.HTTPError
+//
+//	@Router			/views [get]
+func (server *Server) GetViews(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("userID").(string)
+
+	views, err := server.ViewStorage.GetViews(server.ctx, userID)
+	if err != nil {
+		utils.JSONError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	utils.JSONFormat(w, http.StatusOK, append(append([]model.View{}, model.BuiltinViews...), views...))
+}
+
+// AddView save a new view
+//
+//	@Description	AddView
+//	@Summary		save a new view
+//	@Tags			views
+//	@Accept			json
+//	@Produce		json
+//	@Param			input	body		domain.ViewInput	true	"view info"
+//	@Success		201		{object}	domain.View
+//
+//	@Failure		422		{object}	utils.HTTPError
+//	@Failure		500		{object}	utils.HTTPError
+//
+//	@Router			/view [post]
+func (server *Server) AddView(w http.ResponseWriter, r *http.Request) {
+	input, err := decodeViewInput(r)
+	if err != nil {
+		utils.JSONError(w, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	userID := r.Context().Value("userID").(string)
+
+	view, err := server.ViewStorage.CreateView(server.ctx, userID, input)
+	if err != nil {
+		viewsError(w, err)
+		return
+	}
+
+	utils.JSONFormat(w, http.StatusCreated, view)
+}
+
+// UpdateView update view name and definition
+//
+//	@Description	UpdateView
+//	@Summary		update saved view, built-in views can't be changed
+//	@Tags			views
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		int					true	"id"
+//	@Param			input	body		domain.ViewInput	true	"view info"
+//	@Success		200		{object}	domain.View
+//
+//	@Failure		400		{object}	utils.HTTPError
+//	@Failure		404		{object}	utils.HTTPError
+//	@Failure		422		{object}	utils.HTTPError
+//	@Failure		500		{object}	utils.HTTPError
+//
+//	@Router			/view/{id} [put]
+func (server *Server) UpdateView(w http.ResponseWriter, r *http.Request) {
+	viewID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.JSONError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	if _, ok := model.BuiltinView(viewID); ok {
+		utils.JSONError(w, http.StatusUnprocessableEntity, model.ErrBuiltinView)
+		return
+	}
+
+	input, err := decodeViewInput(r)
+	if err != nil {
+		utils.JSONError(w, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	userID := r.Context().Value("userID").(string)
+
+	view, err := server.ViewStorage.UpdateView(server.ctx, viewID, userID, input)
+	if err != nil {
+		viewsError(w, err)
+		return
+	}
+
+	utils.JSONFormat(w, http.StatusOK, view)
+}
+
+// DeleteView delete saved view
+//
+//	@Description	DeleteView
+//	@Summary		delete saved view, built-in views can't be deleted
+//	@Tags			views
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		int		true	"id"
+//	@Success		204	{string}	string	"view successfully deleted"
+//
+//	@Failure		400	{object}	utils.HTTPError
+//	@Failure		404	{object}	utils.HTTPError
+//	@Failure		422	{object}	utils.HTTPError
+//	@Failure		500	{object}	utils.HTTPError
+//
+//	@Router			/view/{id} [delete]
+func (server *Server) DeleteView(w http.ResponseWriter, r *http.Request) {
+	viewID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.JSONError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	if _, ok := model.BuiltinView(viewID); ok {
+		utils.JSONError(w, http.StatusUnprocessableEntity, model.ErrBuiltinView)
+		return
+	}
+
+	userID := r.Context().Value("userID").(string)
+
+	if err := server.ViewStorage.DeleteView(server.ctx, viewID, userID); err != nil {
+		viewsError(w, err)
+		return
+	}
+
+	utils.JSONFormat(w, http.StatusNoContent, "view successfully deleted")
+}
+
+// GetViewReminds return reminds of the view
+//
+//	@Description	GetViewReminds
+//	@Summary		return a list of reminds by definition of built-in or saved view
+//	@Tags			views
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		int		true	"id"
+//	@Param			cursor	query		string	false	"cursor"
+//	@Param			tz		query		string	false	"IANA time zone relative ranges are counted in, UTC by default"
+//	@Success		200		{object}	domain.TodoResponse
+//
+//	@Failure		400		{object}	utils.HTTPError
+//	@Failure		404		{object}	utils.HTTPError
+//	@Failure		500		{object}	utils.HTTPError
+//
+//	@Router			/views/{id}/reminds [get]
+func (server *Server) GetViewReminds(w http.ResponseWriter, r *http.Request) {
+	viewID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.JSONError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	cursorStr := r.URL.Query().Get("cursor")
+	cursor, err := strconv.Atoi(cursorStr)
+	if err != nil && cursorStr != "" {
+		utils.JSONError(w, http.StatusBadRequest, errors.New("cursor parameter is invalid"))
+		return
+	}
+
+	location, err := time.LoadLocation(r.URL.Query().Get("tz"))
+	if err != nil {
+		utils.JSONError(w, http.StatusBadRequest, errors.New("tz parameter is invalid, should be IANA time zone"))
+		return
+	}
+
+	userID := r.Context().Value("userID").(string)
+
+	view, ok := model.BuiltinView(viewID)
+	if !ok {
+		if view, err = server.ViewStorage.GetView(server.ctx, viewID, userID); err != nil {
+			viewsError(w, err)
+			return
+		}
+	}
+
+	params, err := viewFetchParams(view.Definition, time.Now().In(location))
+	if err != nil {
+		// definition was valid when it was saved, so it can only fail on stored data
+		utils.JSONError(w, http.StatusInternalServerError, err)
+		return
+	}
+	params.Page.Cursor = cursor
+
+	reminds, count, nextCursor, err := server.TodoStorage.GetReminds(server.ctx, params, userID)
+	if err != nil {
+		utils.JSONError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	res := model.TodoResponse{
+		Todos: reminds,
+		Count: count,
+		PageInfo: utils.PageInfo{
+			Page:       params.Page,
+			NextCursor: nextCursor,
+		},
+	}
+
+	utils.JSONFormat(w, http.StatusOK, res)
+}
+
+// decodeViewInput reads view from request body and checks that it can be opened
+func decodeViewInput(r *http.Request) (model.ViewInput, error) {
+	var input model.ViewInput
+
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		return model.ViewInput{}, err
+	}
+
+	input.Name = strings.TrimSpace(input.Name)
+	if input.Name == "" {
+		return model.ViewInput{}, errors.New("name is empty")
+	}
+
+	input.Definition.Q = strings.TrimSpace(input.Definition.Q)
+	if _, err := viewFetchParams(input.Definition, time.Now()); err != nil {
+		return model.ViewInput{}, err
+	}
+
+	return input, nil
+}
+
+// viewFetchParams checks view definition like parameters of the list of reminds and returns FetchParams
+// of the first page. Relative deadline range is resolved from now
+func viewFetchParams(def model.ViewDefinition, now time.Time) (model.FetchParams, error) {
+	if def.Limit < 0 {
+		return model.FetchParams{}, errors.New("limit is invalid, should be positive integer")
+	}
+
+	limit := def.Limit
+	if limit == 0 {
+		limit = 10
+	}
+
+	if utf8.RuneCountInString(def.Q) > maxSearchLength {
+		return model.FetchParams{}, fmt.Errorf("q is invalid, should be no longer than %d characters", maxSearchLength)
+	}
+
+	// search results are ordered by relevance when sort isn't given
+	if def.Q == "" || def.Filter != "" || def.FilterOption != "" {
+		if def.Filter != "CreatedAt" && def.Filter != "DeadlineAt" && def.Filter != "Priority" {
+			return model.FetchParams{}, errors.New("filter is invalid, should be CreatedAt, DeadlineAt or Priority")
+		}
+		if def.FilterOption != "ASC" && def.FilterOption != "DESC" {
+			return model.FetchParams{}, errors.New("filterOption is invalid, should be ASC or DESC")
+		}
+	}
+
+	if def.FilterParams != "all" && def.FilterParams != "current" && def.FilterParams != "completed" && def.FilterParams != "overdue" {
+		return model.FetchParams{}, errors.New("filterParams is invalid, should be all, current, completed or overdue")
+	}
+
+	if def.TagsMatch != "" && def.TagsMatch != model.TagsMatchAny && def.TagsMatch != model.TagsMatchAll {
+		return model.FetchParams{}, errors.New("tagsMatch is invalid, should be any or all")
+	}
+
+	if def.Project < 0 {
+		return model.FetchParams{}, errors.New("project is invalid, should be project id")
+	}
+
+	var deadline model.TimeRangeFilter
+	if def.Deadline != nil {
+		var from, to time.Time
+		var err error
+
+		if def.Deadline.From != "" {
+			if from, err = model.ResolveRangeBound(def.Deadline.From, now); err != nil {
+				return model.FetchParams{}, err
+			}
+			deadline.StartRange = from.UTC().Format(time.RFC3339)
+		}
+		if def.Deadline.To != "" {
+			if to, err = model.ResolveRangeBound(def.Deadline.To, now); err != nil {
+				return model.FetchParams{}, err
+			}
+			deadline.EndRange = to.UTC().Format(time.RFC3339)
+		}
+		if def.Deadline.From != "" && def.Deadline.To != "" && to.Before(from) {
+			return model.FetchParams{}, errors.New("deadline range is invalid, from should be before to")
+		}
+	}
+
+	return model.FetchParams{
+		Page:          utils.Page{Limit: limit},
+		FilterByDate:  def.Filter,
+		FilterBySort:  def.FilterOption,
+		FilterByQuery: def.FilterParams,
+		Tags:          def.Tags,
+		TagsMatch:     def.TagsMatch,
+		ProjectID:     def.Project,
+		Search:        def.Q,
+		Deadline:      deadline,
+	}, nil
+}
+
+func viewsError(w http.ResponseWriter, err error) {
+	if errors.Is(err, model.ErrCantFindViewWithID) {
+		utils.JSONError(w, http.StatusNotFound, err)
+		return
+	}
+	utils.JSONError(w, http.StatusInternalServerError, err)
+}
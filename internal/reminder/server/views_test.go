@@ -0,0 +1,412 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/red-rocket-software/reminder-go/internal/reminder/domain"
+	mockdb "github.com/red-rocket-software/reminder-go/internal/reminder/domain/mocks"
+	"github.com/red-rocket-software/reminder-go/pkg/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServer_GetViews(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	viewStore := mockdb.NewMockViewRepository(c)
+	viewStore.EXPECT().GetViews(gomock.Any(), testUserID).Return([]domain.View{{ID: 1, UserID: testUserID, Name: "work"}}, nil)
+
+	server := newTestServer(mockdb.NewMockTodoRepository(c), mockdb.NewMockConfigRepository(c))
+	server.ViewStorage = viewStore
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/views", http.NoBody)
+	req = req.WithContext(context.WithValue(req.Context(), "userID", testUserID))
+
+	handler := http.HandlerFunc(server.GetViews)
+	handler.ServeHTTP(w, req)
+
+	require.Equal(t, 200, w.Code)
+
+	var views []domain.View
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &views))
+	require.Len(t, views, len(domain.BuiltinViews)+1)
+	require.Equal(t, "Today", views[0].Name)
+	require.True(t, views[0].Builtin)
+	require.Equal(t, "work", views[len(views)-1].Name)
+}
+
+func TestServer_AddView(t *testing.T) {
+	testCases := []struct {
+		name               string
+		body               string
+		mockBehavior       func(store *mockdb.MockViewRepository)
+		expectedStatusCode int
+	}{
+		{
+			name: "OK",
+			body: `{"name": " work ", "definition": {"filter": "DeadlineAt", "filterOption": "ASC", "filterParams": "current", "tags": [1], "deadline": {"from": "today-1d", "to": "today+14d"}}}`,
+			mockBehavior: func(store *mockdb.MockViewRepository) {
+				store.EXPECT().CreateView(gomock.Any(), testUserID, domain.ViewInput{
+					Name: "work",
+					Definition: domain.ViewDefinition{
+						Filter:       "DeadlineAt",
+						FilterOption: "ASC",
+						FilterParams: "current",
+						Tags:         []int{1},
+						Deadline:     &domain.ViewRange{From: "today-1d", To: "today+14d"},
+					},
+				}).Return(domain.View{ID: 1, Name: "work"}, nil)
+			},
+			expectedStatusCode: 201,
+		},
+		{
+			name: "OK search without sort",
+			body: `{"name": "dentist", "definition": {"filterParams": "all", "q": "dentist"}}`,
+			mockBehavior: func(store *mockdb.MockViewRepository) {
+				store.EXPECT().CreateView(gomock.Any(), testUserID, gomock.Any()).Return(domain.View{ID: 2, Name: "dentist"}, nil)
+			},
+			expectedStatusCode: 201,
+		},
+		{
+			name:               "Error - empty name",
+			body:               `{"name": "", "definition": {"filter": "CreatedAt", "filterOption": "ASC", "filterParams": "all"}}`,
+			mockBehavior:       func(store *mockdb.MockViewRepository) {},
+			expectedStatusCode: 422,
+		},
+		{
+			name:               "Error - wrong filter",
+			body:               `{"name": "work", "definition": {"filter": "Title", "filterOption": "ASC", "filterParams": "all"}}`,
+			mockBehavior:       func(store *mockdb.MockViewRepository) {},
+			expectedStatusCode: 422,
+		},
+		{
+			name:               "Error - wrong filterParams",
+			body:               `{"name": "work", "definition": {"filter": "CreatedAt", "filterOption": "ASC", "filterParams": "some"}}`,
+			mockBehavior:       func(store *mockdb.MockViewRepository) {},
+			expectedStatusCode: 422,
+		},
+		{
+			name:               "Error - wrong range bound",
+			body:               `{"name": "work", "definition": {"filter": "CreatedAt", "filterOption": "ASC", "filterParams": "all", "deadline": {"from": "tomorrow"}}}`,
+			mockBehavior:       func(store *mockdb.MockViewRepository) {},
+			expectedStatusCode: 422,
+		},
+		{
+			name:               "Error - reversed range",
+			body:               `{"name": "work", "definition": {"filter": "CreatedAt", "filterOption": "ASC", "filterParams": "all", "deadline": {"from": "today+1d", "to": "today"}}}`,
+			mockBehavior:       func(store *mockdb.MockViewRepository) {},
+			expectedStatusCode: 422,
+		},
+		{
+			name: "Error - internal error",
+			body: `{"name": "work", "definition": {"filter": "CreatedAt", "filterOption": "ASC", "filterParams": "all"}}`,
+			mockBehavior: func(store *mockdb.MockViewRepository) {
+				store.EXPECT().CreateView(gomock.Any(), testUserID, gomock.Any()).Return(domain.View{}, errors.New("something went wrong"))
+			},
+			expectedStatusCode: 500,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			viewStore := mockdb.NewMockViewRepository(c)
+			test.mockBehavior(viewStore)
+
+			server := newTestServer(mockdb.NewMockTodoRepository(c), mockdb.NewMockConfigRepository(c))
+			server.ViewStorage = viewStore
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodPost, "/view", bytes.NewBufferString(test.body))
+			req = req.WithContext(context.WithValue(req.Context(), "userID", testUserID))
+
+			handler := http.HandlerFunc(server.AddView)
+			handler.ServeHTTP(w, req)
+
+			require.Equal(t, test.expectedStatusCode, w.Code)
+		})
+	}
+}
+
+func TestServer_UpdateView(t *testing.T) {
+	testCases := []struct {
+		name               string
+		id                 string
+		mockBehavior       func(store *mockdb.MockViewRepository)
+		expectedStatusCode int
+	}{
+		{
+			name: "OK",
+			id:   "1",
+			mockBehavior: func(store *mockdb.MockViewRepository) {
+				store.EXPECT().UpdateView(gomock.Any(), 1, testUserID, gomock.Any()).Return(domain.View{ID: 1, Name: "work"}, nil)
+			},
+			expectedStatusCode: 200,
+		},
+		{
+			name: "Error - not found",
+			id:   "1",
+			mockBehavior: func(store *mockdb.MockViewRepository) {
+				store.EXPECT().UpdateView(gomock.Any(), 1, testUserID, gomock.Any()).Return(domain.View{}, domain.ErrCantFindViewWithID)
+			},
+			expectedStatusCode: 404,
+		},
+		{
+			name:               "Error - built-in view",
+			id:                 "-1",
+			mockBehavior:       func(store *mockdb.MockViewRepository) {},
+			expectedStatusCode: 422,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			viewStore := mockdb.NewMockViewRepository(c)
+			test.mockBehavior(viewStore)
+
+			server := newTestServer(mockdb.NewMockTodoRepository(c), mockdb.NewMockConfigRepository(c))
+			server.ViewStorage = viewStore
+
+			body := `{"name": "work", "definition": {"filter": "CreatedAt", "filterOption": "DESC", "filterParams": "all"}}`
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodPut, "/view/"+test.id, bytes.NewBufferString(body))
+			req = req.WithContext(context.WithValue(req.Context(), "userID", testUserID))
+			req = mux.SetURLVars(req, map[string]string{"id": test.id})
+
+			handler := http.HandlerFunc(server.UpdateView)
+			handler.ServeHTTP(w, req)
+
+			require.Equal(t, test.expectedStatusCode, w.Code)
+		})
+	}
+}
+
+func TestServer_DeleteView(t *testing.T) {
+	testCases := []struct {
+		name               string
+		id                 string
+		mockBehavior       func(store *mockdb.MockViewRepository)
+		expectedStatusCode int
+	}{
+		{
+			name: "OK",
+			id:   "1",
+			mockBehavior: func(store *mockdb.MockViewRepository) {
+				store.EXPECT().DeleteView(gomock.Any(), 1, testUserID).Return(nil)
+			},
+			expectedStatusCode: 204,
+		},
+		{
+			name: "Error - not found",
+			id:   "1",
+			mockBehavior: func(store *mockdb.MockViewRepository) {
+				store.EXPECT().DeleteView(gomock.Any(), 1, testUserID).Return(domain.ErrCantFindViewWithID)
+			},
+			expectedStatusCode: 404,
+		},
+		{
+			name:               "Error - built-in view",
+			id:                 "-2",
+			mockBehavior:       func(store *mockdb.MockViewRepository) {},
+			expectedStatusCode: 422,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			viewStore := mockdb.NewMockViewRepository(c)
+			test.mockBehavior(viewStore)
+
+			server := newTestServer(mockdb.NewMockTodoRepository(c), mockdb.NewMockConfigRepository(c))
+			server.ViewStorage = viewStore
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodDelete, "/view/"+test.id, http.NoBody)
+			req = req.WithContext(context.WithValue(req.Context(), "userID", testUserID))
+			req = mux.SetURLVars(req, map[string]string{"id": test.id})
+
+			handler := http.HandlerFunc(server.DeleteView)
+			handler.ServeHTTP(w, req)
+
+			require.Equal(t, test.expectedStatusCode, w.Code)
+		})
+	}
+}
+
+func TestServer_GetViewReminds(t *testing.T) {
+	saved := domain.View{
+		ID:     1,
+		UserID: testUserID,
+		Name:   "work",
+		Definition: domain.ViewDefinition{
+			Limit:        5,
+			Filter:       "Priority",
+			FilterOption: "DESC",
+			FilterParams: "all",
+			Tags:         []int{1, 2},
+			TagsMatch:    domain.TagsMatchAll,
+			Project:      3,
+		},
+	}
+
+	testCases := []struct {
+		name               string
+		id                 string
+		query              string
+		mockBehavior       func(todoStore *mockdb.MockTodoRepository, viewStore *mockdb.MockViewRepository)
+		expectedStatusCode int
+	}{
+		{
+			name:  "OK saved view",
+			id:    "1",
+			query: "?cursor=7",
+			mockBehavior: func(todoStore *mockdb.MockTodoRepository, viewStore *mockdb.MockViewRepository) {
+				viewStore.EXPECT().GetView(gomock.Any(), 1, testUserID).Return(saved, nil)
+				todoStore.EXPECT().GetReminds(gomock.Any(), domain.FetchParams{
+					Page:          utils.Page{Cursor: 7, Limit: 5},
+					FilterByDate:  "Priority",
+					FilterBySort:  "DESC",
+					FilterByQuery: "all",
+					Tags:          []int{1, 2},
+					TagsMatch:     domain.TagsMatchAll,
+					ProjectID:     3,
+				}, testUserID).Return([]domain.Todo{{ID: 1}}, 1, 0, nil)
+			},
+			expectedStatusCode: 200,
+		},
+		{
+			name:  "OK built-in view",
+			id:    "-1",
+			query: "?tz=Europe/Kyiv",
+			mockBehavior: func(todoStore *mockdb.MockTodoRepository, viewStore *mockdb.MockViewRepository) {
+				todoStore.EXPECT().GetReminds(gomock.Any(), gomock.Any(), testUserID).DoAndReturn(func(_ context.Context, params domain.FetchParams, _ string) ([]domain.Todo, int, int, error) {
+					require.Equal(t, "current", params.FilterByQuery)
+					require.NotEmpty(t, params.Deadline.StartRange)
+					require.NotEmpty(t, params.Deadline.EndRange)
+					return []domain.Todo{}, 0, 0, nil
+				})
+			},
+			expectedStatusCode: 200,
+		},
+		{
+			name:               "Error - wrong tz",
+			id:                 "-1",
+			query:              "?tz=Mars/Olympus",
+			mockBehavior:       func(todoStore *mockdb.MockTodoRepository, viewStore *mockdb.MockViewRepository) {},
+			expectedStatusCode: 400,
+		},
+		{
+			name: "Error - not found",
+			id:   "1",
+			mockBehavior: func(todoStore *mockdb.MockTodoRepository, viewStore *mockdb.MockViewRepository) {
+				viewStore.EXPECT().GetView(gomock.Any(), 1, testUserID).Return(domain.View{}, domain.ErrCantFindViewWithID)
+			},
+			expectedStatusCode: 404,
+		},
+		{
+			name: "Error - internal error",
+			id:   "1",
+			mockBehavior: func(todoStore *mockdb.MockTodoRepository, viewStore *mockdb.MockViewRepository) {
+				viewStore.EXPECT().GetView(gomock.Any(), 1, testUserID).Return(saved, nil)
+				todoStore.EXPECT().GetReminds(gomock.Any(), gomock.Any(), testUserID).Return(nil, 0, 0, errors.New("something went wrong"))
+			},
+			expectedStatusCode: 500,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			todoStore := mockdb.NewMockTodoRepository(c)
+			viewStore := mockdb.NewMockViewRepository(c)
+			test.mockBehavior(todoStore, viewStore)
+
+			server := newTestServer(todoStore, mockdb.NewMockConfigRepository(c))
+			server.ViewStorage = viewStore
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodGet, "/views/"+test.id+"/reminds"+test.query, http.NoBody)
+			req = req.WithContext(context.WithValue(req.Context(), "userID", testUserID))
+			req = mux.SetURLVars(req, map[string]string{"id": test.id})
+
+			handler := http.HandlerFunc(server.GetViewReminds)
+			handler.ServeHTTP(w, req)
+
+			require.Equal(t, test.expectedStatusCode, w.Code)
+		})
+	}
+}
+
+func TestViewFetchParams(t *testing.T) {
+	kyiv, err := time.LoadLocation("Europe/Kyiv")
+	require.NoError(t, err)
+
+	// 23:30 in Kyiv is still the previous day in UTC
+	now := time.Date(2023, time.April, 10, 23, 30, 0, 0, kyiv)
+
+	testCases := []struct {
+		name     string
+		viewID   int
+		expected domain.TimeRangeFilter
+	}{
+		{
+			name:     "Today",
+			viewID:   domain.ViewToday,
+			expected: domain.TimeRangeFilter{StartRange: "2023-04-09T21:00:00Z", EndRange: "2023-04-10T21:00:00Z"},
+		},
+		{
+			name:     "Overdue",
+			viewID:   domain.ViewOverdue,
+			expected: domain.TimeRangeFilter{EndRange: "2023-04-10T20:30:00Z"},
+		},
+		{
+			name:     "Upcoming 7 days",
+			viewID:   domain.ViewUpcoming,
+			expected: domain.TimeRangeFilter{StartRange: "2023-04-10T20:30:00Z", EndRange: "2023-04-16T21:00:00Z"},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			view, ok := domain.BuiltinView(test.viewID)
+			require.True(t, ok)
+
+			params, err := viewFetchParams(view.Definition, now)
+			require.NoError(t, err)
+			require.Equal(t, test.expected, params.Deadline)
+			require.Equal(t, 10, params.Page.Limit)
+		})
+	}
+
+	t.Run("absolute bounds", func(t *testing.T) {
+		params, err := viewFetchParams(domain.ViewDefinition{
+			Filter:       "DeadlineAt",
+			FilterOption: "ASC",
+			FilterParams: "all",
+			Deadline:     &domain.ViewRange{From: "2023-04-01T00:00:00+03:00"},
+		}, now)
+		require.NoError(t, err)
+		require.Equal(t, domain.TimeRangeFilter{StartRange: "2023-03-31T21:00:00Z"}, params.Deadline)
+	})
+}
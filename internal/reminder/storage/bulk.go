@@ -0,0 +1,108 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	model "github.com/red-rocket-software/reminder-go/internal/reminder/domain"
+)
+
+// BulkUpdate applies action to each remind in one transaction. Every remind is changed in its own savepoint,
+// so remind which can't be changed is reported as failed and changes of other reminds are committed
+func (s *TodoStorage) BulkUpdate(ctx context.Context, userID string, input model.BulkInput) ([]model.BulkResult, error) {
+	tx, err := s.Postgres.Begin(ctx)
+	if err != nil {
+		s.logger.Errorf("unable to begin transaction: %v", err)
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	ids := uniqueInts(input.IDs)
+	results := make([]model.BulkResult, 0, len(ids))
+
+	for _, id := range ids {
+		savepoint, err := tx.Begin(ctx)
+		if err != nil {
+			s.logger.Errorf("unable to create savepoint: %v", err)
+			return nil, err
+		}
+
+		if err := s.bulkApply(ctx, savepoint, id, userID, input); err != nil {
+			if err := savepoint.Rollback(ctx); err != nil {
+				s.logger.Errorf("unable to rollback to savepoint: %v", err)
+				return nil, err
+			}
+			results = append(results, model.BulkResult{ID: id, Error: err.Error()})
+			continue
+		}
+
+		if err := savepoint.Commit(ctx); err != nil {
+			s.logger.Errorf("unable to release savepoint: %v", err)
+			return nil, err
+		}
+		results = append(results, model.BulkResult{ID: id, Success: true})
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// bulkApply applies bulk action to one remind
+func (s *TodoStorage) bulkApply(ctx context.Context, tx pgx.Tx, id int, userID string, input model.BulkInput) error {
+	switch input.Action {
+	case model.BulkComplete:
+		return s.updateStatus(ctx, tx, id, userID, model.TodoUpdateStatusInput{Completed: true, FinishedAt: input.FinishedAt})
+	case model.BulkReopen:
+		return s.updateStatus(ctx, tx, id, userID, model.TodoUpdateStatusInput{Completed: false})
+	case model.BulkDelete:
+		return s.deleteRemind(ctx, tx, id, userID)
+	case model.BulkMoveDeadline, model.BulkDeadlineNotify:
+	default:
+		return model.ErrInvalidBulkAction
+	}
+
+	var old model.Todo
+
+	row := tx.QueryRow(ctx, `SELECT `+todoColumns+` FROM reminder.todo
+WHERE "ID" = $1 AND "ID" IN (`+remindsWithRole("$2", model.RoleEditor)+`) FOR UPDATE`, id, userID)
+	err := row.Scan(todoFields(&old)...)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return model.ErrCantFindRemindWithID
+	}
+	if err != nil {
+		s.logger.Printf("unable to get remind %v", err)
+		return err
+	}
+
+	updated := old
+
+	if input.Action == model.BulkMoveDeadline {
+		// notify period is moved together with deadline, so offsets are kept
+		updated.DeadlineAt = old.DeadlineAt.AddDate(0, 0, input.Days)
+		updated.NotifyPeriod = make([]time.Time, 0, len(old.NotifyPeriod))
+		for _, period := range old.NotifyPeriod {
+			updated.NotifyPeriod = append(updated.NotifyPeriod, period.AddDate(0, 0, input.Days))
+		}
+	} else {
+		updated.DeadlineNotify = input.DeadlineNotify
+	}
+
+	const sql = `UPDATE reminder.todo SET "DeadlineAt" = $1, "NotifyPeriod" = $2, "DeadlineNotify" = $3, "Version" = "Version" + 1 WHERE "ID" = $4`
+
+	if _, err := tx.Exec(ctx, sql, updated.DeadlineAt, updated.NotifyPeriod, updated.DeadlineNotify, id); err != nil {
+		s.logger.Printf("unable to update remind %v", err)
+		return err
+	}
+
+	if err := recordHistory(ctx, tx, id, old.UserID, userID, model.HistoryUpdate, todoChanges(remindValues(old), remindValues(updated))); err != nil {
+		s.logger.Printf("unable to record remind history %v", err)
+		return err
+	}
+
+	return nil
+}
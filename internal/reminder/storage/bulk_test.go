@@ -0,0 +1,78 @@
+package storage
+
+import (
+	"context"
+	"testing"
+
+	model "github.com/red-rocket-software/reminder-go/internal/reminder/domain"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStorageTodo_BulkUpdate(t *testing.T) {
+	defer func() {
+		err := Truncate()
+		require.NoError(t, err)
+	}()
+
+	expectedTodo, err := SeedTodos()
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	userID := expectedTodo[0].UserID
+
+	t.Run("failed remind doesn't stop others", func(t *testing.T) {
+		results, err := testTodoStorage.BulkUpdate(ctx, userID, model.BulkInput{
+			IDs:    []int{expectedTodo[0].ID, 9999, expectedTodo[1].ID},
+			Action: model.BulkComplete,
+		})
+		require.NoError(t, err)
+		require.Equal(t, []model.BulkResult{
+			{ID: expectedTodo[0].ID, Success: true},
+			{ID: 9999, Error: model.ErrCantFindRemindWithID.Error()},
+			{ID: expectedTodo[1].ID, Success: true},
+		}, results)
+
+		for _, id := range []int{expectedTodo[0].ID, expectedTodo[1].ID} {
+			todo, err := testTodoStorage.GetRemindByID(ctx, id, userID)
+			require.NoError(t, err)
+			require.True(t, todo.Completed)
+		}
+	})
+
+	t.Run("move deadline with notify period", func(t *testing.T) {
+		before, err := testTodoStorage.GetRemindByID(ctx, expectedTodo[2].ID, userID)
+		require.NoError(t, err)
+
+		results, err := testTodoStorage.BulkUpdate(ctx, userID, model.BulkInput{IDs: []int{before.ID}, Action: model.BulkMoveDeadline, Days: 2})
+		require.NoError(t, err)
+		require.True(t, results[0].Success)
+
+		after, err := testTodoStorage.GetRemindByID(ctx, before.ID, userID)
+		require.NoError(t, err)
+		require.Equal(t, before.DeadlineAt.AddDate(0, 0, 2), after.DeadlineAt)
+		require.Len(t, after.NotifyPeriod, len(before.NotifyPeriod))
+		for i := range before.NotifyPeriod {
+			require.Equal(t, before.NotifyPeriod[i].AddDate(0, 0, 2), after.NotifyPeriod[i])
+		}
+	})
+
+	t.Run("reminds of another user", func(t *testing.T) {
+		results, err := testTodoStorage.BulkUpdate(ctx, "another user", model.BulkInput{IDs: []int{expectedTodo[3].ID}, Action: model.BulkDelete})
+		require.NoError(t, err)
+		require.False(t, results[0].Success)
+
+		_, err = testTodoStorage.GetRemindByID(ctx, expectedTodo[3].ID, userID)
+		require.NoError(t, err)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		results, err := testTodoStorage.BulkUpdate(ctx, userID, model.BulkInput{IDs: []int{expectedTodo[3].ID, expectedTodo[4].ID}, Action: model.BulkDelete})
+		require.NoError(t, err)
+		require.True(t, results[0].Success)
+		require.True(t, results[1].Success)
+
+		trash, err := testTodoStorage.GetTrash(ctx, userID)
+		require.NoError(t, err)
+		require.Len(t, trash, 2)
+	})
+}
@@ -0,0 +1,35 @@
+package storage
+
+import (
+	"context"
+
+	model "github.com/red-rocket-software/reminder-go/internal/reminder/domain"
+)
+
+// GetCalendarReminds returns all reminds available to user except reminds of archived projects, by deadline
+func (s *TodoStorage) GetCalendarReminds(ctx context.Context, userID string) ([]model.Todo, error) {
+	reminds := []model.Todo{}
+
+	sql := `SELECT ` + todoColumns + ` FROM reminder.todo
+WHERE "ID" IN (` + remindsWithRole("$1", model.RoleViewer) + `) AND ` + notInArchivedProject(`"ProjectID"`) + `
+ORDER BY "DeadlineAt", "ID"`
+
+	rows, err := s.Postgres.Query(ctx, sql, userID)
+	if err != nil {
+		s.logger.Errorf("error get calendar reminds from db: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var remind model.Todo
+
+		if err := rows.Scan(todoFields(&remind)...); err != nil {
+			s.logger.Errorf("remind doesnt exist: %v", err)
+			return nil, err
+		}
+		reminds = append(reminds, remind)
+	}
+
+	return reminds, rows.Err()
+}
@@ -0,0 +1,65 @@
+package storage
+
+import (
+	"context"
+	"testing"
+
+	model "github.com/red-rocket-software/reminder-go/internal/reminder/domain"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStorageTodo_GetCalendarReminds(t *testing.T) {
+	defer func() {
+		err := Truncate()
+		require.NoError(t, err)
+	}()
+
+	expectedTodo, err := SeedTodos()
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	userID := expectedTodo[0].UserID
+
+	err = testTodoStorage.DeleteRemind(ctx, expectedTodo[0].ID, userID)
+	require.NoError(t, err)
+
+	reminds, err := testTodoStorage.GetCalendarReminds(ctx, userID)
+	require.NoError(t, err)
+	require.Len(t, reminds, len(expectedTodo)-1)
+	for i := 1; i < len(reminds); i++ {
+		require.False(t, reminds[i].DeadlineAt.Before(reminds[i-1].DeadlineAt))
+	}
+
+	reminds, err = testTodoStorage.GetCalendarReminds(ctx, "another user")
+	require.NoError(t, err)
+	require.Empty(t, reminds)
+}
+
+func TestStorage_CalendarToken(t *testing.T) {
+	defer func() {
+		err := Truncate()
+		require.NoError(t, err)
+	}()
+
+	userID, err := SeedUserConfig()
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	tokenHash := "hash"
+
+	err = testConfigStorage.SetCalendarToken(ctx, userID, &tokenHash)
+	require.NoError(t, err)
+
+	got, err := testConfigStorage.GetUserByCalendarToken(ctx, tokenHash)
+	require.NoError(t, err)
+	require.Equal(t, userID, got)
+
+	err = testConfigStorage.SetCalendarToken(ctx, userID, nil)
+	require.NoError(t, err)
+
+	_, err = testConfigStorage.GetUserByCalendarToken(ctx, tokenHash)
+	require.ErrorIs(t, err, model.ErrCantFindUserConfigs)
+
+	err = testConfigStorage.SetCalendarToken(ctx, "another user", &tokenHash)
+	require.ErrorIs(t, err, model.ErrCantFindUserConfigs)
+}
@@ -0,0 +1,116 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	model "github.com/red-rocket-software/reminder-go/internal/reminder/domain"
+)
+
+// RequestErasure schedules erasure of all data of the user, repeated request keeps the first schedule
+func (s *ConfigsStorage) RequestErasure(ctx context.Context, userID string, eraseAt time.Time) (model.Erasure, error) {
+	erasure := model.Erasure{UserID: userID}
+
+	const sql = `UPDATE reminder.users_configs SET "ErasureRequestedAt" = COALESCE("ErasureRequestedAt", $1), "EraseAt" = COALESCE("EraseAt", $2)
+WHERE "ID" = $3 RETURNING "ErasureRequestedAt", "EraseAt"`
+
+	err := s.Postgres.QueryRow(ctx, sql, time.Now(), eraseAt, userID).Scan(&erasure.RequestedAt, &erasure.EraseAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return model.Erasure{}, model.ErrCantFindUserConfigs
+	}
+	if err != nil {
+		s.logger.Errorf("unable to request erasure %v", err)
+		return model.Erasure{}, err
+	}
+
+	return erasure, nil
+}
+
+// CancelErasure cancels scheduled erasure of the user
+func (s *ConfigsStorage) CancelErasure(ctx context.Context, userID string) error {
+	const sql = `UPDATE reminder.users_configs SET "ErasureRequestedAt" = NULL, "EraseAt" = NULL WHERE "ID" = $1 AND "EraseAt" IS NOT NULL`
+
+	ct, err := s.Postgres.Exec(ctx, sql, userID)
+	if err != nil {
+		s.logger.Errorf("unable to cancel erasure %v", err)
+		return err
+	}
+
+	if ct.RowsAffected() == 0 {
+		return model.ErrErasureNotRequested
+	}
+
+	return nil
+}
+
+// GetDueErasures returns ids of users whose erasure is scheduled before given time
+func (s *ConfigsStorage) GetDueErasures(ctx context.Context, before time.Time) ([]string, error) {
+	userIDs := []string{}
+
+	const sql = `SELECT "ID" FROM reminder.users_configs WHERE "EraseAt" <= $1 ORDER BY "EraseAt"`
+
+	rows, err := s.Postgres.Query(ctx, sql, before)
+	if err != nil {
+		s.logger.Errorf("error get due erasures from db: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var userID string
+
+		if err := rows.Scan(&userID); err != nil {
+			s.logger.Errorf("erasure doesn't exist: %v", err)
+			return nil, err
+		}
+		userIDs = append(userIDs, userID)
+	}
+
+	return userIDs, rows.Err()
+}
+
+// EraseUser deletes reminds of the user with their checklists, members and history, memberships of the user
+// in reminds of other users, notifications in the outbox, tags, projects, views and configs in one transaction. History of reminds of other users
+// is kept with ActorErased instead of the user
+func (s *ConfigsStorage) EraseUser(ctx context.Context, userID string) error {
+	tx, err := s.Postgres.Begin(ctx)
+	if err != nil {
+		s.logger.Errorf("unable to begin transaction: %v", err)
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	// reminds reference configs, tags and projects of the user, so they are deleted first.
+	// Checklist items, tags of reminds and members are deleted by ON DELETE CASCADE
+	statements := []string{
+		`DELETE FROM reminder.todo_history WHERE "Owner" = $1`,
+		`UPDATE reminder.todo_history SET "Actor" = '` + model.ActorErased + `' WHERE "Actor" = $1`,
+		`DELETE FROM reminder.todo_members WHERE "User" = $1`,
+		`DELETE FROM reminder.outbox WHERE "UserID" = $1`,
+		`DELETE FROM reminder.todo WHERE "User" = $1`,
+		`DELETE FROM reminder.tags WHERE "User" = $1`,
+		`DELETE FROM reminder.projects WHERE "User" = $1`,
+		`DELETE FROM reminder.views WHERE "User" = $1`,
+	}
+
+	for _, sql := range statements {
+		if _, err := tx.Exec(ctx, sql, userID); err != nil {
+			s.logger.Errorf("unable to erase user data: %v", err)
+			return err
+		}
+	}
+
+	ct, err := tx.Exec(ctx, `DELETE FROM reminder.users_configs WHERE "ID" = $1`, userID)
+	if err != nil {
+		s.logger.Errorf("unable to erase user configs: %v", err)
+		return err
+	}
+
+	if ct.RowsAffected() == 0 {
+		return model.ErrCantFindUserConfigs
+	}
+
+	return tx.Commit(ctx)
+}
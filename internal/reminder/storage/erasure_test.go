@@ -0,0 +1,107 @@
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	model "github.com/red-rocket-software/reminder-go/internal/reminder/domain"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStorageConfigs_Erasure(t *testing.T) {
+	defer func() {
+		err := Truncate()
+		require.NoError(t, err)
+	}()
+
+	ctx := context.Background()
+
+	userID, err := SeedUserConfig()
+	require.NoError(t, err)
+
+	eraseAt := time.Now().AddDate(0, 0, 14).Truncate(time.Second)
+
+	t.Run("request keeps the first schedule", func(t *testing.T) {
+		erasure, err := testConfigStorage.RequestErasure(ctx, userID, eraseAt)
+		require.NoError(t, err)
+		require.Equal(t, userID, erasure.UserID)
+		require.True(t, erasure.EraseAt.Equal(eraseAt))
+
+		erasure, err = testConfigStorage.RequestErasure(ctx, userID, eraseAt.AddDate(0, 0, 1))
+		require.NoError(t, err)
+		require.True(t, erasure.EraseAt.Equal(eraseAt))
+
+		_, err = testConfigStorage.RequestErasure(ctx, "unknown user", eraseAt)
+		require.ErrorIs(t, err, model.ErrCantFindUserConfigs)
+	})
+
+	t.Run("due erasures", func(t *testing.T) {
+		userIDs, err := testConfigStorage.GetDueErasures(ctx, time.Now())
+		require.NoError(t, err)
+		require.Empty(t, userIDs)
+
+		userIDs, err = testConfigStorage.GetDueErasures(ctx, eraseAt)
+		require.NoError(t, err)
+		require.Equal(t, []string{userID}, userIDs)
+	})
+
+	t.Run("cancel", func(t *testing.T) {
+		require.NoError(t, testConfigStorage.CancelErasure(ctx, userID))
+		require.ErrorIs(t, testConfigStorage.CancelErasure(ctx, userID), model.ErrErasureNotRequested)
+
+		userIDs, err := testConfigStorage.GetDueErasures(ctx, eraseAt)
+		require.NoError(t, err)
+		require.Empty(t, userIDs)
+	})
+}
+
+func TestStorageConfigs_EraseUser(t *testing.T) {
+	defer func() {
+		err := Truncate()
+		require.NoError(t, err)
+	}()
+
+	ctx := context.Background()
+
+	expectedTodo, err := SeedTodos()
+	require.NoError(t, err)
+	userID := expectedTodo[0].UserID
+
+	_, err = testTagStorage.CreateTag(ctx, userID, model.TagInput{Name: "home"})
+	require.NoError(t, err)
+	_, err = testProjectStorage.CreateProject(ctx, userID, model.ProjectInput{Name: "work"})
+	require.NoError(t, err)
+
+	// remind of another user is shared with erased user, who changes it
+	const otherUserID = "other user"
+	_, err = pClient.Exec(ctx, `INSERT INTO reminder.users_configs ("ID", "Notification", "Period", "CreatedAt") VALUES ($1, false, 2, now())`, otherUserID)
+	require.NoError(t, err)
+	shared, err := testTodoStorage.CreateRemind(ctx, model.Todo{Title: "shared", Description: "shared", UserID: otherUserID, CreatedAt: time.Now(), DeadlineAt: time.Now()})
+	require.NoError(t, err)
+	_, err = testTodoStorage.AddMember(ctx, shared.ID, otherUserID, model.MemberInput{UserID: &userID, Role: model.RoleEditor})
+	require.NoError(t, err)
+	require.NoError(t, testTodoStorage.UpdateStatus(ctx, shared.ID, userID, model.TodoUpdateStatusInput{Completed: true}))
+
+	require.NoError(t, testConfigStorage.EraseUser(ctx, userID))
+
+	var count int
+	for _, sql := range []string{
+		`SELECT COUNT(*) FROM reminder.todo WHERE "User" = $1`,
+		`SELECT COUNT(*) FROM reminder.tags WHERE "User" = $1`,
+		`SELECT COUNT(*) FROM reminder.projects WHERE "User" = $1`,
+		`SELECT COUNT(*) FROM reminder.todo_members WHERE "User" = $1`,
+		`SELECT COUNT(*) FROM reminder.todo_history WHERE "Owner" = $1 OR "Actor" = $1`,
+		`SELECT COUNT(*) FROM reminder.users_configs WHERE "ID" = $1`,
+	} {
+		require.NoError(t, pClient.QueryRow(ctx, sql, userID).Scan(&count))
+		require.Zero(t, count, sql)
+	}
+
+	// shared remind and its history are kept for its owner
+	history, err := testTodoStorage.GetHistory(ctx, shared.ID, otherUserID)
+	require.NoError(t, err)
+	require.Equal(t, model.ActorErased, history[len(history)-1].Actor)
+
+	require.ErrorIs(t, testConfigStorage.EraseUser(ctx, userID), model.ErrCantFindUserConfigs)
+}
@@ -0,0 +1,143 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	model "github.com/red-rocket-software/reminder-go/internal/reminder/domain"
+)
+
+// ClaimRemindsForEscalation leases overdue reminds of users who enabled notifications for them, reminds leased by
+// other workers are skipped until their lease expires. Escalations made before the current deadline and dismissals
+// made before it are ignored, so moved deadline starts over
+func (s *TodoStorage) ClaimRemindsForEscalation(ctx context.Context, now time.Time, firstDelay, lease time.Duration) ([]model.NotificationRemind, error) {
+	reminds := []model.NotificationRemind{}
+
+	sql := `WITH claimed AS (
+UPDATE reminder.todo SET "EscalationLeasedUntil" = $3
+WHERE "ID" IN (
+	SELECT t."ID" FROM reminder.todo t
+	INNER JOIN reminder.users_configs u on u."ID" = t."User"
+	WHERE t."Completed" = false
+	AND t."DeletedAt" IS NULL
+	AND t."DeadlineAt" <= $1
+	AND (t."OverdueDismissedAt" IS NULL OR t."OverdueDismissedAt" < t."DeadlineAt")
+	AND (t."EscalatedAt" IS NULL OR t."EscalatedAt" < t."DeadlineAt" OR t."NextEscalationAt" <= $2)
+	AND (t."EscalationLeasedUntil" IS NULL OR t."EscalationLeasedUntil" <= $2)
+	AND (u."Notification" = true OR t."DeadlineNotify" = true)
+	AND ` + notInArchivedProject(`t."ProjectID"`) + `
+	FOR UPDATE OF t SKIP LOCKED
+)
+RETURNING "ID"
+)
+SELECT t."ID", t."Description", t."Title", t."DeadlineAt", t."User", t."Priority", ` + notifiedMembers + `,
+CASE WHEN t."EscalatedAt" >= t."DeadlineAt" THEN t."Escalations" ELSE 0 END
+FROM claimed c
+INNER JOIN reminder.todo t ON t."ID" = c."ID"
+ORDER BY t."DeadlineAt", t."ID"`
+
+	rows, err := s.Postgres.Query(ctx, sql, now.Add(-firstDelay).UTC(), now.UTC(), now.Add(lease).UTC())
+	if err != nil {
+		s.logger.Errorf("error to select reminds for escalation: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var remind model.NotificationRemind
+
+		if err := rows.Scan(
+			&remind.ID,
+			&remind.Description,
+			&remind.Title,
+			&remind.DeadlineAt,
+			&remind.UserID,
+			&remind.Priority,
+			&remind.MemberIDs,
+			&remind.Escalations,
+		); err != nil {
+			s.logger.Errorf("remind doesn't exist: %v", err)
+			return nil, err
+		}
+		reminds = append(reminds, remind)
+	}
+
+	return reminds, rows.Err()
+}
+
+// UpdateEscalation stores escalation state of remind, releases its lease and adds deliveries of the overdue
+// notification to the outbox in one transaction. It isn't recorded in history like other notifications because
+// it changes only state of notifier
+func (s *TodoStorage) UpdateEscalation(ctx context.Context, id int, escalations int, escalatedAt, nextAt time.Time, outbox []model.OutboxMessage) error {
+	tx, err := s.Postgres.Begin(ctx)
+	if err != nil {
+		s.logger.Errorf("unable to begin transaction: %v", err)
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	const sql = `UPDATE reminder.todo SET "Escalations" = $1, "EscalatedAt" = $2, "NextEscalationAt" = $3, "EscalationLeasedUntil" = NULL WHERE "ID" = $4`
+
+	ct, err := tx.Exec(ctx, sql, escalations, escalatedAt.UTC(), nextAt.UTC(), id)
+	if err != nil {
+		s.logger.Printf("unable to update remind escalation %v", err)
+		return err
+	}
+
+	if ct.RowsAffected() == 0 {
+		return model.ErrCantFindRemindWithID
+	}
+
+	if err := s.addToOutbox(ctx, tx, outbox, escalatedAt); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// DismissOverdue stops overdue notifications of remind until its deadline is moved
+func (s *TodoStorage) DismissOverdue(ctx context.Context, id int, userID string) error {
+	tx, err := s.Postgres.Begin(ctx)
+	if err != nil {
+		s.logger.Errorf("unable to begin transaction: %v", err)
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	var todo model.Todo
+
+	row := tx.QueryRow(ctx, `SELECT `+todoColumns+` FROM reminder.todo
+WHERE "ID" = $1 AND "ID" IN (`+remindsWithRole("$2", model.RoleEditor)+`) FOR UPDATE`, id, userID)
+	err = row.Scan(todoFields(&todo)...)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return model.ErrCantFindRemindWithID
+	}
+	if err != nil {
+		s.logger.Printf("unable to get remind %v", err)
+		return err
+	}
+
+	now := time.Now().UTC()
+	if !todo.IsOverdue(now) {
+		return model.ErrRemindNotOverdue
+	}
+
+	if _, err := tx.Exec(ctx, `UPDATE reminder.todo SET "OverdueDismissedAt" = $1 WHERE "ID" = $2`, now, id); err != nil {
+		s.logger.Printf("unable to dismiss overdue remind %v", err)
+		return err
+	}
+
+	var dismissedAt any
+	if todo.OverdueDismissedAt != nil {
+		dismissedAt = formatTime(*todo.OverdueDismissedAt)
+	}
+	changes := todoChanges(map[string]any{"overdue_dismissed_at": dismissedAt}, map[string]any{"overdue_dismissed_at": formatTime(now)})
+	if err := recordHistory(ctx, tx, id, todo.UserID, userID, model.HistoryUpdate, changes); err != nil {
+		s.logger.Printf("unable to record remind history %v", err)
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
@@ -0,0 +1,111 @@
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	model "github.com/red-rocket-software/reminder-go/internal/reminder/domain"
+	"github.com/red-rocket-software/reminder-go/pkg/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStorageTodo_Escalation(t *testing.T) {
+	defer func() {
+		err := Truncate()
+		require.NoError(t, err)
+	}()
+
+	// all seeded reminds are overdue, the second one is completed
+	todos, err := SeedTodosForDeadline()
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	userID := todos[0].UserID
+	now := time.Now()
+
+	escalated := func(reminds []model.NotificationRemind, id int) (model.NotificationRemind, bool) {
+		for _, remind := range reminds {
+			if remind.ID == id {
+				return remind, true
+			}
+		}
+		return model.NotificationRemind{}, false
+	}
+
+	reminds, err := testTodoStorage.ClaimRemindsForEscalation(ctx, now, time.Hour, 0)
+	require.NoError(t, err)
+	require.Len(t, reminds, 4)
+	_, ok := escalated(reminds, todos[1].ID)
+	require.False(t, ok)
+
+	first, ok := escalated(reminds, todos[0].ID)
+	require.True(t, ok)
+	require.Equal(t, 0, first.Escalations)
+
+	t.Run("next escalation is waited for", func(t *testing.T) {
+		require.NoError(t, testTodoStorage.UpdateEscalation(ctx, todos[0].ID, 1, now, now.Add(24*time.Hour), nil))
+
+		reminds, err := testTodoStorage.ClaimRemindsForEscalation(ctx, now, time.Hour, 0)
+		require.NoError(t, err)
+		_, ok := escalated(reminds, todos[0].ID)
+		require.False(t, ok)
+
+		reminds, err = testTodoStorage.ClaimRemindsForEscalation(ctx, now.Add(25*time.Hour), time.Hour, 0)
+		require.NoError(t, err)
+		remind, ok := escalated(reminds, todos[0].ID)
+		require.True(t, ok)
+		require.Equal(t, 1, remind.Escalations)
+	})
+
+	t.Run("overdue state", func(t *testing.T) {
+		remind, err := testTodoStorage.GetRemindByID(ctx, todos[2].ID, userID)
+		require.NoError(t, err)
+		require.True(t, remind.Overdue)
+
+		remind, err = testTodoStorage.GetRemindByID(ctx, todos[1].ID, userID)
+		require.NoError(t, err)
+		require.False(t, remind.Overdue)
+
+		_, count, _, err := testTodoStorage.GetReminds(ctx, model.FetchParams{
+			Page:          utils.Page{Limit: 10},
+			FilterByDate:  "DeadlineAt",
+			FilterBySort:  "ASC",
+			FilterByQuery: "overdue",
+		}, userID)
+		require.NoError(t, err)
+		require.Equal(t, 4, count)
+	})
+
+	t.Run("dismiss", func(t *testing.T) {
+		require.ErrorIs(t, testTodoStorage.DismissOverdue(ctx, todos[1].ID, userID), model.ErrRemindNotOverdue)
+		require.ErrorIs(t, testTodoStorage.DismissOverdue(ctx, todos[2].ID, "another user"), model.ErrCantFindRemindWithID)
+
+		require.NoError(t, testTodoStorage.DismissOverdue(ctx, todos[2].ID, userID))
+
+		reminds, err := testTodoStorage.ClaimRemindsForEscalation(ctx, now.Add(26*time.Hour), time.Hour, 0)
+		require.NoError(t, err)
+		_, ok := escalated(reminds, todos[2].ID)
+		require.False(t, ok)
+
+		remind, err := testTodoStorage.GetRemindByID(ctx, todos[2].ID, userID)
+		require.NoError(t, err)
+		require.NotNil(t, remind.OverdueDismissedAt)
+	})
+
+	t.Run("leased reminds are skipped until lease expires", func(t *testing.T) {
+		claimedAt := now.Add(30 * time.Hour)
+
+		reminds, err := testTodoStorage.ClaimRemindsForEscalation(ctx, claimedAt, time.Hour, time.Hour)
+		require.NoError(t, err)
+		require.Len(t, reminds, 3)
+
+		reminds, err = testTodoStorage.ClaimRemindsForEscalation(ctx, claimedAt, time.Hour, time.Hour)
+		require.NoError(t, err)
+		require.Empty(t, reminds)
+
+		reminds, err = testTodoStorage.ClaimRemindsForEscalation(ctx, claimedAt.Add(2*time.Hour), time.Hour, time.Hour)
+		require.NoError(t, err)
+		require.Len(t, reminds, 3)
+	})
+}
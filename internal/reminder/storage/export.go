@@ -0,0 +1,72 @@
+package storage
+
+import (
+	"context"
+
+	model "github.com/red-rocket-software/reminder-go/internal/reminder/domain"
+)
+
+// ExportReminds calls fn for each remind owned by user, including completed and trashed ones, ordered by id.
+// Rows are scanned while they are read from database, so fn can write them out without keeping all reminds in memory
+func (s *TodoStorage) ExportReminds(ctx context.Context, userID string, fn func(model.Todo) error) error {
+	const sql = `SELECT ` + todoColumns + `, ` + progressColumns + `,
+ARRAY(SELECT "TagID" FROM reminder.todo_tags WHERE "TodoID" = todo."ID" ORDER BY "TagID")
+FROM reminder.todo WHERE "User" = $1 ORDER BY "ID"`
+
+	rows, err := s.Postgres.Query(ctx, sql, userID)
+	if err != nil {
+		s.logger.Errorf("error export reminds from db: %v", err)
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var remind model.Todo
+		var tagIDs []int
+
+		fields := append(todoFields(&remind), progressFields(&remind)...)
+		if err := rows.Scan(append(fields, &tagIDs)...); err != nil {
+			s.logger.Errorf("remind doesn't exist: %v", err)
+			return err
+		}
+
+		remind.Tags = make([]model.Tag, len(tagIDs))
+		for i, id := range tagIDs {
+			remind.Tags[i] = model.Tag{ID: id}
+		}
+
+		if err := fn(remind); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+// ExportItems calls fn for each checklist item of reminds owned by user, ordered by remind and position
+func (s *TodoStorage) ExportItems(ctx context.Context, userID string, fn func(model.TodoItem) error) error {
+	const sql = `SELECT ` + todoItemColumns + ` FROM reminder.todo_items
+WHERE "TodoID" IN (SELECT "ID" FROM reminder.todo WHERE "User" = $1) ORDER BY "TodoID", "Position", "ID"`
+
+	rows, err := s.Postgres.Query(ctx, sql, userID)
+	if err != nil {
+		s.logger.Errorf("error export checklist items from db: %v", err)
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var item model.TodoItem
+
+		if err := rows.Scan(todoItemFields(&item)...); err != nil {
+			s.logger.Errorf("checklist item doesn't exist: %v", err)
+			return err
+		}
+
+		if err := fn(item); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
@@ -0,0 +1,73 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	model "github.com/red-rocket-software/reminder-go/internal/reminder/domain"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStorageTodo_Export(t *testing.T) {
+	defer func() {
+		err := Truncate()
+		require.NoError(t, err)
+	}()
+
+	expectedTodo, err := SeedTodos()
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	userID := expectedTodo[0].UserID
+
+	// trashed reminds are exported too
+	require.NoError(t, testTodoStorage.DeleteRemind(ctx, expectedTodo[4].ID, userID))
+
+	item, err := testTodoStorage.CreateItem(ctx, expectedTodo[0].ID, userID, model.TodoItemInput{Title: "milk"})
+	require.NoError(t, err)
+
+	t.Run("reminds", func(t *testing.T) {
+		var ids []int
+		err := testTodoStorage.ExportReminds(ctx, userID, func(remind model.Todo) error {
+			ids = append(ids, remind.ID)
+			if remind.ID == expectedTodo[0].ID {
+				require.Equal(t, model.Progress{Total: 1}, remind.Progress)
+			}
+			if remind.ID == expectedTodo[4].ID {
+				require.NotNil(t, remind.DeletedAt)
+			}
+			return nil
+		})
+		require.NoError(t, err)
+		require.Len(t, ids, len(expectedTodo))
+	})
+
+	t.Run("items", func(t *testing.T) {
+		var items []model.TodoItem
+		err := testTodoStorage.ExportItems(ctx, userID, func(item model.TodoItem) error {
+			items = append(items, item)
+			return nil
+		})
+		require.NoError(t, err)
+		require.Equal(t, []model.TodoItem{item}, items)
+	})
+
+	t.Run("error of callback stops export", func(t *testing.T) {
+		stop := errors.New("stop")
+		calls := 0
+		err := testTodoStorage.ExportReminds(ctx, userID, func(remind model.Todo) error {
+			calls++
+			return stop
+		})
+		require.ErrorIs(t, err, stop)
+		require.Equal(t, 1, calls)
+	})
+
+	t.Run("reminds of another user", func(t *testing.T) {
+		err := testTodoStorage.ExportReminds(ctx, "another user", func(remind model.Todo) error {
+			return errors.New("remind of another user is exported")
+		})
+		require.NoError(t, err)
+	})
+}
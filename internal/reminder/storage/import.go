@@ -0,0 +1,60 @@
+package storage
+
+import (
+	"context"
+
+	model "github.com/red-rocket-software/reminder-go/internal/reminder/domain"
+)
+
+// ImportReminds creates reminds in one transaction. Every remind is created in its own savepoint,
+// so remind with unknown project or tag is reported as failed and others are imported.
+// Dry run creates reminds the same way and rolls the transaction back, ids of reminds aren't returned then
+func (s *TodoStorage) ImportReminds(ctx context.Context, reminds []model.Todo, dryRun bool) ([]model.BulkResult, error) {
+	tx, err := s.Postgres.Begin(ctx)
+	if err != nil {
+		s.logger.Errorf("unable to begin transaction: %v", err)
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	results := make([]model.BulkResult, 0, len(reminds))
+
+	for _, remind := range reminds {
+		savepoint, err := tx.Begin(ctx)
+		if err != nil {
+			s.logger.Errorf("unable to create savepoint: %v", err)
+			return nil, err
+		}
+
+		created, err := s.createRemind(ctx, savepoint, remind)
+		if err != nil {
+			if err := savepoint.Rollback(ctx); err != nil {
+				s.logger.Errorf("unable to rollback to savepoint: %v", err)
+				return nil, err
+			}
+			results = append(results, model.BulkResult{Error: err.Error()})
+			continue
+		}
+
+		if err := savepoint.Commit(ctx); err != nil {
+			s.logger.Errorf("unable to release savepoint: %v", err)
+			return nil, err
+		}
+
+		result := model.BulkResult{ID: created.ID, Success: true}
+		if dryRun {
+			result.ID = 0
+		}
+		results = append(results, result)
+	}
+
+	if dryRun {
+		return results, tx.Rollback(ctx)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
@@ -0,0 +1,58 @@
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	model "github.com/red-rocket-software/reminder-go/internal/reminder/domain"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStorageTodo_ImportReminds(t *testing.T) {
+	defer func() {
+		err := Truncate()
+		require.NoError(t, err)
+	}()
+
+	ctx := context.Background()
+	userID := "import user"
+	deadline := time.Now().Add(24 * time.Hour).Truncate(time.Minute)
+	unknownProject := 9999
+
+	reminds := []model.Todo{
+		{Title: "first", Description: "imported", UserID: userID, CreatedAt: time.Now().Truncate(time.Second), DeadlineAt: deadline},
+		{Title: "second", Description: "unknown project", UserID: userID, CreatedAt: time.Now().Truncate(time.Second), DeadlineAt: deadline, ProjectID: &unknownProject},
+	}
+
+	t.Run("dry run creates nothing", func(t *testing.T) {
+		results, err := testTodoStorage.ImportReminds(ctx, reminds, true)
+		require.NoError(t, err)
+		require.Equal(t, []model.BulkResult{
+			{Success: true},
+			{Error: model.ErrCantFindProjectWithID.Error()},
+		}, results)
+
+		calendar, err := testTodoStorage.GetCalendarReminds(ctx, userID)
+		require.NoError(t, err)
+		require.Empty(t, calendar)
+	})
+
+	t.Run("failed remind doesn't stop others", func(t *testing.T) {
+		results, err := testTodoStorage.ImportReminds(ctx, reminds, false)
+		require.NoError(t, err)
+		require.Len(t, results, 2)
+		require.True(t, results[0].Success)
+		require.NotZero(t, results[0].ID)
+		require.Equal(t, model.ErrCantFindProjectWithID.Error(), results[1].Error)
+
+		todo, err := testTodoStorage.GetRemindByID(ctx, results[0].ID, userID)
+		require.NoError(t, err)
+		require.Equal(t, "first", todo.Title)
+
+		history, err := testTodoStorage.GetHistory(ctx, todo.ID, userID)
+		require.NoError(t, err)
+		require.Len(t, history, 1)
+		require.Equal(t, model.HistoryCreate, history[0].Action)
+	})
+}
@@ -0,0 +1,293 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	model "github.com/red-rocket-software/reminder-go/internal/reminder/domain"
+)
+
+// scheduledNotifications selects current schedule of notifications of reminds which aren't completed or in trash,
+// $1 is the current time. Notification before the deadline isn't scheduled after the deadline has passed because
+// overdue notifications are sent instead. Zero notify periods are placeholders of reminds created before they were
+// dropped from input and are skipped
+const scheduledNotifications = `SELECT t."ID" AS "TodoID", '` + model.NotificationPeriod + `' AS "Kind", t."DeadlineAt" - u."Period" * interval '1 day' AS "DueAt"
+FROM reminder.todo t
+INNER JOIN reminder.users_configs u ON u."ID" = t."User"
+WHERE t."Completed" = false
+AND t."DeletedAt" IS NULL
+AND u."Notification" = true
+AND u."Period" > 0
+AND t."DeadlineAt" > $1
+UNION ALL
+SELECT t."ID", '` + model.NotificationDeadline + `', p
+FROM reminder.todo t, unnest(t."NotifyPeriod") p
+WHERE t."Completed" = false
+AND t."DeletedAt" IS NULL
+AND t."DeadlineNotify" = true
+AND p > '0001-01-01'`
+
+// notificationColumns returns columns of notification with given alias and its remind with alias t,
+// they are scanned by notificationFields
+func notificationColumns(alias string) string {
+	return alias + `."ID", ` + alias + `."Kind", ` + alias + `."DueAt", ` + alias + `."SentAt", COALESCE(` + alias + `."LeasedBy", ''), ` +
+		alias + `."LeasedUntil", ` + alias + `."Attempts", COALESCE(` + alias + `."LastError", ''), ` + alias + `."NextAttemptAt", ` + alias + `."DeadAt",
+t."ID", t."Description", t."Title", t."DeadlineAt", t."User", t."Priority", ` + notifiedMembers
+}
+
+func notificationFields(n *model.Notification) []any {
+	return []any{
+		&n.ID,
+		&n.Kind,
+		&n.DueAt,
+		&n.SentAt,
+		&n.LeasedBy,
+		&n.LeasedUntil,
+		&n.Attempts,
+		&n.LastError,
+		&n.NextAttemptAt,
+		&n.DeadAt,
+		&n.Remind.ID,
+		&n.Remind.Description,
+		&n.Remind.Title,
+		&n.Remind.DeadlineAt,
+		&n.Remind.UserID,
+		&n.Remind.Priority,
+		&n.Remind.MemberIDs,
+	}
+}
+
+// scheduleLockKey is a key of advisory lock which is held by worker scheduling notifications
+const scheduleLockKey = 1903202301
+
+// ScheduleNotifications adds scheduled notifications which aren't in the queue yet. Sent notifications are kept,
+// so the same notification isn't sent twice, and not sent ones which aren't scheduled anymore are removed.
+// Only one worker schedules notifications at a time, the others skip it
+func (s *TodoStorage) ScheduleNotifications(ctx context.Context, now time.Time) error {
+	tx, err := s.Postgres.Begin(ctx)
+	if err != nil {
+		s.logger.Errorf("unable to begin transaction: %v", err)
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	var locked bool
+	if err := tx.QueryRow(ctx, `SELECT pg_try_advisory_xact_lock($1)`, scheduleLockKey).Scan(&locked); err != nil {
+		s.logger.Errorf("error to lock notifications schedule: %v", err)
+		return err
+	}
+	if !locked {
+		return nil
+	}
+
+	sql := `INSERT INTO reminder.notifications ("TodoID", "Kind", "DueAt")
+SELECT s."TodoID", s."Kind", s."DueAt" FROM (` + scheduledNotifications + `) s
+ON CONFLICT DO NOTHING`
+
+	if _, err := tx.Exec(ctx, sql, now.UTC()); err != nil {
+		s.logger.Errorf("error to schedule notifications: %v", err)
+		return err
+	}
+
+	sql = `DELETE FROM reminder.notifications
+WHERE "SentAt" IS NULL
+AND ("TodoID", "Kind", "DueAt") NOT IN (SELECT s."TodoID", s."Kind", s."DueAt" FROM (` + scheduledNotifications + `) s)`
+
+	if _, err := tx.Exec(ctx, sql, now.UTC()); err != nil {
+		s.logger.Errorf("error to remove unscheduled notifications: %v", err)
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// ClaimDueNotifications leases due notifications to the worker. Notifications locked by other workers are skipped,
+// and the ones whose lease has expired are claimed again. Failed notifications are claimed when their next attempt
+// is due and dead ones aren't claimed. Notifications of reminds in archived projects are kept
+// in the queue until the project is restored
+func (s *TodoStorage) ClaimDueNotifications(ctx context.Context, workerID string, now time.Time, lease time.Duration, limit int) ([]model.Notification, error) {
+	notifications := []model.Notification{}
+
+	sql := `WITH claimed AS (
+UPDATE reminder.notifications SET "LeasedBy" = $2, "LeasedUntil" = $3
+WHERE "ID" IN (
+	SELECT n."ID" FROM reminder.notifications n
+	INNER JOIN reminder.todo t ON t."ID" = n."TodoID"
+	WHERE n."SentAt" IS NULL
+	AND n."DueAt" <= $1
+	AND (n."LeasedUntil" IS NULL OR n."LeasedUntil" <= $1)
+	AND (n."NextAttemptAt" IS NULL OR n."NextAttemptAt" <= $1)
+	AND n."DeadAt" IS NULL
+	AND t."Completed" = false
+	AND t."DeletedAt" IS NULL
+	AND ` + notInArchivedProject(`t."ProjectID"`) + `
+	ORDER BY n."DueAt", n."ID"
+	LIMIT $4
+	FOR UPDATE OF n SKIP LOCKED
+)
+RETURNING *
+)
+SELECT ` + notificationColumns("c") + `
+FROM claimed c
+INNER JOIN reminder.todo t ON t."ID" = c."TodoID"
+ORDER BY c."DueAt", c."ID"`
+
+	rows, err := s.Postgres.Query(ctx, sql, now.UTC(), workerID, now.Add(lease).UTC(), limit)
+	if err != nil {
+		s.logger.Errorf("error to claim due notifications: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var n model.Notification
+
+		if err := rows.Scan(notificationFields(&n)...); err != nil {
+			s.logger.Errorf("notification doesn't exist: %v", err)
+			return nil, err
+		}
+		notifications = append(notifications, n)
+	}
+
+	return notifications, rows.Err()
+}
+
+// MarkNotificationSent marks notification sent and adds its deliveries to the outbox in one transaction, so
+// the notification is delivered once it is marked and isn't sent again after a crash
+func (s *TodoStorage) MarkNotificationSent(ctx context.Context, id int64, workerID string, sentAt time.Time, outbox []model.OutboxMessage) error {
+	tx, err := s.Postgres.Begin(ctx)
+	if err != nil {
+		s.logger.Errorf("unable to begin transaction: %v", err)
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	const sql = `UPDATE reminder.notifications n SET "SentAt" = $1, "Attempts" = n."Attempts" + 1, "LeasedUntil" = NULL, "NextAttemptAt" = NULL
+FROM reminder.todo t
+WHERE n."ID" = $2 AND n."LeasedBy" = $3 AND n."SentAt" IS NULL AND t."ID" = n."TodoID"
+RETURNING n."Kind", t."ID", t."User"`
+
+	var (
+		kind   string
+		todoID int
+		userID string
+	)
+
+	err = tx.QueryRow(ctx, sql, sentAt.UTC(), id, workerID).Scan(&kind, &todoID, &userID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return model.ErrNotificationNotLeased
+	}
+	if err != nil {
+		s.logger.Printf("unable to mark notification sent %v", err)
+		return err
+	}
+
+	if err := s.addToOutbox(ctx, tx, outbox, sentAt); err != nil {
+		return err
+	}
+
+	if kind == model.NotificationPeriod {
+		if err := s.updateNotificated(ctx, tx, todoID, userID, true); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (s *TodoStorage) MarkNotificationFailed(ctx context.Context, id int64, workerID string, failedAt time.Time, lastError string, retryAt *time.Time) error {
+	const sql = `UPDATE reminder.notifications SET "Attempts" = "Attempts" + 1, "LastError" = $1, "NextAttemptAt" = $2,
+"DeadAt" = CASE WHEN $2::timestamp IS NULL THEN $3::timestamp END, "LeasedUntil" = NULL
+WHERE "ID" = $4 AND "LeasedBy" = $5 AND "SentAt" IS NULL`
+
+	var next *time.Time
+	if retryAt != nil {
+		t := retryAt.UTC()
+		next = &t
+	}
+
+	ct, err := s.Postgres.Exec(ctx, sql, lastError, next, failedAt.UTC(), id, workerID)
+	if err != nil {
+		s.logger.Printf("unable to mark notification failed %v", err)
+		return err
+	}
+
+	if ct.RowsAffected() == 0 {
+		return model.ErrNotificationNotLeased
+	}
+
+	return nil
+}
+
+func (s *TodoStorage) GetDeadNotifications(ctx context.Context, limit int) ([]model.Notification, error) {
+	notifications := []model.Notification{}
+
+	sql := `SELECT ` + notificationColumns("n") + `
+FROM reminder.notifications n
+INNER JOIN reminder.todo t ON t."ID" = n."TodoID"
+WHERE n."DeadAt" IS NOT NULL AND n."SentAt" IS NULL
+ORDER BY n."DeadAt" DESC, n."ID" DESC
+LIMIT $1`
+
+	rows, err := s.Postgres.Query(ctx, sql, limit)
+	if err != nil {
+		s.logger.Errorf("error to select dead notifications: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var n model.Notification
+
+		if err := rows.Scan(notificationFields(&n)...); err != nil {
+			s.logger.Errorf("notification doesn't exist: %v", err)
+			return nil, err
+		}
+		notifications = append(notifications, n)
+	}
+
+	return notifications, rows.Err()
+}
+
+// RetryNotification makes notification due again and releases its lease, number of attempts is kept, so failed
+// dead notification is dead again after one more attempt
+func (s *TodoStorage) RetryNotification(ctx context.Context, id int64, now time.Time) error {
+	const sql = `UPDATE reminder.notifications SET "DeadAt" = NULL, "NextAttemptAt" = $1, "LeasedUntil" = NULL
+WHERE "ID" = $2 AND "SentAt" IS NULL`
+
+	ct, err := s.Postgres.Exec(ctx, sql, now.UTC(), id)
+	if err != nil {
+		s.logger.Printf("unable to retry notification %v", err)
+		return err
+	}
+
+	if ct.RowsAffected() == 0 {
+		return model.ErrCantFindNotificationWithID
+	}
+
+	return nil
+}
+
+// NextNotificationAt returns the nearest time when not sent notification or outbox message can be claimed: when
+// it is due, its lease has expired or its next attempt is due. Dead ones aren't claimed
+func (s *TodoStorage) NextNotificationAt(ctx context.Context, now time.Time) (*time.Time, error) {
+	const sql = `SELECT min(a) FROM (
+	SELECT GREATEST("DueAt", "LeasedUntil", "NextAttemptAt") a FROM reminder.notifications
+	WHERE "SentAt" IS NULL AND "DeadAt" IS NULL
+	UNION ALL
+	SELECT GREATEST("CreatedAt", "LeasedUntil", "NextAttemptAt") FROM reminder.outbox
+	WHERE "SentAt" IS NULL AND "DeadAt" IS NULL
+) s
+WHERE a > $1`
+
+	var next *time.Time
+	err := s.Postgres.QueryRow(ctx, sql, now.UTC()).Scan(&next)
+	if err != nil {
+		s.logger.Errorf("error to select next notification: %v", err)
+		return nil, err
+	}
+
+	return next, nil
+}
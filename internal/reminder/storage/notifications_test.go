@@ -0,0 +1,265 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/red-rocket-software/reminder-go/config"
+	model "github.com/red-rocket-software/reminder-go/internal/reminder/domain"
+	"github.com/red-rocket-software/reminder-go/internal/reminder/server"
+	"github.com/red-rocket-software/reminder-go/pkg/logging"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStorageTodo_NotificationQueue(t *testing.T) {
+	defer func() {
+		err := Truncate()
+		require.NoError(t, err)
+	}()
+
+	// the first seeded remind has deadline notification due now, deadlines of all of them have passed
+	todos, err := SeedTodosForDeadline()
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	now := time.Now().UTC()
+	notify := true
+	deadline := now.Add(72 * time.Hour).Truncate(time.Minute)
+	notifyAt := now.Add(2 * time.Hour).Truncate(time.Minute)
+
+	// remind with deadline in 3 days, user is notified 2 days before it
+	remind, err := testTodoStorage.CreateRemind(ctx, model.Todo{
+		Title:          "upcoming",
+		Description:    "upcoming",
+		UserID:         todos[0].UserID,
+		CreatedAt:      now,
+		DeadlineAt:     deadline,
+		DeadlineNotify: &notify,
+		NotifyPeriod:   []time.Time{notifyAt},
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, testTodoStorage.ScheduleNotifications(ctx, now))
+
+	due, err := testTodoStorage.ClaimDueNotifications(ctx, "worker-1", now, time.Minute, 10)
+	require.NoError(t, err)
+	require.Len(t, due, 1)
+	require.Equal(t, todos[0].ID, due[0].Remind.ID)
+	require.Equal(t, model.NotificationDeadline, due[0].Kind)
+	require.Equal(t, "worker-1", due[0].LeasedBy)
+
+	t.Run("leased notification is claimed again when lease expires", func(t *testing.T) {
+		claimed, err := testTodoStorage.ClaimDueNotifications(ctx, "worker-2", now, time.Minute, 10)
+		require.NoError(t, err)
+		require.Empty(t, claimed)
+
+		next, err := testTodoStorage.NextNotificationAt(ctx, now)
+		require.NoError(t, err)
+		require.NotNil(t, next)
+		require.WithinDuration(t, now.Add(time.Minute), *next, time.Millisecond)
+
+		claimed, err = testTodoStorage.ClaimDueNotifications(ctx, "worker-2", now.Add(2*time.Minute), time.Minute, 10)
+		require.NoError(t, err)
+		require.Len(t, claimed, 1)
+		require.Equal(t, due[0].ID, claimed[0].ID)
+
+		require.ErrorIs(t, testTodoStorage.MarkNotificationSent(ctx, due[0].ID, "worker-1", now, nil), model.ErrNotificationNotLeased)
+	})
+
+	t.Run("sent notification isn't due anymore", func(t *testing.T) {
+		require.NoError(t, testTodoStorage.MarkNotificationSent(ctx, due[0].ID, "worker-2", now, nil))
+		require.ErrorIs(t, testTodoStorage.MarkNotificationSent(ctx, due[0].ID, "worker-2", now, nil), model.ErrNotificationNotLeased)
+
+		require.NoError(t, testTodoStorage.ScheduleNotifications(ctx, now))
+
+		due, err := testTodoStorage.ClaimDueNotifications(ctx, "worker-1", now.Add(time.Hour), time.Minute, 10)
+		require.NoError(t, err)
+		require.Empty(t, due)
+
+		next, err := testTodoStorage.NextNotificationAt(ctx, now)
+		require.NoError(t, err)
+		require.NotNil(t, next)
+		require.Equal(t, notifyAt, *next)
+	})
+
+	t.Run("missed notifications are due later", func(t *testing.T) {
+		later := now.Add(25 * time.Hour)
+		require.NoError(t, testTodoStorage.ScheduleNotifications(ctx, later))
+
+		due, err := testTodoStorage.ClaimDueNotifications(ctx, "worker-1", later, time.Minute, 10)
+		require.NoError(t, err)
+		require.Len(t, due, 2)
+		require.Equal(t, model.NotificationDeadline, due[0].Kind)
+		require.Equal(t, notifyAt, due[0].DueAt)
+		require.Equal(t, model.NotificationPeriod, due[1].Kind)
+		require.Equal(t, deadline.AddDate(0, 0, -2), due[1].DueAt)
+	})
+
+	t.Run("rescheduled notification replaces not sent one", func(t *testing.T) {
+		moved := now.Add(5 * time.Hour).Truncate(time.Minute)
+		_, err := pClient.Exec(ctx, `UPDATE reminder.todo SET "NotifyPeriod" = $1 WHERE "ID" = $2`, []time.Time{moved}, remind.ID)
+		require.NoError(t, err)
+
+		require.NoError(t, testTodoStorage.ScheduleNotifications(ctx, now))
+
+		next, err := testTodoStorage.NextNotificationAt(ctx, now)
+		require.NoError(t, err)
+		require.NotNil(t, next)
+		require.Equal(t, moved, *next)
+	})
+
+	t.Run("completed remind isn't notified", func(t *testing.T) {
+		_, err := pClient.Exec(ctx, `UPDATE reminder.todo SET "Completed" = true WHERE "ID" = $1`, remind.ID)
+		require.NoError(t, err)
+
+		require.NoError(t, testTodoStorage.ScheduleNotifications(ctx, now))
+
+		next, err := testTodoStorage.NextNotificationAt(ctx, now)
+		require.NoError(t, err)
+		require.Nil(t, next)
+	})
+}
+
+func TestStorageTodo_NotificationRetries(t *testing.T) {
+	defer func() {
+		err := Truncate()
+		require.NoError(t, err)
+	}()
+
+	// the first seeded remind has deadline notification due now
+	todos, err := SeedTodosForDeadline()
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	now := time.Now().UTC()
+
+	require.NoError(t, testTodoStorage.ScheduleNotifications(ctx, now))
+
+	due, err := testTodoStorage.ClaimDueNotifications(ctx, "worker-1", now, time.Minute, 10)
+	require.NoError(t, err)
+	require.Len(t, due, 1)
+	require.Equal(t, todos[0].ID, due[0].Remind.ID)
+	require.Zero(t, due[0].Attempts)
+
+	t.Run("failed notification is claimed when the next attempt is due", func(t *testing.T) {
+		retryAt := now.Add(10 * time.Minute)
+		require.NoError(t, testTodoStorage.MarkNotificationFailed(ctx, due[0].ID, "worker-1", now, "connection refused", &retryAt))
+		require.ErrorIs(t, testTodoStorage.MarkNotificationFailed(ctx, due[0].ID, "worker-2", now, "connection refused", &retryAt), model.ErrNotificationNotLeased)
+
+		next, err := testTodoStorage.NextNotificationAt(ctx, now)
+		require.NoError(t, err)
+		require.NotNil(t, next)
+		require.WithinDuration(t, retryAt, *next, time.Millisecond)
+
+		claimed, err := testTodoStorage.ClaimDueNotifications(ctx, "worker-1", now.Add(5*time.Minute), time.Minute, 10)
+		require.NoError(t, err)
+		require.Empty(t, claimed)
+
+		claimed, err = testTodoStorage.ClaimDueNotifications(ctx, "worker-2", retryAt, time.Minute, 10)
+		require.NoError(t, err)
+		require.Len(t, claimed, 1)
+		require.Equal(t, 1, claimed[0].Attempts)
+		require.Equal(t, "connection refused", claimed[0].LastError)
+	})
+
+	t.Run("dead notification isn't claimed", func(t *testing.T) {
+		deadAt := now.Add(10 * time.Minute)
+		require.NoError(t, testTodoStorage.MarkNotificationFailed(ctx, due[0].ID, "worker-2", deadAt, "no such host", nil))
+
+		claimed, err := testTodoStorage.ClaimDueNotifications(ctx, "worker-1", now.Add(time.Hour), time.Minute, 10)
+		require.NoError(t, err)
+		require.Empty(t, claimed)
+
+		next, err := testTodoStorage.NextNotificationAt(ctx, now)
+		require.NoError(t, err)
+		require.Nil(t, next)
+
+		dead, err := testTodoStorage.GetDeadNotifications(ctx, 10)
+		require.NoError(t, err)
+		require.Len(t, dead, 1)
+		require.Equal(t, due[0].ID, dead[0].ID)
+		require.Equal(t, 2, dead[0].Attempts)
+		require.Equal(t, "no such host", dead[0].LastError)
+		require.NotNil(t, dead[0].DeadAt)
+		require.WithinDuration(t, deadAt, *dead[0].DeadAt, time.Millisecond)
+	})
+
+	t.Run("retried notification is due again", func(t *testing.T) {
+		later := now.Add(time.Hour)
+		require.NoError(t, testTodoStorage.RetryNotification(ctx, due[0].ID, later))
+
+		dead, err := testTodoStorage.GetDeadNotifications(ctx, 10)
+		require.NoError(t, err)
+		require.Empty(t, dead)
+
+		claimed, err := testTodoStorage.ClaimDueNotifications(ctx, "worker-1", later, time.Minute, 10)
+		require.NoError(t, err)
+		require.Len(t, claimed, 1)
+		require.Equal(t, 2, claimed[0].Attempts)
+
+		require.NoError(t, testTodoStorage.MarkNotificationSent(ctx, due[0].ID, "worker-1", later, nil))
+		require.ErrorIs(t, testTodoStorage.RetryNotification(ctx, due[0].ID, later), model.ErrCantFindNotificationWithID)
+		require.ErrorIs(t, testTodoStorage.RetryNotification(ctx, 0, later), model.ErrCantFindNotificationWithID)
+	})
+}
+
+// TestStorageTodo_NotificationQueue_AddRemind creates remind by the handler, so its notify period is stored
+// as it comes from user input
+func TestStorageTodo_NotificationQueue_AddRemind(t *testing.T) {
+	defer func() {
+		err := Truncate()
+		require.NoError(t, err)
+	}()
+
+	ctx := context.Background()
+	now := time.Now().UTC()
+	deadline := now.Add(24 * time.Hour).Truncate(time.Minute)
+	notifyAt := deadline.Add(-time.Hour)
+
+	userID, err := SeedUserConfig()
+	require.NoError(t, err)
+
+	srv := server.New(ctx, logging.GetLogger(), testTodoStorage, testConfigStorage, nil, nil, nil, nil, config.Config{})
+
+	body := fmt.Sprintf(`{"title": "title", "description": "description", "deadline_at": %q, "created_at": %q, "deadline_notify": true, "notify_period": [%q]}`,
+		deadline.Format(time.RFC3339), now.Format("02.01.2006, 15:04:05"), notifyAt.Format(time.RFC3339))
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/remind", strings.NewReader(body))
+	req = req.WithContext(context.WithValue(req.Context(), "userID", userID))
+	http.HandlerFunc(srv.AddRemind).ServeHTTP(w, req)
+	require.Equal(t, http.StatusCreated, w.Code)
+
+	var remind model.Todo
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &remind))
+	require.Len(t, remind.NotifyPeriod, 1)
+
+	require.NoError(t, testTodoStorage.ScheduleNotifications(ctx, now))
+
+	// nothing is due until the notify period
+	due, err := testTodoStorage.ClaimDueNotifications(ctx, "worker-1", now, time.Minute, 10)
+	require.NoError(t, err)
+	require.Empty(t, due)
+
+	next, err := testTodoStorage.NextNotificationAt(ctx, now)
+	require.NoError(t, err)
+	require.NotNil(t, next)
+	require.Equal(t, notifyAt, *next)
+
+	t.Run("zero notify period isn't scheduled", func(t *testing.T) {
+		_, err := pClient.Exec(ctx, `UPDATE reminder.todo SET "NotifyPeriod" = $1 WHERE "ID" = $2`, []time.Time{{}, notifyAt}, remind.ID)
+		require.NoError(t, err)
+
+		require.NoError(t, testTodoStorage.ScheduleNotifications(ctx, now))
+
+		due, err := testTodoStorage.ClaimDueNotifications(ctx, "worker-1", now, time.Minute, 10)
+		require.NoError(t, err)
+		require.Empty(t, due)
+	})
+}
@@ -0,0 +1,212 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"firebase.google.com/go/auth"
+	"github.com/golang/mock/gomock"
+	"github.com/red-rocket-software/reminder-go/config"
+	model "github.com/red-rocket-software/reminder-go/internal/reminder/domain"
+	mock_firestore "github.com/red-rocket-software/reminder-go/pkg/firestore/mocks"
+	"github.com/red-rocket-software/reminder-go/pkg/logging"
+	"github.com/red-rocket-software/reminder-go/workers/notifier"
+	"github.com/red-rocket-software/reminder-go/workers/notifier/channel"
+	"github.com/stretchr/testify/require"
+)
+
+// TestNotifier_Workers runs several notification workers against the same database, each due notification
+// should be delivered once
+func TestNotifier_Workers(t *testing.T) {
+	defer func() {
+		err := Truncate()
+		require.NoError(t, err)
+	}()
+
+	ctx := context.Background()
+
+	var mu sync.Mutex
+	delivered := map[int]int{}
+	keys := map[int]string{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload struct {
+			RemindID int `json:"remind_id"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		mu.Lock()
+		delivered[payload.RemindID]++
+		keys[payload.RemindID] = r.Header.Get(channel.IdempotencyHeader)
+		mu.Unlock()
+	}))
+	defer srv.Close()
+
+	userID, err := SeedUserConfig()
+	require.NoError(t, err)
+	_, err = testConfigStorage.UpdateUserConfig(ctx, userID, model.UserConfigs{
+		Channels: []model.ChannelPreference{{Channel: model.ChannelWebhook, Address: srv.URL}},
+	})
+	require.NoError(t, err)
+
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	fireClient := mock_firestore.NewMockClient(c)
+	fireClient.EXPECT().GetUser(userID).Return(&auth.UserRecord{UserInfo: &auth.UserInfo{UID: userID}}, nil).AnyTimes()
+
+	logger := logging.GetLogger()
+	newWorker := func(id string) *notifier.Worker {
+		cfg := config.Config{}
+		cfg.Channels.Email.Disabled = true
+		cfg.Channels.Webhook.Enabled = true
+		// stand-in webhook listens on loopback
+		cfg.Channels.AllowPrivateNetworks = true
+		cfg.Channels.Timeout = 5 * time.Second
+		cfg.Scheduler.WorkerID = id
+		cfg.Scheduler.Lease = time.Minute
+		cfg.Scheduler.BatchSize = 5
+		cfg.Retry.MaxAttempts = 3
+		cfg.Retry.Delay = time.Minute
+		cfg.Retry.MaxDelay = time.Hour
+
+		return notifier.NewWorker(ctx, &logger, testTodoStorage, testConfigStorage, fireClient, cfg)
+	}
+
+	createReminds := func(n int) []int {
+		now := time.Now().UTC()
+		notify := true
+
+		var ids []int
+		for i := 0; i < n; i++ {
+			remind, err := testTodoStorage.CreateRemind(ctx, model.Todo{
+				Title:          fmt.Sprintf("remind %d", i),
+				Description:    "due",
+				UserID:         userID,
+				CreatedAt:      now,
+				DeadlineAt:     now.Add(24 * time.Hour),
+				DeadlineNotify: &notify,
+				NotifyPeriod:   []time.Time{now.Add(-time.Minute).Truncate(time.Second)},
+			})
+			require.NoError(t, err)
+			ids = append(ids, remind.ID)
+		}
+		return ids
+	}
+
+	t.Run("each notification is delivered once", func(t *testing.T) {
+		ids := createReminds(30)
+
+		var wg sync.WaitGroup
+		errs := make(chan error, 12)
+		for i := 0; i < 4; i++ {
+			worker := newWorker(fmt.Sprintf("worker-%d", i))
+
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for round := 0; round < 3; round++ {
+					if err := worker.ProcessDueNotifications(); err != nil {
+						errs <- err
+					}
+					if _, err := worker.ProcessOutbox(); err != nil {
+						errs <- err
+					}
+				}
+			}()
+		}
+		wg.Wait()
+		close(errs)
+
+		for err := range errs {
+			require.NoError(t, err)
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		require.Len(t, delivered, len(ids))
+		for _, id := range ids {
+			require.Equal(t, 1, delivered[id], "remind %d", id)
+		}
+
+		var notSent int
+		err := pClient.QueryRow(ctx, `SELECT count(*) FROM reminder.notifications WHERE "SentAt" IS NULL`).Scan(&notSent)
+		require.NoError(t, err)
+		require.Zero(t, notSent)
+
+		err = pClient.QueryRow(ctx, `SELECT count(*) FROM reminder.outbox WHERE "SentAt" IS NULL`).Scan(&notSent)
+		require.NoError(t, err)
+		require.Zero(t, notSent)
+	})
+
+	t.Run("notifications of crashed worker are sent by others", func(t *testing.T) {
+		ids := createReminds(1)
+
+		// crashed worker has claimed the notification and hasn't sent it
+		require.NoError(t, testTodoStorage.ScheduleNotifications(ctx, time.Now()))
+		claimed, err := testTodoStorage.ClaimDueNotifications(ctx, "crashed", time.Now(), 500*time.Millisecond, 10)
+		require.NoError(t, err)
+		require.Len(t, claimed, 1)
+
+		worker := newWorker("worker-0")
+
+		require.NoError(t, worker.ProcessDueNotifications())
+		next, err := worker.ProcessOutbox()
+		require.NoError(t, err)
+		require.NotNil(t, next)
+
+		mu.Lock()
+		require.Zero(t, delivered[ids[0]])
+		mu.Unlock()
+
+		// the next run is when the lease expires
+		time.Sleep(notifier.Wakeup(time.Now(), next, time.Second) + 10*time.Millisecond)
+
+		require.NoError(t, worker.ProcessDueNotifications())
+		_, err = worker.ProcessOutbox()
+		require.NoError(t, err)
+
+		mu.Lock()
+		require.Equal(t, 1, delivered[ids[0]])
+		mu.Unlock()
+
+		var leasedBy string
+		err = pClient.QueryRow(ctx, `SELECT "LeasedBy" FROM reminder.notifications WHERE "ID" = $1 AND "SentAt" IS NOT NULL`, claimed[0].ID).Scan(&leasedBy)
+		require.NoError(t, err)
+		require.Equal(t, "worker-0", leasedBy)
+	})
+
+	t.Run("outbox messages of crashed worker are delivered by others with the same key", func(t *testing.T) {
+		ids := createReminds(1)
+
+		worker := newWorker("worker-0")
+		require.NoError(t, worker.ProcessDueNotifications())
+
+		// crashed worker has claimed the message and hasn't recorded its delivery
+		claimed, err := testTodoStorage.ClaimOutbox(ctx, "crashed", time.Now(), 500*time.Millisecond, 10)
+		require.NoError(t, err)
+		require.Len(t, claimed, 1)
+
+		next, err := worker.ProcessOutbox()
+		require.NoError(t, err)
+		require.NotNil(t, next)
+
+		time.Sleep(notifier.Wakeup(time.Now(), next, time.Second) + 10*time.Millisecond)
+
+		_, err = worker.ProcessOutbox()
+		require.NoError(t, err)
+
+		mu.Lock()
+		require.Equal(t, 1, delivered[ids[0]])
+		require.Equal(t, claimed[0].IdempotencyKey, keys[ids[0]])
+		mu.Unlock()
+	})
+}
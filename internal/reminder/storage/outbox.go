@@ -0,0 +1,174 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	model "github.com/red-rocket-software/reminder-go/internal/reminder/domain"
+)
+
+const outboxColumns = `"ID", "IdempotencyKey", "TodoID", "UserID", "Channel", "Payload", "CreatedAt", "SentAt", COALESCE("LeasedBy", ''),
+"LeasedUntil", "Attempts", COALESCE("LastError", ''), "NextAttemptAt", "DeadAt"`
+
+func outboxFields(m *model.OutboxMessage) []any {
+	return []any{
+		&m.ID,
+		&m.IdempotencyKey,
+		&m.TodoID,
+		&m.UserID,
+		&m.Channel,
+		&m.Payload,
+		&m.CreatedAt,
+		&m.SentAt,
+		&m.LeasedBy,
+		&m.LeasedUntil,
+		&m.Attempts,
+		&m.LastError,
+		&m.NextAttemptAt,
+		&m.DeadAt,
+	}
+}
+
+// addToOutbox adds messages to the outbox in transaction of the state change which caused them. Messages whose
+// idempotency key is already in the outbox are skipped
+func (s *TodoStorage) addToOutbox(ctx context.Context, tx pgx.Tx, outbox []model.OutboxMessage, createdAt time.Time) error {
+	const sql = `INSERT INTO reminder.outbox ("IdempotencyKey", "TodoID", "UserID", "Channel", "Payload", "CreatedAt")
+VALUES ($1, $2, $3, $4, $5, $6)
+ON CONFLICT ("IdempotencyKey") DO NOTHING`
+
+	for _, m := range outbox {
+		if _, err := tx.Exec(ctx, sql, m.IdempotencyKey, m.TodoID, m.UserID, m.Channel, m.Payload, createdAt.UTC()); err != nil {
+			s.logger.Errorf("error to add message to outbox: %v", err)
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ClaimOutbox leases outbox messages to the worker like ClaimDueNotifications, messages locked by other workers
+// are skipped and the ones whose lease has expired are claimed again
+func (s *TodoStorage) ClaimOutbox(ctx context.Context, workerID string, now time.Time, lease time.Duration, limit int) ([]model.OutboxMessage, error) {
+	outbox := []model.OutboxMessage{}
+
+	sql := `UPDATE reminder.outbox SET "LeasedBy" = $2, "LeasedUntil" = $3
+WHERE "ID" IN (
+	SELECT "ID" FROM reminder.outbox
+	WHERE "SentAt" IS NULL
+	AND "DeadAt" IS NULL
+	AND ("LeasedUntil" IS NULL OR "LeasedUntil" <= $1)
+	AND ("NextAttemptAt" IS NULL OR "NextAttemptAt" <= $1)
+	ORDER BY "CreatedAt", "ID"
+	LIMIT $4
+	FOR UPDATE SKIP LOCKED
+)
+RETURNING ` + outboxColumns
+
+	rows, err := s.Postgres.Query(ctx, sql, now.UTC(), workerID, now.Add(lease).UTC(), limit)
+	if err != nil {
+		s.logger.Errorf("error to claim outbox: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var m model.OutboxMessage
+
+		if err := rows.Scan(outboxFields(&m)...); err != nil {
+			s.logger.Errorf("outbox message doesn't exist: %v", err)
+			return nil, err
+		}
+		outbox = append(outbox, m)
+	}
+
+	return outbox, rows.Err()
+}
+
+func (s *TodoStorage) MarkOutboxSent(ctx context.Context, id int64, workerID string, sentAt time.Time) error {
+	const sql = `UPDATE reminder.outbox SET "SentAt" = $1, "Attempts" = "Attempts" + 1, "LeasedUntil" = NULL, "NextAttemptAt" = NULL
+WHERE "ID" = $2 AND "LeasedBy" = $3 AND "SentAt" IS NULL`
+
+	ct, err := s.Postgres.Exec(ctx, sql, sentAt.UTC(), id, workerID)
+	if err != nil {
+		s.logger.Printf("unable to mark outbox message sent %v", err)
+		return err
+	}
+
+	if ct.RowsAffected() == 0 {
+		return model.ErrNotificationNotLeased
+	}
+
+	return nil
+}
+
+func (s *TodoStorage) MarkOutboxFailed(ctx context.Context, id int64, workerID string, failedAt time.Time, lastError string, retryAt *time.Time) error {
+	const sql = `UPDATE reminder.outbox SET "Attempts" = "Attempts" + 1, "LastError" = $1, "NextAttemptAt" = $2,
+"DeadAt" = CASE WHEN $2::timestamp IS NULL THEN $3::timestamp END, "LeasedUntil" = NULL
+WHERE "ID" = $4 AND "LeasedBy" = $5 AND "SentAt" IS NULL`
+
+	var next *time.Time
+	if retryAt != nil {
+		t := retryAt.UTC()
+		next = &t
+	}
+
+	ct, err := s.Postgres.Exec(ctx, sql, lastError, next, failedAt.UTC(), id, workerID)
+	if err != nil {
+		s.logger.Printf("unable to mark outbox message failed %v", err)
+		return err
+	}
+
+	if ct.RowsAffected() == 0 {
+		return model.ErrNotificationNotLeased
+	}
+
+	return nil
+}
+
+func (s *TodoStorage) GetDeadOutbox(ctx context.Context, limit int) ([]model.OutboxMessage, error) {
+	outbox := []model.OutboxMessage{}
+
+	sql := `SELECT ` + outboxColumns + `
+FROM reminder.outbox
+WHERE "DeadAt" IS NOT NULL AND "SentAt" IS NULL
+ORDER BY "DeadAt" DESC, "ID" DESC
+LIMIT $1`
+
+	rows, err := s.Postgres.Query(ctx, sql, limit)
+	if err != nil {
+		s.logger.Errorf("error to select dead outbox messages: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var m model.OutboxMessage
+
+		if err := rows.Scan(outboxFields(&m)...); err != nil {
+			s.logger.Errorf("outbox message doesn't exist: %v", err)
+			return nil, err
+		}
+		outbox = append(outbox, m)
+	}
+
+	return outbox, rows.Err()
+}
+
+// RetryOutbox makes outbox message due again like RetryNotification, number of attempts is kept
+func (s *TodoStorage) RetryOutbox(ctx context.Context, id int64, now time.Time) error {
+	const sql = `UPDATE reminder.outbox SET "DeadAt" = NULL, "NextAttemptAt" = $1, "LeasedUntil" = NULL
+WHERE "ID" = $2 AND "SentAt" IS NULL`
+
+	ct, err := s.Postgres.Exec(ctx, sql, now.UTC(), id)
+	if err != nil {
+		s.logger.Printf("unable to retry outbox message %v", err)
+		return err
+	}
+
+	if ct.RowsAffected() == 0 {
+		return model.ErrCantFindOutboxMessageWithID
+	}
+
+	return nil
+}
@@ -0,0 +1,144 @@
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	model "github.com/red-rocket-software/reminder-go/internal/reminder/domain"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStorageTodo_Outbox(t *testing.T) {
+	defer func() {
+		err := Truncate()
+		require.NoError(t, err)
+	}()
+
+	// the first seeded remind has deadline notification due now
+	todos, err := SeedTodosForDeadline()
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	now := time.Now().UTC()
+
+	outboxMessage := func(key string, todoID int) model.OutboxMessage {
+		return model.OutboxMessage{
+			IdempotencyKey: key,
+			TodoID:         todoID,
+			UserID:         todos[0].UserID,
+			Channel:        model.ChannelEmail,
+			Payload:        []byte(`{"message": {"title": "tes1"}}`),
+		}
+	}
+
+	require.NoError(t, testTodoStorage.ScheduleNotifications(ctx, now))
+
+	// notification before the deadline of the fourth remind is due too
+	_, err = pClient.Exec(ctx, `INSERT INTO reminder.notifications ("TodoID", "Kind", "DueAt") VALUES ($1, $2, $3)`,
+		todos[3].ID, model.NotificationPeriod, now.Add(-time.Hour))
+	require.NoError(t, err)
+
+	due, err := testTodoStorage.ClaimDueNotifications(ctx, "worker-1", now, time.Minute, 10)
+	require.NoError(t, err)
+	require.Len(t, due, 2)
+	require.Equal(t, todos[3].ID, due[0].Remind.ID)
+	require.Equal(t, todos[0].ID, due[1].Remind.ID)
+
+	t.Run("sent notification is added to the outbox", func(t *testing.T) {
+		err := testTodoStorage.MarkNotificationSent(ctx, due[1].ID, "worker-1", now, []model.OutboxMessage{
+			outboxMessage("notification-1/user/email/0", todos[0].ID),
+			outboxMessage("notification-1/user/webhook/1", todos[0].ID),
+		})
+		require.NoError(t, err)
+
+		// notification which isn't leased by the worker isn't added
+		err = testTodoStorage.MarkNotificationSent(ctx, due[1].ID, "worker-1", now, []model.OutboxMessage{outboxMessage("notification-1/user/email/2", todos[0].ID)})
+		require.ErrorIs(t, err, model.ErrNotificationNotLeased)
+
+		outbox, err := testTodoStorage.ClaimOutbox(ctx, "worker-1", now, time.Minute, 10)
+		require.NoError(t, err)
+		require.Len(t, outbox, 2)
+		require.Equal(t, "notification-1/user/email/0", outbox[0].IdempotencyKey)
+		require.Equal(t, todos[0].ID, outbox[0].TodoID)
+		require.Equal(t, model.ChannelEmail, outbox[0].Channel)
+		require.JSONEq(t, `{"message": {"title": "tes1"}}`, string(outbox[0].Payload))
+		require.Equal(t, "worker-1", outbox[0].LeasedBy)
+
+		remind, err := testTodoStorage.GetRemindByID(ctx, todos[0].ID, todos[0].UserID)
+		require.NoError(t, err)
+		require.False(t, remind.Notificated)
+
+		require.NoError(t, testTodoStorage.MarkOutboxSent(ctx, outbox[0].ID, "worker-1", now))
+		require.ErrorIs(t, testTodoStorage.MarkOutboxSent(ctx, outbox[0].ID, "worker-1", now), model.ErrNotificationNotLeased)
+
+		// leased message is claimed again when the lease expires
+		claimed, err := testTodoStorage.ClaimOutbox(ctx, "worker-2", now, time.Minute, 10)
+		require.NoError(t, err)
+		require.Empty(t, claimed)
+
+		claimed, err = testTodoStorage.ClaimOutbox(ctx, "worker-2", now.Add(2*time.Minute), time.Minute, 10)
+		require.NoError(t, err)
+		require.Len(t, claimed, 1)
+		require.Equal(t, outbox[1].ID, claimed[0].ID)
+		require.NoError(t, testTodoStorage.MarkOutboxSent(ctx, claimed[0].ID, "worker-2", now))
+	})
+
+	t.Run("notification before the deadline marks the remind", func(t *testing.T) {
+		require.NoError(t, testTodoStorage.MarkNotificationSent(ctx, due[0].ID, "worker-1", now, nil))
+
+		remind, err := testTodoStorage.GetRemindByID(ctx, todos[3].ID, todos[3].UserID)
+		require.NoError(t, err)
+		require.True(t, remind.Notificated)
+
+		history, err := testTodoStorage.GetHistory(ctx, todos[3].ID, todos[3].UserID)
+		require.NoError(t, err)
+		require.Equal(t, model.HistoryNotification, history[len(history)-1].Action)
+	})
+
+	t.Run("failed message is retried and dead after the last attempt", func(t *testing.T) {
+		require.NoError(t, testTodoStorage.UpdateEscalation(ctx, todos[2].ID, 1, now, now.Add(time.Hour), []model.OutboxMessage{
+			outboxMessage("escalation-1", todos[2].ID),
+		}))
+
+		outbox, err := testTodoStorage.ClaimOutbox(ctx, "worker-1", now, time.Minute, 10)
+		require.NoError(t, err)
+		require.Len(t, outbox, 1)
+		require.Equal(t, "escalation-1", outbox[0].IdempotencyKey)
+
+		retryAt := now.Add(10 * time.Minute)
+		require.NoError(t, testTodoStorage.MarkOutboxFailed(ctx, outbox[0].ID, "worker-1", now, "connection refused", &retryAt))
+
+		next, err := testTodoStorage.NextNotificationAt(ctx, now)
+		require.NoError(t, err)
+		require.NotNil(t, next)
+		require.WithinDuration(t, retryAt, *next, time.Millisecond)
+
+		claimed, err := testTodoStorage.ClaimOutbox(ctx, "worker-1", retryAt, time.Minute, 10)
+		require.NoError(t, err)
+		require.Len(t, claimed, 1)
+		require.Equal(t, 1, claimed[0].Attempts)
+		require.Equal(t, "connection refused", claimed[0].LastError)
+
+		require.NoError(t, testTodoStorage.MarkOutboxFailed(ctx, outbox[0].ID, "worker-1", retryAt, "no such host", nil))
+
+		claimed, err = testTodoStorage.ClaimOutbox(ctx, "worker-1", now.Add(time.Hour), time.Minute, 10)
+		require.NoError(t, err)
+		require.Empty(t, claimed)
+
+		dead, err := testTodoStorage.GetDeadOutbox(ctx, 10)
+		require.NoError(t, err)
+		require.Len(t, dead, 1)
+		require.Equal(t, 2, dead[0].Attempts)
+		require.NotNil(t, dead[0].DeadAt)
+
+		require.NoError(t, testTodoStorage.RetryOutbox(ctx, outbox[0].ID, now.Add(time.Hour)))
+
+		claimed, err = testTodoStorage.ClaimOutbox(ctx, "worker-1", now.Add(time.Hour), time.Minute, 10)
+		require.NoError(t, err)
+		require.Len(t, claimed, 1)
+
+		require.NoError(t, testTodoStorage.MarkOutboxSent(ctx, outbox[0].ID, "worker-1", now.Add(time.Hour)))
+		require.ErrorIs(t, testTodoStorage.RetryOutbox(ctx, outbox[0].ID, now), model.ErrCantFindOutboxMessageWithID)
+	})
+}
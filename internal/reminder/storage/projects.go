@@ -0,0 +1,178 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	model "github.com/red-rocket-software/reminder-go/internal/reminder/domain"
+	"github.com/red-rocket-software/reminder-go/pkg/logging"
+	"github.com/red-rocket-software/reminder-go/pkg/postgresql"
+)
+
+var _ model.ProjectRepository = (*ProjectStorage)(nil)
+
+// projectColumns lists reminder.projects columns in the order projectFields returns them
+const projectColumns = `"ID", "User", "Name", "Color", "Position", "ArchivedAt", "CreatedAt"`
+
+// ProjectStorage handles database communication with PostgreSQL.
+type ProjectStorage struct {
+	// Postgres database.PGX
+	Postgres *pgxpool.Pool
+	// Logrus logger
+	logger *logging.Logger
+}
+
+// NewProjectsStorage  return new ProjectStorage with Postgres pool and logger
+func NewProjectsStorage(postgres *pgxpool.Pool, logger *logging.Logger) model.ProjectRepository {
+	return &ProjectStorage{Postgres: postgres, logger: logger}
+}
+
+// projectFields returns pointers to project fields to scan a row selected with projectColumns
+func projectFields(project *model.Project) []any {
+	return []any{
+		&project.ID,
+		&project.UserID,
+		&project.Name,
+		&project.Color,
+		&project.Position,
+		&project.ArchivedAt,
+		&project.CreatedAt,
+	}
+}
+
+// GetProjects returns user projects ordered by position
+func (s *ProjectStorage) GetProjects(ctx context.Context, userID string, withArchived bool) ([]model.Project, error) {
+	projects := []model.Project{}
+
+	const sql = `SELECT ` + projectColumns + ` FROM reminder.projects
+WHERE "User" = $1 AND ($2 OR "ArchivedAt" IS NULL) ORDER BY "Position", "ID"`
+
+	rows, err := s.Postgres.Query(ctx, sql, userID, withArchived)
+	if err != nil {
+		s.logger.Errorf("error get projects from db: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var project model.Project
+
+		if err := rows.Scan(projectFields(&project)...); err != nil {
+			s.logger.Errorf("project doesn't exist: %v", err)
+			return nil, err
+		}
+		projects = append(projects, project)
+	}
+
+	return projects, rows.Err()
+}
+
+// CreateProject store new project to DB PostgresSQL
+func (s *ProjectStorage) CreateProject(ctx context.Context, userID string, input model.ProjectInput) (model.Project, error) {
+	var project model.Project
+
+	const sql = `INSERT INTO reminder.projects ("User", "Name", "Color", "Position", "ArchivedAt", "CreatedAt")
+SELECT $1, $2, $3, COALESCE($4, (SELECT MAX("Position") + 1 FROM reminder.projects WHERE "User" = $1), 0), CASE WHEN $5 THEN $6::timestamp END, $6
+RETURNING ` + projectColumns
+
+	row := s.Postgres.QueryRow(ctx, sql, userID, input.Name, input.Color, input.Position, input.Archived, time.Now())
+	if err := row.Scan(projectFields(&project)...); err != nil {
+		s.logger.Errorf("Error create project: %v", err)
+		return model.Project{}, err
+	}
+
+	return project, nil
+}
+
+// UpdateProject changes project fields. Archiving of archived project keeps the first archive time
+func (s *ProjectStorage) UpdateProject(ctx context.Context, id int, userID string, input model.ProjectInput) (model.Project, error) {
+	var project model.Project
+
+	const sql = `UPDATE reminder.projects SET "Name" = $1, "Color" = $2, "Position" = COALESCE($3, "Position"),
+"ArchivedAt" = CASE WHEN $4 THEN COALESCE("ArchivedAt", $5) END
+WHERE "ID" = $6 AND "User" = $7 RETURNING ` + projectColumns
+
+	row := s.Postgres.QueryRow(ctx, sql, input.Name, input.Color, input.Position, input.Archived, time.Now(), id, userID)
+	err := row.Scan(projectFields(&project)...)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return model.Project{}, model.ErrCantFindProjectWithID
+	}
+	if err != nil {
+		s.logger.Errorf("unable to update project: %v", err)
+		return model.Project{}, err
+	}
+
+	return project, nil
+}
+
+// DeleteProject deletes project and, if withReminds is true, moves all its reminds to trash
+func (s *ProjectStorage) DeleteProject(ctx context.Context, id int, userID string, withReminds bool) error {
+	tx, err := s.Postgres.Begin(ctx)
+	if err != nil {
+		s.logger.Errorf("unable to begin transaction: %v", err)
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if withReminds {
+		deletedAt := time.Now()
+
+		// deleted reminds are recorded in their history in the same statement
+		const sql = `WITH deleted AS (UPDATE reminder.todo SET "DeletedAt" = $1 WHERE "ProjectID" = $2 AND "User" = $3 AND "DeletedAt" IS NULL RETURNING "ID")
+INSERT INTO reminder.todo_history ("TodoID", "Owner", "Actor", "Action", "Changes", "CreatedAt")
+SELECT "ID", $3, $3, $4, $5, $1 FROM deleted`
+		if _, err := tx.Exec(ctx, sql, deletedAt, id, userID, model.HistoryDelete, deletedChanges(nil, &deletedAt)); err != nil {
+			s.logger.Errorf("Error delete project reminds: %v", err)
+			return model.ErrDeleteFailed
+		}
+	}
+
+	// reminds which are kept lose the project by foreign key ON DELETE SET NULL
+	const sql = `DELETE FROM reminder.projects WHERE "ID" = $1 AND "User" = $2`
+
+	ct, err := tx.Exec(ctx, sql, id, userID)
+	if err != nil {
+		s.logger.Errorf("Error delete project: %v", err)
+		return model.ErrDeleteFailed
+	}
+
+	if ct.RowsAffected() == 0 {
+		return model.ErrCantFindProjectWithID
+	}
+
+	return tx.Commit(ctx)
+}
+
+// checkProject checks that reminds of the user can be put to the project
+func checkProject(ctx context.Context, db postgresql.Client, userID string, projectID *int) error {
+	if projectID == nil {
+		return nil
+	}
+
+	var archivedAt *time.Time
+
+	const sql = `SELECT "ArchivedAt" FROM reminder.projects WHERE "ID" = $1 AND "User" = $2`
+
+	err := db.QueryRow(ctx, sql, *projectID, userID).Scan(&archivedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return model.ErrCantFindProjectWithID
+	}
+	if err != nil {
+		return err
+	}
+
+	if archivedAt != nil {
+		return model.ErrProjectArchived
+	}
+
+	return nil
+}
+
+// notInArchivedProject returns condition which excludes reminds of archived projects,
+// column is remind "ProjectID" column, e.g. t."ProjectID"
+func notInArchivedProject(column string) string {
+	return `(` + column + ` IS NULL OR ` + column + ` NOT IN (SELECT "ID" FROM reminder.projects WHERE "ArchivedAt" IS NOT NULL))`
+}
@@ -0,0 +1,130 @@
+package storage
+
+import (
+	"context"
+	"testing"
+
+	model "github.com/red-rocket-software/reminder-go/internal/reminder/domain"
+	"github.com/red-rocket-software/reminder-go/pkg/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStorageTodo_Projects(t *testing.T) {
+	defer func() {
+		err := Truncate()
+		require.NoError(t, err)
+	}()
+
+	expectedTodo, err := SeedTodos()
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	userID := expectedTodo[0].UserID
+
+	first, err := testProjectStorage.CreateProject(ctx, userID, model.ProjectInput{Name: "first"})
+	require.NoError(t, err)
+	require.Equal(t, 0, first.Position)
+
+	second, err := testProjectStorage.CreateProject(ctx, userID, model.ProjectInput{Name: "second"})
+	require.NoError(t, err)
+	require.Equal(t, 1, second.Position)
+
+	moveToProject := func(todo model.Todo, projectID int) error {
+		_, err := testTodoStorage.UpdateRemind(ctx, todo.ID, userID, model.TodoUpdateInput{
+			Title:       todo.Title,
+			Description: todo.Description,
+			DeadlineAt:  "2023-04-15T16:27:00Z",
+			Completed:   todo.Completed,
+			FinishedAt:  todo.FinishedAt,
+			ProjectID:   &projectID,
+		})
+		return err
+	}
+
+	require.NoError(t, moveToProject(expectedTodo[0], first.ID))
+	require.NoError(t, moveToProject(expectedTodo[1], second.ID))
+	require.NoError(t, moveToProject(expectedTodo[2], second.ID))
+
+	params := model.FetchParams{
+		Page:          utils.Page{Limit: 10},
+		FilterByDate:  "CreatedAt",
+		FilterBySort:  "ASC",
+		FilterByQuery: "all",
+	}
+
+	t.Run("error project of another user", func(t *testing.T) {
+		_, err := testProjectStorage.UpdateProject(ctx, first.ID, "another user", model.ProjectInput{Name: "mine"})
+		require.ErrorIs(t, err, model.ErrCantFindProjectWithID)
+
+		require.ErrorIs(t, moveToProject(expectedTodo[3], first.ID+second.ID), model.ErrCantFindProjectWithID)
+	})
+
+	t.Run("filter by project", func(t *testing.T) {
+		params := params
+		params.ProjectID = second.ID
+
+		_, count, _, err := testTodoStorage.GetReminds(ctx, params, userID)
+		require.NoError(t, err)
+		require.Equal(t, 2, count)
+	})
+
+	t.Run("archived project hides reminds", func(t *testing.T) {
+		archived, err := testProjectStorage.UpdateProject(ctx, second.ID, userID, model.ProjectInput{Name: "second", Archived: true})
+		require.NoError(t, err)
+		require.NotNil(t, archived.ArchivedAt)
+		require.Equal(t, 1, archived.Position)
+
+		_, count, _, err := testTodoStorage.GetReminds(ctx, params, userID)
+		require.NoError(t, err)
+		require.Equal(t, 3, count)
+
+		projects, err := testProjectStorage.GetProjects(ctx, userID, false)
+		require.NoError(t, err)
+		require.Len(t, projects, 1)
+
+		require.ErrorIs(t, moveToProject(expectedTodo[3], second.ID), model.ErrProjectArchived)
+
+		// remind stays in archived project when project isn't changed
+		require.NoError(t, moveToProject(expectedTodo[1], second.ID))
+
+		updated, err := testTodoStorage.UpdateRemind(ctx, expectedTodo[2].ID, userID, model.TodoUpdateInput{
+			Title:       "without project",
+			Description: expectedTodo[2].Description,
+			DeadlineAt:  "2023-04-15T16:27:00Z",
+			Completed:   expectedTodo[2].Completed,
+			FinishedAt:  expectedTodo[2].FinishedAt,
+		})
+		require.NoError(t, err)
+		require.Equal(t, &second.ID, updated.ProjectID)
+	})
+
+	t.Run("remove remind from project", func(t *testing.T) {
+		require.NoError(t, moveToProject(expectedTodo[3], first.ID))
+		require.NoError(t, moveToProject(expectedTodo[3], 0))
+
+		todo, err := testTodoStorage.GetRemindByID(ctx, expectedTodo[3].ID, userID)
+		require.NoError(t, err)
+		require.Nil(t, todo.ProjectID)
+	})
+
+	t.Run("delete project keeps reminds", func(t *testing.T) {
+		err := testProjectStorage.DeleteProject(ctx, first.ID, userID, false)
+		require.NoError(t, err)
+
+		todo, err := testTodoStorage.GetRemindByID(ctx, expectedTodo[0].ID, userID)
+		require.NoError(t, err)
+		require.Nil(t, todo.ProjectID)
+	})
+
+	t.Run("delete project with reminds", func(t *testing.T) {
+		err := testProjectStorage.DeleteProject(ctx, second.ID, userID, true)
+		require.NoError(t, err)
+
+		_, count, _, err := testTodoStorage.GetReminds(ctx, params, userID)
+		require.NoError(t, err)
+		require.Equal(t, 3, count)
+
+		err = testProjectStorage.DeleteProject(ctx, second.ID, userID, true)
+		require.ErrorIs(t, err, model.ErrCantFindProjectWithID)
+	})
+}
@@ -0,0 +1,66 @@
+package storage
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+var errInvalidSort = errors.New("wrong sort params, should be CreatedAt, DeadlineAt or Priority in ASC or DESC order")
+
+// sortColumns whitelists columns reminds can be sorted by, query values are never put into SQL
+var sortColumns = map[string]string{
+	"CreatedAt":  `"CreatedAt"`,
+	"DeadlineAt": `"DeadlineAt"`,
+	"Priority":   `"Priority"`,
+}
+
+var sortDirections = map[string]string{
+	"ASC":  "ASC",
+	"DESC": "DESC",
+}
+
+// query composes SET assignments and WHERE conditions of SQL statement. Values are bound
+// as parameters and only placeholders are put into SQL text
+type query struct {
+	assignments []string
+	conditions  []string
+	args        []any
+}
+
+// arg binds value and returns its placeholder, e.g. $2
+func (q *query) arg(value any) string {
+	q.args = append(q.args, value)
+	return "$" + strconv.Itoa(len(q.args))
+}
+
+// set adds assignment of value to column, column should be a constant quoted name
+func (q *query) set(column string, value any) {
+	q.assignments = append(q.assignments, column+" = "+q.arg(value))
+}
+
+// where adds condition joined with AND to other ones
+func (q *query) where(condition string) {
+	q.conditions = append(q.conditions, condition)
+}
+
+// String returns conditions to put after WHERE
+func (q *query) String() string {
+	if len(q.conditions) == 0 {
+		return "true"
+	}
+	return strings.Join(q.conditions, " AND ")
+}
+
+// orderBy returns whitelisted sort column and direction
+func orderBy(column, direction string) (string, string, error) {
+	col, ok := sortColumns[column]
+	if !ok {
+		return "", "", errInvalidSort
+	}
+	dir, ok := sortDirections[direction]
+	if !ok {
+		return "", "", errInvalidSort
+	}
+	return col, dir, nil
+}
@@ -0,0 +1,51 @@
+package storage
+
+import (
+	"context"
+	"testing"
+
+	model "github.com/red-rocket-software/reminder-go/internal/reminder/domain"
+	"github.com/red-rocket-software/reminder-go/pkg/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuery(t *testing.T) {
+	var q query
+	require.Equal(t, "true", q.String())
+
+	q.where(`"User" = ` + q.arg("user'; DROP TABLE reminder.todo; --"))
+	q.where(`"ProjectID" = ANY(` + q.arg([]int{1, 2}) + `)`)
+
+	require.Equal(t, `"User" = $1 AND "ProjectID" = ANY($2)`, q.String())
+	require.Equal(t, []any{"user'; DROP TABLE reminder.todo; --", []int{1, 2}}, q.args)
+}
+
+func TestOrderBy(t *testing.T) {
+	column, direction, err := orderBy("Priority", "DESC")
+	require.NoError(t, err)
+	require.Equal(t, `"Priority"`, column)
+	require.Equal(t, "DESC", direction)
+
+	for _, params := range [][2]string{
+		{`CreatedAt" DESC; DROP TABLE reminder.todo; --`, "ASC"},
+		{"CreatedAt", "ASC; DROP TABLE reminder.todo"},
+		{"createdat", "asc"},
+		{"", ""},
+	} {
+		_, _, err := orderBy(params[0], params[1])
+		require.ErrorIs(t, err, errInvalidSort)
+	}
+}
+
+func TestStorageTodo_GetRemindsHostileSort(t *testing.T) {
+	params := model.FetchParams{
+		Page:          utils.Page{Limit: 10},
+		FilterByDate:  `CreatedAt"; DELETE FROM reminder.todo; --`,
+		FilterBySort:  "ASC",
+		FilterByQuery: "all",
+	}
+
+	// sort params are rejected before the query is sent to database
+	_, _, _, err := testTodoStorage.GetReminds(context.Background(), params, "user")
+	require.ErrorIs(t, err, errInvalidSort)
+}
@@ -0,0 +1,109 @@
+package storage
+
+import (
+	"fmt"
+	"time"
+
+	model "github.com/red-rocket-software/reminder-go/internal/reminder/domain"
+	"github.com/red-rocket-software/reminder-go/pkg/rrule"
+)
+
+// maxOccurrences limits the number of expanded occurrences of one remind
+const maxOccurrences = 366
+
+// nextOccurrence builds the remind for the next occurrence of a completed recurring remind.
+// It returns false when the recurrence is over
+func nextOccurrence(todo model.Todo, finishedAt time.Time) (model.Todo, bool, error) {
+	rule, err := rrule.Parse(*todo.RRule)
+	if err != nil {
+		return model.Todo{}, false, err
+	}
+
+	anchor := todo.DeadlineAt
+	if todo.RecurFromCompletion {
+		// keep the time of the deadline, but count from the day of completion
+		anchor = time.Date(finishedAt.Year(), finishedAt.Month(), finishedAt.Day(),
+			anchor.Hour(), anchor.Minute(), anchor.Second(), 0, anchor.Location())
+	}
+
+	it := rule.Iterator(anchor)
+	it.Next() // the anchor itself
+
+	var next time.Time
+	// skip occurrences which passed while the remind was overdue
+	for passed := 1; ; passed++ {
+		t, ok := it.Next()
+		if !ok {
+			return model.Todo{}, false, nil
+		}
+		if todo.RecurFromCompletion || t.After(finishedAt) {
+			next = t
+			if rule.Count > 0 {
+				rule.Count -= passed
+			}
+			break
+		}
+	}
+
+	ruleStr := rule.String()
+
+	// sent notifications are already removed from NotifyPeriod, so it is rebuilt from offsets
+	var notifyPeriod []time.Time
+	for _, offset := range todo.NotifyOffsets {
+		notifyPeriod = append(notifyPeriod, next.Add(-time.Duration(offset)*time.Second))
+	}
+
+	return model.Todo{
+		Title:               todo.Title,
+		Description:         todo.Description,
+		UserID:              todo.UserID,
+		CreatedAt:           time.Now(),
+		DeadlineAt:          next,
+		DeadlineNotify:      todo.DeadlineNotify,
+		NotifyPeriod:        notifyPeriod,
+		NotifyOffsets:       todo.NotifyOffsets,
+		Priority:            todo.Priority,
+		ProjectID:           todo.ProjectID,
+		RRule:               &ruleStr,
+		RecurFromCompletion: todo.RecurFromCompletion,
+	}, true, nil
+}
+
+// notifyOffsets returns seconds from each notify period to the deadline, see Todo.NotifyOffsets
+func notifyOffsets(deadline time.Time, notifyPeriod []time.Time) []int64 {
+	var offsets []int64
+	for _, p := range notifyPeriod {
+		if p.IsZero() {
+			continue
+		}
+		offsets = append(offsets, int64(deadline.Sub(p)/time.Second))
+	}
+	return offsets
+}
+
+// expandOccurrences fills Occurrences of recurring reminds inside given range
+func expandOccurrences(reminds []model.Todo, timeRange model.TimeRangeFilter) error {
+	from, err := time.Parse(time.RFC3339, timeRange.StartRange)
+	if err != nil {
+		return fmt.Errorf("wrong occurrences range: %w", err)
+	}
+	to, err := time.Parse(time.RFC3339, timeRange.EndRange)
+	if err != nil {
+		return fmt.Errorf("wrong occurrences range: %w", err)
+	}
+
+	for i := range reminds {
+		if reminds[i].RRule == nil || reminds[i].Completed {
+			continue
+		}
+
+		rule, err := rrule.Parse(*reminds[i].RRule)
+		if err != nil {
+			return err
+		}
+
+		reminds[i].Occurrences = rule.Between(reminds[i].DeadlineAt, from, to, maxOccurrences)
+	}
+
+	return nil
+}
@@ -0,0 +1,107 @@
+package storage
+
+import (
+	"testing"
+	"time"
+
+	model "github.com/red-rocket-software/reminder-go/internal/reminder/domain"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNextOccurrence(t *testing.T) {
+	weekly := "FREQ=WEEKLY;BYDAY=MO"
+	everyThreeDays := "FREQ=DAILY;INTERVAL=3"
+	twice := "FREQ=WEEKLY;BYDAY=MO;COUNT=2"
+	once := "FREQ=WEEKLY;COUNT=1;BYDAY=MO"
+	projectID := 1
+
+	// Monday
+	deadline := time.Date(2023, time.April, 3, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		todo         model.Todo
+		finishedAt   time.Time
+		wantDeadline time.Time
+		wantRule     string
+		wantOk       bool
+	}{
+		{
+			name:         "completed in time",
+			todo:         model.Todo{DeadlineAt: deadline, RRule: &weekly, Priority: model.PriorityCritical, ProjectID: &projectID},
+			finishedAt:   deadline.Add(-time.Hour),
+			wantDeadline: deadline.AddDate(0, 0, 7),
+			wantRule:     weekly,
+			wantOk:       true,
+		},
+		{
+			name:         "completed late skips passed occurrences",
+			todo:         model.Todo{DeadlineAt: deadline, RRule: &weekly},
+			finishedAt:   deadline.AddDate(0, 0, 10),
+			wantDeadline: deadline.AddDate(0, 0, 14),
+			wantRule:     weekly,
+			wantOk:       true,
+		},
+		{
+			name:         "after completion",
+			todo:         model.Todo{DeadlineAt: deadline, RRule: &everyThreeDays, RecurFromCompletion: true},
+			finishedAt:   time.Date(2023, time.April, 5, 18, 0, 0, 0, time.UTC),
+			wantDeadline: time.Date(2023, time.April, 8, 9, 0, 0, 0, time.UTC),
+			wantRule:     everyThreeDays,
+			wantOk:       true,
+		},
+		{
+			name:         "count is decremented",
+			todo:         model.Todo{DeadlineAt: deadline, RRule: &twice},
+			finishedAt:   deadline,
+			wantDeadline: deadline.AddDate(0, 0, 7),
+			wantRule:     once,
+			wantOk:       true,
+		},
+		{
+			name:       "last occurrence",
+			todo:       model.Todo{DeadlineAt: deadline, RRule: &once},
+			finishedAt: deadline,
+			wantOk:     false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// notification was already sent, so only offset is left
+			tt.todo.NotifyOffsets = []int64{int64(time.Hour / time.Second)}
+
+			got, ok, err := nextOccurrence(tt.todo, tt.finishedAt)
+			require.NoError(t, err)
+			require.Equal(t, tt.wantOk, ok)
+			if !ok {
+				return
+			}
+			require.Equal(t, tt.wantDeadline, got.DeadlineAt)
+			require.Equal(t, tt.wantRule, *got.RRule)
+			require.Equal(t, tt.todo.Priority, got.Priority)
+			require.Equal(t, tt.todo.ProjectID, got.ProjectID)
+			require.Equal(t, []time.Time{tt.wantDeadline.Add(-time.Hour)}, got.NotifyPeriod)
+		})
+	}
+}
+
+func TestExpandOccurrences(t *testing.T) {
+	weekly := "FREQ=WEEKLY;BYDAY=MO"
+	deadline := time.Date(2023, time.April, 3, 9, 0, 0, 0, time.UTC)
+
+	reminds := []model.Todo{
+		{DeadlineAt: deadline, RRule: &weekly},
+		{DeadlineAt: deadline},
+	}
+
+	err := expandOccurrences(reminds, model.TimeRangeFilter{
+		StartRange: "2023-04-01T00:00:00Z",
+		EndRange:   "2023-04-17T23:00:00Z",
+	})
+	require.NoError(t, err)
+	require.Equal(t, []time.Time{deadline, deadline.AddDate(0, 0, 7), deadline.AddDate(0, 0, 14)}, reminds[0].Occurrences)
+	require.Empty(t, reminds[1].Occurrences)
+
+	err = expandOccurrences(reminds, model.TimeRangeFilter{StartRange: "wrong"})
+	require.Error(t, err)
+}
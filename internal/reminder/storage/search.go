@@ -0,0 +1,31 @@
+package storage
+
+import (
+	"html"
+	"strings"
+)
+
+// markStart and markStop are private use characters which wrap matched words in ts_headline,
+// they are replaced with <mark></mark> after the text is HTML escaped
+const (
+	markStart = "\ue000"
+	markStop  = "\ue001"
+)
+
+// searchQuery returns tsquery of user search text, it understands quotes, OR and -word like web search engines
+func searchQuery(placeholder string) string {
+	return `websearch_to_tsquery('simple', ` + placeholder + `)`
+}
+
+// headlineOptions are ts_headline options for title and description of remind
+var (
+	titleHeadlineOptions       = `HighlightAll=true, StartSel="` + markStart + `", StopSel="` + markStop + `"`
+	descriptionHeadlineOptions = `MaxFragments=2, MaxWords=20, MinWords=5, StartSel="` + markStart + `", StopSel="` + markStop + `"`
+)
+
+var markReplacer = strings.NewReplacer(markStart, "<mark>", markStop, "</mark>")
+
+// highlight escapes headline and marks matched words
+func highlight(headline string) string {
+	return markReplacer.Replace(html.EscapeString(headline))
+}
@@ -0,0 +1,13 @@
+package storage
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHighlight(t *testing.T) {
+	headline := `buy ` + markStart + `milk` + markStop + ` & <script>alert(1)</script>`
+
+	require.Equal(t, `buy <mark>milk</mark> &amp; &lt;script&gt;alert(1)&lt;/script&gt;`, highlight(headline))
+}
@@ -17,6 +17,9 @@ import (
 
 var testTodoStorage model.TodoRepository
 var testConfigStorage model.ConfigRepository
+var testTagStorage model.TagRepository
+var testProjectStorage model.ProjectRepository
+var testViewStorage model.ViewRepository
 var pClient *pgxpool.Pool
 
 func TestMain(m *testing.M) {
@@ -30,6 +33,9 @@ func TestMain(m *testing.M) {
 
 	testTodoStorage = NewStorageTodo(pClient, &logger)
 	testConfigStorage = NewConfigsStorage(pClient, &logger)
+	testTagStorage = NewTagsStorage(pClient, &logger)
+	testProjectStorage = NewProjectsStorage(pClient, &logger)
+	testViewStorage = NewViewsStorage(pClient, &logger)
 
 	os.Exit(m.Run())
 }
@@ -171,6 +177,7 @@ func SeedTodos() ([]model.Todo, error) {
 		if err != nil {
 			return nil, fmt.Errorf("error create remind: %v", err)
 		}
+		todos[i].Tags = []model.Tag{}
 
 	}
 
@@ -179,7 +186,7 @@ func SeedTodos() ([]model.Todo, error) {
 
 // Truncate removes all seed data from the test database.
 func Truncate() error {
-	stmt := "TRUNCATE TABLE reminder.todo, reminder.users_configs;"
+	stmt := "TRUNCATE TABLE reminder.todo_history, reminder.todo_members, reminder.todo_tags, reminder.tags, reminder.todo_items, reminder.notifications, reminder.outbox, reminder.todo, reminder.projects, reminder.views, reminder.users_configs;"
 
 	if _, err := pClient.Exec(context.Background(), stmt); err != nil {
 		return fmt.Errorf("truncate test database tables %v", err)
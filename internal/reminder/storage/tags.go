@@ -0,0 +1,212 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	model "github.com/red-rocket-software/reminder-go/internal/reminder/domain"
+	"github.com/red-rocket-software/reminder-go/pkg/logging"
+	"github.com/red-rocket-software/reminder-go/pkg/postgresql"
+)
+
+var _ model.TagRepository = (*TagStorage)(nil)
+
+// tagColumns lists reminder.tags columns in the order tagFields returns them
+const tagColumns = `"ID", "User", "Name", "Color", "CreatedAt"`
+
+// uniqueViolation is PostgreSQL error code of unique constraint violation
+const uniqueViolation = "23505"
+
+// TagStorage handles database communication with PostgreSQL.
+type TagStorage struct {
+	// Postgres database.PGX
+	Postgres *pgxpool.Pool
+	// Logrus logger
+	logger *logging.Logger
+}
+
+// NewTagsStorage  return new TagStorage with Postgres pool and logger
+func NewTagsStorage(postgres *pgxpool.Pool, logger *logging.Logger) model.TagRepository {
+	return &TagStorage{Postgres: postgres, logger: logger}
+}
+
+// tagFields returns pointers to tag fields to scan a row selected with tagColumns
+func tagFields(tag *model.Tag) []any {
+	return []any{
+		&tag.ID,
+		&tag.UserID,
+		&tag.Name,
+		&tag.Color,
+		&tag.CreatedAt,
+	}
+}
+
+// GetTags returns all user tags ordered by name
+func (s *TagStorage) GetTags(ctx context.Context, userID string) ([]model.Tag, error) {
+	tags := []model.Tag{}
+
+	const sql = `SELECT ` + tagColumns + ` FROM reminder.tags WHERE "User" = $1 ORDER BY "Name"`
+
+	rows, err := s.Postgres.Query(ctx, sql, userID)
+	if err != nil {
+		s.logger.Errorf("error get tags from db: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var tag model.Tag
+
+		if err := rows.Scan(tagFields(&tag)...); err != nil {
+			s.logger.Errorf("tag doesn't exist: %v", err)
+			return nil, err
+		}
+		tags = append(tags, tag)
+	}
+
+	return tags, rows.Err()
+}
+
+// CreateTag store new tag to DB PostgresSQL
+func (s *TagStorage) CreateTag(ctx context.Context, userID string, input model.TagInput) (model.Tag, error) {
+	var tag model.Tag
+
+	const sql = `INSERT INTO reminder.tags ("User", "Name", "Color", "CreatedAt") VALUES ($1, $2, $3, $4) RETURNING ` + tagColumns
+
+	row := s.Postgres.QueryRow(ctx, sql, userID, input.Name, input.Color, time.Now())
+	if err := row.Scan(tagFields(&tag)...); err != nil {
+		if isUniqueViolation(err) {
+			return model.Tag{}, model.ErrTagAlreadyExists
+		}
+		s.logger.Errorf("Error create tag: %v", err)
+		return model.Tag{}, err
+	}
+
+	return tag, nil
+}
+
+// UpdateTag changes name and color of tag
+func (s *TagStorage) UpdateTag(ctx context.Context, id int, userID string, input model.TagInput) (model.Tag, error) {
+	var tag model.Tag
+
+	const sql = `UPDATE reminder.tags SET "Name" = $1, "Color" = $2 WHERE "ID" = $3 AND "User" = $4 RETURNING ` + tagColumns
+
+	row := s.Postgres.QueryRow(ctx, sql, input.Name, input.Color, id, userID)
+	err := row.Scan(tagFields(&tag)...)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return model.Tag{}, model.ErrCantFindTagWithID
+	}
+	if err != nil {
+		if isUniqueViolation(err) {
+			return model.Tag{}, model.ErrTagAlreadyExists
+		}
+		s.logger.Errorf("unable to update tag: %v", err)
+		return model.Tag{}, err
+	}
+
+	return tag, nil
+}
+
+// DeleteTag deletes tag. Reminds stay, only the tag is removed from them
+func (s *TagStorage) DeleteTag(ctx context.Context, id int, userID string) error {
+	const sql = `DELETE FROM reminder.tags WHERE "ID" = $1 AND "User" = $2`
+
+	ct, err := s.Postgres.Exec(ctx, sql, id, userID)
+	if err != nil {
+		s.logger.Errorf("Error delete tag: %v", err)
+		return model.ErrDeleteFailed
+	}
+
+	if ct.RowsAffected() == 0 {
+		return model.ErrCantFindTagWithID
+	}
+
+	return nil
+}
+
+// setRemindTags replaces tags of remind. Only tags of the remind owner can be set
+func setRemindTags(ctx context.Context, db postgresql.Client, todoID int, tagIDs []int) error {
+	if _, err := db.Exec(ctx, `DELETE FROM reminder.todo_tags WHERE "TodoID" = $1`, todoID); err != nil {
+		return err
+	}
+
+	if len(tagIDs) == 0 {
+		return nil
+	}
+
+	const sql = `INSERT INTO reminder.todo_tags ("TodoID", "TagID")
+SELECT t."ID", g."ID" FROM reminder.todo t
+JOIN reminder.tags g ON g."User" = t."User"
+WHERE t."ID" = $1 AND g."ID" = ANY($2)`
+
+	ct, err := db.Exec(ctx, sql, todoID, uniqueInts(tagIDs))
+	if err != nil {
+		return err
+	}
+
+	if int(ct.RowsAffected()) != len(uniqueInts(tagIDs)) {
+		return model.ErrCantFindTagWithID
+	}
+
+	return nil
+}
+
+// attachTags loads tags of given reminds
+func attachTags(ctx context.Context, db postgresql.Client, reminds []model.Todo) error {
+	if len(reminds) == 0 {
+		return nil
+	}
+
+	ids := make([]int, len(reminds))
+	byID := make(map[int]*model.Todo, len(reminds))
+	for i := range reminds {
+		ids[i] = reminds[i].ID
+		reminds[i].Tags = []model.Tag{}
+		byID[reminds[i].ID] = &reminds[i]
+	}
+
+	const sql = `SELECT tt."TodoID", g."ID", g."User", g."Name", g."Color", g."CreatedAt" FROM reminder.todo_tags tt
+JOIN reminder.tags g ON g."ID" = tt."TagID"
+WHERE tt."TodoID" = ANY($1) ORDER BY g."Name"`
+
+	rows, err := db.Query(ctx, sql, ids)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var todoID int
+		var tag model.Tag
+
+		if err := rows.Scan(append([]any{&todoID}, tagFields(&tag)...)...); err != nil {
+			return err
+		}
+		if remind, ok := byID[todoID]; ok {
+			remind.Tags = append(remind.Tags, tag)
+		}
+	}
+
+	return rows.Err()
+}
+
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == uniqueViolation
+}
+
+func uniqueInts(values []int) []int {
+	seen := make(map[int]bool, len(values))
+	res := make([]int, 0, len(values))
+	for _, v := range values {
+		if !seen[v] {
+			seen[v] = true
+			res = append(res, v)
+		}
+	}
+	return res
+}
@@ -0,0 +1,103 @@
+package storage
+
+import (
+	"context"
+	"testing"
+
+	model "github.com/red-rocket-software/reminder-go/internal/reminder/domain"
+	"github.com/red-rocket-software/reminder-go/pkg/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStorageTodo_Tags(t *testing.T) {
+	defer func() {
+		err := Truncate()
+		require.NoError(t, err)
+	}()
+
+	expectedTodo, err := SeedTodos()
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	userID := expectedTodo[0].UserID
+
+	work, err := testTagStorage.CreateTag(ctx, userID, model.TagInput{Name: "work"})
+	require.NoError(t, err)
+	urgent, err := testTagStorage.CreateTag(ctx, userID, model.TagInput{Name: "urgent"})
+	require.NoError(t, err)
+
+	t.Run("error duplicate name", func(t *testing.T) {
+		_, err := testTagStorage.CreateTag(ctx, userID, model.TagInput{Name: "work"})
+		require.ErrorIs(t, err, model.ErrTagAlreadyExists)
+	})
+
+	t.Run("error tag of another user", func(t *testing.T) {
+		_, err := testTagStorage.UpdateTag(ctx, work.ID, "another user", model.TagInput{Name: "home"})
+		require.ErrorIs(t, err, model.ErrCantFindTagWithID)
+
+		err = testTagStorage.DeleteTag(ctx, work.ID, "another user")
+		require.ErrorIs(t, err, model.ErrCantFindTagWithID)
+	})
+
+	t.Run("set tags on remind", func(t *testing.T) {
+		todo, err := testTodoStorage.UpdateRemind(ctx, expectedTodo[0].ID, userID, model.TodoUpdateInput{
+			Title:       expectedTodo[0].Title,
+			Description: expectedTodo[0].Description,
+			DeadlineAt:  "2023-04-15T16:27:00Z",
+			Tags:        []int{work.ID, urgent.ID},
+		})
+		require.NoError(t, err)
+		require.Equal(t, []model.Tag{urgent, work}, todo.Tags)
+
+		_, err = testTodoStorage.UpdateRemind(ctx, expectedTodo[2].ID, userID, model.TodoUpdateInput{
+			Title:       expectedTodo[2].Title,
+			Description: expectedTodo[2].Description,
+			DeadlineAt:  "2023-04-15T16:27:00Z",
+			Tags:        []int{work.ID},
+		})
+		require.NoError(t, err)
+
+		_, err = testTodoStorage.UpdateRemind(ctx, expectedTodo[3].ID, userID, model.TodoUpdateInput{
+			Title:       expectedTodo[3].Title,
+			Description: expectedTodo[3].Description,
+			DeadlineAt:  "2023-04-15T16:27:00Z",
+			Tags:        []int{work.ID + urgent.ID},
+		})
+		require.ErrorIs(t, err, model.ErrCantFindTagWithID)
+	})
+
+	t.Run("filter by tags", func(t *testing.T) {
+		params := model.FetchParams{
+			Page:          utils.Page{Limit: 10},
+			FilterByDate:  "CreatedAt",
+			FilterBySort:  "ASC",
+			FilterByQuery: "all",
+			Tags:          []int{work.ID, urgent.ID},
+		}
+
+		reminds, count, _, err := testTodoStorage.GetReminds(ctx, params, userID)
+		require.NoError(t, err)
+		require.Equal(t, 2, count)
+		require.Len(t, reminds, 2)
+
+		params.TagsMatch = model.TagsMatchAll
+		reminds, count, _, err = testTodoStorage.GetReminds(ctx, params, userID)
+		require.NoError(t, err)
+		require.Equal(t, 1, count)
+		require.Equal(t, expectedTodo[0].ID, reminds[0].ID)
+		require.Len(t, reminds[0].Tags, 2)
+	})
+
+	t.Run("delete tag keeps reminds", func(t *testing.T) {
+		err := testTagStorage.DeleteTag(ctx, urgent.ID, userID)
+		require.NoError(t, err)
+
+		todo, err := testTodoStorage.GetRemindByID(ctx, expectedTodo[0].ID, userID)
+		require.NoError(t, err)
+		require.Equal(t, []model.Tag{work}, todo.Tags)
+
+		tags, err := testTagStorage.GetTags(ctx, userID)
+		require.NoError(t, err)
+		require.Equal(t, []model.Tag{work}, tags)
+	})
+}
@@ -0,0 +1,163 @@
+package storage
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"time"
+
+	model "github.com/red-rocket-software/reminder-go/internal/reminder/domain"
+	"github.com/red-rocket-software/reminder-go/pkg/postgresql"
+)
+
+// historyColumns lists reminder.todo_history columns in the order historyFields returns them
+const historyColumns = `"ID", "TodoID", "Actor", "Action", "Changes", "CreatedAt"`
+
+// historyFields returns pointers to history entry fields to scan a row selected with historyColumns
+func historyFields(entry *model.HistoryEntry) []any {
+	return []any{
+		&entry.ID,
+		&entry.TodoID,
+		&entry.Actor,
+		&entry.Action,
+		&entry.Changes,
+		&entry.CreatedAt,
+	}
+}
+
+// GetHistory returns changes of remind, oldest first. The remind author sees history after
+// the remind is deleted, members see it while the remind is shared with them
+func (s *TodoStorage) GetHistory(ctx context.Context, todoID int, userID string) ([]model.HistoryEntry, error) {
+	history := []model.HistoryEntry{}
+
+	const sql = `SELECT ` + historyColumns + ` FROM reminder.todo_history
+WHERE "TodoID" = $1 AND ("Owner" = $2 OR "TodoID" IN (SELECT "TodoID" FROM reminder.todo_members WHERE "User" = $2)) ORDER BY "ID"`
+
+	rows, err := s.Postgres.Query(ctx, sql, todoID, userID)
+	if err != nil {
+		s.logger.Errorf("error get remind history from db: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var entry model.HistoryEntry
+
+		if err := rows.Scan(historyFields(&entry)...); err != nil {
+			s.logger.Errorf("history entry doesn't exist: %v", err)
+			return nil, err
+		}
+		history = append(history, entry)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(history) == 0 {
+		// remind without history, e.g. created before history was recorded, is told apart from not available one
+		if err := s.checkRole(ctx, todoID, userID, model.RoleViewer); err != nil {
+			return nil, err
+		}
+	}
+
+	return history, nil
+}
+
+// recordHistory stores change of remind using given pool or transaction, owner is the remind author
+func recordHistory(ctx context.Context, db postgresql.Client, todoID int, owner, actor string, action model.HistoryAction, changes map[string]model.FieldChange) error {
+	if changes == nil {
+		changes = map[string]model.FieldChange{}
+	}
+
+	const sql = `INSERT INTO reminder.todo_history ("TodoID", "Owner", "Actor", "Action", "Changes", "CreatedAt") VALUES ($1, $2, $3, $4, $5, $6)`
+
+	_, err := db.Exec(ctx, sql, todoID, owner, actor, action, changes, time.Now())
+	return err
+}
+
+// remindValues returns fields of remind which are recorded in history keyed by their json names.
+// Times are formatted in UTC so values read from db and parsed from input are compared equally
+func remindValues(todo model.Todo) map[string]any {
+	var notifyPeriod []string
+	for _, t := range todo.NotifyPeriod {
+		notifyPeriod = append(notifyPeriod, formatTime(t))
+	}
+
+	var tags []int
+	for _, tag := range todo.Tags {
+		tags = append(tags, tag.ID)
+	}
+	sort.Ints(tags)
+
+	values := map[string]any{
+		"title":                 todo.Title,
+		"description":           todo.Description,
+		"deadline_at":           formatTime(todo.DeadlineAt),
+		"completed":             todo.Completed,
+		"notificated":           todo.Notificated,
+		"notify_period":         notifyPeriod,
+		"priority":              todo.Priority,
+		"recur_from_completion": todo.RecurFromCompletion,
+		"tags":                  tags,
+		"finished_at":           nil,
+		"deadline_notify":       nil,
+		"project_id":            nil,
+		"rrule":                 nil,
+	}
+	if todo.FinishedAt != nil {
+		values["finished_at"] = formatTime(*todo.FinishedAt)
+	}
+	if todo.DeadlineNotify != nil {
+		values["deadline_notify"] = *todo.DeadlineNotify
+	}
+	if todo.ProjectID != nil {
+		values["project_id"] = *todo.ProjectID
+	}
+	if todo.RRule != nil {
+		values["rrule"] = *todo.RRule
+	}
+
+	return values
+}
+
+// todoChanges returns fields which differ in old and new values of remind, nil old values record creation
+func todoChanges(old, new map[string]any) map[string]model.FieldChange {
+	changes := map[string]model.FieldChange{}
+
+	for field, value := range new {
+		if isEmptyValue(old[field]) && isEmptyValue(value) {
+			continue
+		}
+		if !reflect.DeepEqual(old[field], value) {
+			changes[field] = model.FieldChange{From: old[field], To: value}
+		}
+	}
+
+	return changes
+}
+
+// isEmptyValue reports whether value is nil or nil slice, they mean the same for history
+func isEmptyValue(value any) bool {
+	if value == nil {
+		return true
+	}
+	v := reflect.ValueOf(value)
+	return v.Kind() == reflect.Slice && v.Len() == 0
+}
+
+func formatTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
+
+// deletedChanges returns change of remind moved to trash or restored from it
+func deletedChanges(from, to *time.Time) map[string]model.FieldChange {
+	change := model.FieldChange{}
+	if from != nil {
+		change.From = formatTime(*from)
+	}
+	if to != nil {
+		change.To = formatTime(*to)
+	}
+
+	return map[string]model.FieldChange{"deleted_at": change}
+}
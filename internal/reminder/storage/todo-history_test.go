@@ -0,0 +1,99 @@
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	model "github.com/red-rocket-software/reminder-go/internal/reminder/domain"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTodoChanges(t *testing.T) {
+	deadline := time.Date(2023, time.April, 15, 16, 27, 0, 0, time.UTC)
+	projectID := 1
+
+	old := model.Todo{Title: "title", DeadlineAt: deadline, Priority: model.PriorityLow}
+	// the same deadline in another location isn't a change
+	updated := model.Todo{Title: "new title", DeadlineAt: deadline.In(time.FixedZone("EEST", 3*60*60)), Priority: model.PriorityHigh, ProjectID: &projectID}
+
+	require.Equal(t, map[string]model.FieldChange{
+		"title":      {From: "title", To: "new title"},
+		"priority":   {From: model.PriorityLow, To: model.PriorityHigh},
+		"project_id": {From: nil, To: 1},
+	}, todoChanges(remindValues(old), remindValues(updated)))
+
+	require.Empty(t, todoChanges(remindValues(old), remindValues(old)))
+
+	created := todoChanges(nil, remindValues(old))
+	require.Equal(t, model.FieldChange{From: nil, To: "title"}, created["title"])
+	require.Equal(t, model.FieldChange{From: nil, To: "2023-04-15T16:27:00Z"}, created["deadline_at"])
+	require.NotContains(t, created, "project_id")
+	require.NotContains(t, created, "tags")
+}
+
+func TestStorageTodo_History(t *testing.T) {
+	defer func() {
+		err := Truncate()
+		require.NoError(t, err)
+	}()
+
+	userID, err := SeedUserConfig()
+	require.NoError(t, err)
+
+	ctx := context.Background()
+
+	todo, err := testTodoStorage.CreateRemind(ctx, model.Todo{
+		Title:      "title",
+		UserID:     userID,
+		CreatedAt:  time.Now(),
+		DeadlineAt: time.Date(2023, time.April, 15, 16, 27, 0, 0, time.UTC),
+	})
+	require.NoError(t, err)
+
+	_, err = testTodoStorage.UpdateRemind(ctx, todo.ID, userID, model.TodoUpdateInput{Title: "new title", DeadlineAt: "2023-04-15T16:27:00Z"})
+	require.NoError(t, err)
+
+	err = testTodoStorage.UpdateStatus(ctx, todo.ID, userID, model.TodoUpdateStatusInput{Completed: true})
+	require.NoError(t, err)
+
+	err = testTodoStorage.UpdateNotification(ctx, todo.ID, userID, model.NotificationDAO{Notificated: true})
+	require.NoError(t, err)
+
+	err = testTodoStorage.DeleteRemind(ctx, todo.ID, userID)
+	require.NoError(t, err)
+
+	err = testTodoStorage.PurgeRemind(ctx, todo.ID, userID)
+	require.NoError(t, err)
+
+	t.Run("history survives remind deletion", func(t *testing.T) {
+		history, err := testTodoStorage.GetHistory(ctx, todo.ID, userID)
+		require.NoError(t, err)
+		require.Len(t, history, 6)
+
+		actions := make([]model.HistoryAction, len(history))
+		for i, entry := range history {
+			actions[i] = entry.Action
+		}
+		require.Equal(t, []model.HistoryAction{
+			model.HistoryCreate,
+			model.HistoryUpdate,
+			model.HistoryStatus,
+			model.HistoryNotification,
+			model.HistoryDelete,
+			model.HistoryPurge,
+		}, actions)
+
+		require.Equal(t, userID, history[1].Actor)
+		require.Equal(t, model.FieldChange{From: "title", To: "new title"}, history[1].Changes["title"])
+		require.Equal(t, model.FieldChange{From: false, To: true}, history[2].Changes["completed"])
+		require.Equal(t, model.ActorSystem, history[3].Actor)
+		require.Nil(t, history[4].Changes["deleted_at"].From)
+		require.NotNil(t, history[4].Changes["deleted_at"].To)
+	})
+
+	t.Run("history of remind of another user", func(t *testing.T) {
+		_, err := testTodoStorage.GetHistory(ctx, todo.ID, "another user")
+		require.ErrorIs(t, err, model.ErrCantFindRemindWithID)
+	})
+}
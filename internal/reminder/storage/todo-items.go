@@ -0,0 +1,129 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	model "github.com/red-rocket-software/reminder-go/internal/reminder/domain"
+)
+
+// todoItemColumns lists reminder.todo_items columns in the order todoItemFields returns them
+const todoItemColumns = `"ID", "TodoID", "Title", "Completed", "DeadlineAt", "Position", "CreatedAt"`
+
+// progressColumns selects checklist progress of the remind selected from reminder.todo
+const progressColumns = `(SELECT COUNT(*) FROM reminder.todo_items i WHERE i."TodoID" = todo."ID" AND i."Completed"),
+(SELECT COUNT(*) FROM reminder.todo_items i WHERE i."TodoID" = todo."ID")`
+
+// todoItemFields returns pointers to item fields to scan a row selected with todoItemColumns
+func todoItemFields(item *model.TodoItem) []any {
+	return []any{
+		&item.ID,
+		&item.TodoID,
+		&item.Title,
+		&item.Completed,
+		&item.DeadlineAt,
+		&item.Position,
+		&item.CreatedAt,
+	}
+}
+
+// progressFields returns pointers to progress fields to scan values selected with progressColumns
+func progressFields(todo *model.Todo) []any {
+	return []any{&todo.Progress.Done, &todo.Progress.Total}
+}
+
+// GetItems returns checklist of remind ordered by position
+func (s *TodoStorage) GetItems(ctx context.Context, todoID int, userID string) ([]model.TodoItem, error) {
+	if err := s.checkRole(ctx, todoID, userID, model.RoleViewer); err != nil {
+		return nil, err
+	}
+
+	return s.getItems(ctx, todoID)
+}
+
+func (s *TodoStorage) getItems(ctx context.Context, todoID int) ([]model.TodoItem, error) {
+	items := []model.TodoItem{}
+
+	const sql = `SELECT ` + todoItemColumns + ` FROM reminder.todo_items WHERE "TodoID" = $1 ORDER BY "Position", "ID"`
+
+	rows, err := s.Postgres.Query(ctx, sql, todoID)
+	if err != nil {
+		s.logger.Errorf("error get checklist items from db: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var item model.TodoItem
+
+		if err := rows.Scan(todoItemFields(&item)...); err != nil {
+			s.logger.Errorf("checklist item doesn't exist: %v", err)
+			return nil, err
+		}
+		items = append(items, item)
+	}
+
+	return items, rows.Err()
+}
+
+// CreateItem adds item to remind checklist. Without position the item is added to the end
+func (s *TodoStorage) CreateItem(ctx context.Context, todoID int, userID string, input model.TodoItemInput) (model.TodoItem, error) {
+	var item model.TodoItem
+
+	sql := `INSERT INTO reminder.todo_items ("TodoID", "Title", "Completed", "DeadlineAt", "Position", "CreatedAt")
+SELECT "ID", $2, $3, $4, COALESCE($5, (SELECT COALESCE(MAX("Position") + 1, 0) FROM reminder.todo_items WHERE "TodoID" = $1)), $6
+FROM reminder.todo WHERE "ID" = $1 AND "ID" IN (` + remindsWithRole("$7", model.RoleEditor) + `)
+RETURNING ` + todoItemColumns
+
+	row := s.Postgres.QueryRow(ctx, sql, todoID, input.Title, input.Completed, input.DeadlineAt, input.Position, time.Now(), userID)
+	err := row.Scan(todoItemFields(&item)...)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return model.TodoItem{}, model.ErrCantFindRemindWithID
+	}
+	if err != nil {
+		s.logger.Errorf("Error create checklist item: %v", err)
+		return model.TodoItem{}, err
+	}
+
+	return item, nil
+}
+
+// UpdateItem updates checklist item. Position is kept if it isn't passed
+func (s *TodoStorage) UpdateItem(ctx context.Context, todoID, itemID int, userID string, input model.TodoItemInput) (model.TodoItem, error) {
+	var item model.TodoItem
+
+	sql := `UPDATE reminder.todo_items SET "Title" = $1, "Completed" = $2, "DeadlineAt" = $3, "Position" = COALESCE($4, "Position")
+WHERE "ID" = $5 AND "TodoID" = $6 AND "TodoID" IN (` + remindsWithRole("$7", model.RoleEditor) + `)
+RETURNING ` + todoItemColumns
+
+	row := s.Postgres.QueryRow(ctx, sql, input.Title, input.Completed, input.DeadlineAt, input.Position, itemID, todoID, userID)
+	err := row.Scan(todoItemFields(&item)...)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return model.TodoItem{}, model.ErrCantFindItemWithID
+	}
+	if err != nil {
+		s.logger.Errorf("unable to update checklist item: %v", err)
+		return model.TodoItem{}, err
+	}
+
+	return item, nil
+}
+
+// DeleteItem removes item from remind checklist
+func (s *TodoStorage) DeleteItem(ctx context.Context, todoID, itemID int, userID string) error {
+	sql := `DELETE FROM reminder.todo_items WHERE "ID" = $1 AND "TodoID" = $2 AND "TodoID" IN (` + remindsWithRole("$3", model.RoleEditor) + `)`
+
+	ct, err := s.Postgres.Exec(ctx, sql, itemID, todoID, userID)
+	if err != nil {
+		s.logger.Errorf("Error delete checklist item: %v", err)
+		return model.ErrDeleteFailed
+	}
+
+	if ct.RowsAffected() == 0 {
+		return model.ErrCantFindItemWithID
+	}
+
+	return nil
+}
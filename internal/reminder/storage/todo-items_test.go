@@ -0,0 +1,84 @@
+package storage
+
+import (
+	"context"
+	"testing"
+
+	model "github.com/red-rocket-software/reminder-go/internal/reminder/domain"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStorageTodo_Items(t *testing.T) {
+	defer func() {
+		err := Truncate()
+		require.NoError(t, err)
+	}()
+
+	expectedTodo, err := SeedTodos()
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	todoID := expectedTodo[0].ID
+	userID := expectedTodo[0].UserID
+
+	first, err := testTodoStorage.CreateItem(ctx, todoID, userID, model.TodoItemInput{Title: "first"})
+	require.NoError(t, err)
+	require.Equal(t, 0, first.Position)
+
+	second, err := testTodoStorage.CreateItem(ctx, todoID, userID, model.TodoItemInput{Title: "second", Completed: true})
+	require.NoError(t, err)
+	require.Equal(t, 1, second.Position)
+
+	t.Run("error remind of another user", func(t *testing.T) {
+		_, err := testTodoStorage.CreateItem(ctx, todoID, "another user", model.TodoItemInput{Title: "item"})
+		require.ErrorIs(t, err, model.ErrCantFindRemindWithID)
+
+		_, err = testTodoStorage.GetItems(ctx, todoID, "another user")
+		require.ErrorIs(t, err, model.ErrCantFindRemindWithID)
+	})
+
+	t.Run("get items and progress", func(t *testing.T) {
+		items, err := testTodoStorage.GetItems(ctx, todoID, userID)
+		require.NoError(t, err)
+		require.Equal(t, []model.TodoItem{first, second}, items)
+
+		remind, err := testTodoStorage.GetRemindByID(ctx, todoID, userID)
+		require.NoError(t, err)
+		require.Equal(t, model.Progress{Done: 1, Total: 2}, remind.Progress)
+		require.Len(t, remind.Items, 2)
+	})
+
+	t.Run("update item", func(t *testing.T) {
+		position := 5
+		item, err := testTodoStorage.UpdateItem(ctx, todoID, first.ID, userID, model.TodoItemInput{Title: "updated", Completed: true, Position: &position})
+		require.NoError(t, err)
+		require.Equal(t, "updated", item.Title)
+		require.Equal(t, 5, item.Position)
+
+		_, err = testTodoStorage.UpdateItem(ctx, expectedTodo[1].ID, first.ID, userID, model.TodoItemInput{Title: "updated"})
+		require.ErrorIs(t, err, model.ErrCantFindItemWithID)
+	})
+
+	t.Run("block completion with incomplete items", func(t *testing.T) {
+		_, err := testTodoStorage.CreateItem(ctx, todoID, userID, model.TodoItemInput{Title: "third"})
+		require.NoError(t, err)
+
+		err = testTodoStorage.UpdateStatus(ctx, todoID, userID, model.TodoUpdateStatusInput{Completed: true, Children: model.ChildrenBlock})
+		require.ErrorIs(t, err, model.ErrIncompleteItems)
+
+		err = testTodoStorage.UpdateStatus(ctx, todoID, userID, model.TodoUpdateStatusInput{Completed: true, Children: model.ChildrenComplete})
+		require.NoError(t, err)
+
+		remind, err := testTodoStorage.GetRemindByID(ctx, todoID, userID)
+		require.NoError(t, err)
+		require.Equal(t, model.Progress{Done: 3, Total: 3}, remind.Progress)
+	})
+
+	t.Run("delete item", func(t *testing.T) {
+		err := testTodoStorage.DeleteItem(ctx, todoID, second.ID, userID)
+		require.NoError(t, err)
+
+		err = testTodoStorage.DeleteItem(ctx, todoID, second.ID, userID)
+		require.ErrorIs(t, err, model.ErrCantFindItemWithID)
+	})
+}
@@ -0,0 +1,197 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	model "github.com/red-rocket-software/reminder-go/internal/reminder/domain"
+)
+
+// memberColumns lists reminder.todo_members columns in the order memberFields returns them
+const memberColumns = `"ID", "TodoID", "User", "Email", "Role", "Notify", "CreatedAt"`
+
+// memberFields returns pointers to member fields to scan a row selected with memberColumns
+func memberFields(member *model.Member) []any {
+	return []any{
+		&member.ID,
+		&member.TodoID,
+		&member.UserID,
+		&member.Email,
+		&member.Role,
+		&member.Notify,
+		&member.CreatedAt,
+	}
+}
+
+// notifiedMembers selects ids of members of remind t who opted in to its notifications
+const notifiedMembers = `ARRAY(SELECT m."User" FROM reminder.todo_members m WHERE m."TodoID" = t."ID" AND m."User" IS NOT NULL AND m."Notify" ORDER BY m."ID")`
+
+// remindsWithRole returns subquery which selects ids of reminds available to user passed in arg placeholder
+// with at least given role. The remind author is an owner of it. Reminds in trash aren't available
+func remindsWithRole(arg string, role model.Role) string {
+	return remindsInWithRole(`"DeletedAt" IS NULL`, arg, role)
+}
+
+// trashWithRole is like remindsWithRole but selects reminds in trash
+func trashWithRole(arg string, role model.Role) string {
+	return remindsInWithRole(`"DeletedAt" IS NOT NULL`, arg, role)
+}
+
+// remindsInWithRole selects ids of reminds matching condition which are available to user with given role
+func remindsInWithRole(condition, arg string, role model.Role) string {
+	var roles []string
+	for _, r := range []model.Role{model.RoleViewer, model.RoleEditor, model.RoleOwner} {
+		if r.Includes(role) {
+			roles = append(roles, `'`+string(r)+`'`)
+		}
+	}
+
+	sql := `SELECT "ID" FROM reminder.todo WHERE ` + condition + ` AND ("User" = ` + arg + `
+OR "ID" IN (SELECT "TodoID" FROM reminder.todo_members WHERE "User" = ` + arg + ` AND "Role" IN (`
+	for i, r := range roles {
+		if i > 0 {
+			sql += `, `
+		}
+		sql += r
+	}
+
+	return sql + `)))`
+}
+
+// GetMembers returns members of remind, any member can see others
+func (s *TodoStorage) GetMembers(ctx context.Context, todoID int, userID string) ([]model.Member, error) {
+	if err := s.checkRole(ctx, todoID, userID, model.RoleViewer); err != nil {
+		return nil, err
+	}
+
+	members := []model.Member{}
+
+	const sql = `SELECT ` + memberColumns + ` FROM reminder.todo_members WHERE "TodoID" = $1 ORDER BY "ID"`
+
+	rows, err := s.Postgres.Query(ctx, sql, todoID)
+	if err != nil {
+		s.logger.Errorf("error get remind members from db: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var member model.Member
+
+		if err := rows.Scan(memberFields(&member)...); err != nil {
+			s.logger.Errorf("member doesn't exist: %v", err)
+			return nil, err
+		}
+		members = append(members, member)
+	}
+
+	return members, rows.Err()
+}
+
+// AddMember shares remind with user, only owners can do it
+func (s *TodoStorage) AddMember(ctx context.Context, todoID int, userID string, input model.MemberInput) (model.Member, error) {
+	var member model.Member
+
+	notify := true
+	if input.Notify != nil {
+		notify = *input.Notify
+	}
+
+	sql := `INSERT INTO reminder.todo_members ("TodoID", "User", "Email", "Role", "Notify", "CreatedAt")
+SELECT "ID", $2, $3, $4, $5, $6 FROM reminder.todo WHERE "ID" = $1 AND "ID" IN (` + remindsWithRole("$7", model.RoleOwner) + `)
+RETURNING ` + memberColumns
+
+	row := s.Postgres.QueryRow(ctx, sql, todoID, input.UserID, input.Email, input.Role, notify, time.Now(), userID)
+	err := row.Scan(memberFields(&member)...)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return model.Member{}, model.ErrCantFindRemindWithID
+	}
+	if err != nil {
+		if isUniqueViolation(err) {
+			return model.Member{}, model.ErrMemberAlreadyExists
+		}
+		s.logger.Errorf("Error add remind member: %v", err)
+		return model.Member{}, err
+	}
+
+	return member, nil
+}
+
+// UpdateMember changes member role and notification flag, only owners can do it
+func (s *TodoStorage) UpdateMember(ctx context.Context, todoID, memberID int, userID string, input model.MemberInput) (model.Member, error) {
+	if err := s.checkRole(ctx, todoID, userID, model.RoleOwner); err != nil {
+		return model.Member{}, err
+	}
+
+	var member model.Member
+
+	const sql = `UPDATE reminder.todo_members SET "Role" = $1, "Notify" = COALESCE($2, "Notify")
+WHERE "ID" = $3 AND "TodoID" = $4 RETURNING ` + memberColumns
+
+	row := s.Postgres.QueryRow(ctx, sql, input.Role, input.Notify, memberID, todoID)
+	err := row.Scan(memberFields(&member)...)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return model.Member{}, model.ErrCantFindMemberWithID
+	}
+	if err != nil {
+		s.logger.Errorf("unable to update remind member: %v", err)
+		return model.Member{}, err
+	}
+
+	return member, nil
+}
+
+// DeleteMember stops sharing remind with member. Owners can remove anyone, other members only themselves
+func (s *TodoStorage) DeleteMember(ctx context.Context, todoID, memberID int, userID string) error {
+	if err := s.checkRole(ctx, todoID, userID, model.RoleViewer); err != nil {
+		return err
+	}
+
+	sql := `DELETE FROM reminder.todo_members WHERE "ID" = $1 AND "TodoID" = $2
+AND ("User" = $3 OR "TodoID" IN (` + remindsWithRole("$3", model.RoleOwner) + `))`
+
+	ct, err := s.Postgres.Exec(ctx, sql, memberID, todoID, userID)
+	if err != nil {
+		s.logger.Errorf("Error delete remind member: %v", err)
+		return model.ErrDeleteFailed
+	}
+
+	if ct.RowsAffected() == 0 {
+		return model.ErrCantFindMemberWithID
+	}
+
+	return nil
+}
+
+// ResolveInvites sets user to invites sent to user email. Invites to reminds which are
+// already shared with the user by id are left untouched
+func (s *TodoStorage) ResolveInvites(ctx context.Context, userID, email string) error {
+	const sql = `UPDATE reminder.todo_members SET "User" = $1
+WHERE "User" IS NULL AND lower("Email") = lower($2)
+AND "TodoID" NOT IN (SELECT "TodoID" FROM reminder.todo_members WHERE "User" = $1)`
+
+	if _, err := s.Postgres.Exec(ctx, sql, userID, email); err != nil {
+		s.logger.Errorf("unable to resolve invites: %v", err)
+		return err
+	}
+
+	return nil
+}
+
+// checkRole returns ErrCantFindRemindWithID if user hasn't got the role in remind
+func (s *TodoStorage) checkRole(ctx context.Context, todoID int, userID string, role model.Role) error {
+	var exists bool
+
+	row := s.Postgres.QueryRow(ctx, `SELECT $1 IN (`+remindsWithRole("$2", role)+`)`, todoID, userID)
+	if err := row.Scan(&exists); err != nil {
+		s.logger.Errorf("error to check remind: %v", err)
+		return err
+	}
+	if !exists {
+		return model.ErrCantFindRemindWithID
+	}
+
+	return nil
+}
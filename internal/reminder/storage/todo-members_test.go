@@ -0,0 +1,158 @@
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	model "github.com/red-rocket-software/reminder-go/internal/reminder/domain"
+	"github.com/red-rocket-software/reminder-go/pkg/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStorageTodo_Members(t *testing.T) {
+	defer func() {
+		err := Truncate()
+		require.NoError(t, err)
+	}()
+
+	expectedTodo, err := SeedTodos()
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	ownerID := expectedTodo[0].UserID
+	memberID := "member"
+	todo := expectedTodo[0]
+
+	params := model.FetchParams{
+		Page:          utils.Page{Limit: 10},
+		FilterByDate:  "CreatedAt",
+		FilterBySort:  "ASC",
+		FilterByQuery: "all",
+	}
+
+	update := model.TodoUpdateInput{
+		Title:       "shared",
+		Description: todo.Description,
+		DeadlineAt:  "2023-04-15T16:27:00Z",
+	}
+
+	var member model.Member
+
+	t.Run("remind isn't available before sharing", func(t *testing.T) {
+		_, err := testTodoStorage.GetRemindByID(ctx, todo.ID, memberID)
+		require.ErrorIs(t, err, model.ErrCantFindRemindWithID)
+
+		_, err = testTodoStorage.GetMembers(ctx, todo.ID, memberID)
+		require.ErrorIs(t, err, model.ErrCantFindRemindWithID)
+	})
+
+	t.Run("share with viewer", func(t *testing.T) {
+		member, err = testTodoStorage.AddMember(ctx, todo.ID, ownerID, model.MemberInput{UserID: &memberID, Role: model.RoleViewer})
+		require.NoError(t, err)
+		require.Equal(t, todo.ID, member.TodoID)
+		require.Equal(t, &memberID, member.UserID)
+		require.True(t, member.Notify)
+
+		_, err = testTodoStorage.AddMember(ctx, todo.ID, ownerID, model.MemberInput{UserID: &memberID, Role: model.RoleEditor})
+		require.ErrorIs(t, err, model.ErrMemberAlreadyExists)
+
+		got, err := testTodoStorage.GetRemindByID(ctx, todo.ID, memberID)
+		require.NoError(t, err)
+		require.Equal(t, ownerID, got.UserID)
+
+		reminds, count, _, err := testTodoStorage.GetReminds(ctx, params, memberID)
+		require.NoError(t, err)
+		require.Equal(t, 1, count)
+		require.Equal(t, todo.ID, reminds[0].ID)
+
+		members, err := testTodoStorage.GetMembers(ctx, todo.ID, memberID)
+		require.NoError(t, err)
+		require.Len(t, members, 1)
+	})
+
+	t.Run("viewer can't change remind", func(t *testing.T) {
+		_, err := testTodoStorage.UpdateRemind(ctx, todo.ID, memberID, update)
+		require.ErrorIs(t, err, model.ErrCantFindRemindWithID)
+
+		_, err = testTodoStorage.AddMember(ctx, todo.ID, memberID, model.MemberInput{UserID: &ownerID, Role: model.RoleViewer})
+		require.ErrorIs(t, err, model.ErrCantFindRemindWithID)
+	})
+
+	t.Run("editor can change but not delete remind", func(t *testing.T) {
+		notify := false
+		member, err = testTodoStorage.UpdateMember(ctx, todo.ID, member.ID, ownerID, model.MemberInput{Role: model.RoleEditor, Notify: &notify})
+		require.NoError(t, err)
+		require.Equal(t, model.RoleEditor, member.Role)
+		require.False(t, member.Notify)
+
+		updated, err := testTodoStorage.UpdateRemind(ctx, todo.ID, memberID, update)
+		require.NoError(t, err)
+		require.Equal(t, "shared", updated.Title)
+		require.Equal(t, ownerID, updated.UserID)
+
+		err = testTodoStorage.DeleteRemind(ctx, todo.ID, memberID)
+		require.ErrorIs(t, err, model.ErrCantFindRemindWithID)
+
+		_, err = testTodoStorage.UpdateMember(ctx, todo.ID, member.ID, memberID, model.MemberInput{Role: model.RoleOwner})
+		require.ErrorIs(t, err, model.ErrCantFindRemindWithID)
+	})
+
+	t.Run("invite is resolved on login", func(t *testing.T) {
+		email := "invited@example.com"
+		invitedID := "invited"
+
+		invite, err := testTodoStorage.AddMember(ctx, todo.ID, ownerID, model.MemberInput{Email: &email, Role: model.RoleViewer})
+		require.NoError(t, err)
+		require.Nil(t, invite.UserID)
+
+		_, err = testTodoStorage.GetRemindByID(ctx, todo.ID, invitedID)
+		require.ErrorIs(t, err, model.ErrCantFindRemindWithID)
+
+		err = testTodoStorage.ResolveInvites(ctx, invitedID, "Invited@Example.com")
+		require.NoError(t, err)
+
+		_, err = testTodoStorage.GetRemindByID(ctx, todo.ID, invitedID)
+		require.NoError(t, err)
+	})
+
+	t.Run("member leaves remind", func(t *testing.T) {
+		err := testTodoStorage.DeleteMember(ctx, todo.ID, member.ID, memberID)
+		require.NoError(t, err)
+
+		_, err = testTodoStorage.GetRemindByID(ctx, todo.ID, memberID)
+		require.ErrorIs(t, err, model.ErrCantFindRemindWithID)
+
+		err = testTodoStorage.DeleteMember(ctx, todo.ID, member.ID, ownerID)
+		require.ErrorIs(t, err, model.ErrCantFindMemberWithID)
+	})
+}
+
+func TestStorage_ClaimDueNotifications_Members(t *testing.T) {
+	defer func() {
+		err := Truncate()
+		require.NoError(t, err)
+	}()
+
+	expectedReminds, err := SeedTodosForDeadline()
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	ownerID := expectedReminds[0].UserID
+	notified := "notified"
+	muted := "muted"
+	notify := false
+
+	_, err = testTodoStorage.AddMember(ctx, expectedReminds[0].ID, ownerID, model.MemberInput{UserID: &notified, Role: model.RoleViewer})
+	require.NoError(t, err)
+	_, err = testTodoStorage.AddMember(ctx, expectedReminds[0].ID, ownerID, model.MemberInput{UserID: &muted, Role: model.RoleViewer, Notify: &notify})
+	require.NoError(t, err)
+
+	now := time.Now()
+	require.NoError(t, testTodoStorage.ScheduleNotifications(ctx, now))
+
+	notifications, err := testTodoStorage.ClaimDueNotifications(ctx, "worker", now, time.Minute, 10)
+	require.NoError(t, err)
+	require.Len(t, notifications, 1)
+	require.Equal(t, []string{notified}, notifications[0].Remind.MemberIDs)
+}
@@ -3,17 +3,46 @@ package storage
 import (
 	"context"
 	"errors"
-	"fmt"
+	"strings"
 	"time"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	model "github.com/red-rocket-software/reminder-go/internal/reminder/domain"
 	"github.com/red-rocket-software/reminder-go/pkg/logging"
+	"github.com/red-rocket-software/reminder-go/pkg/postgresql"
 )
 
 var _ model.TodoRepository = (*TodoStorage)(nil)
 
+// todoColumns lists reminder.todo columns in the order todoFields returns them
+const todoColumns = `"ID", "User", "Title", "Description", "CreatedAt", "DeadlineAt", "FinishedAt", "Completed", "Notificated", "DeadlineNotify", "NotifyPeriod", "NotifyOffsets", "Priority", "ProjectID", "RRule", "RecurFromCompletion", "DeletedAt", "Version", "OverdueDismissedAt"`
+
+// todoFields returns pointers to remind fields to scan a row selected with todoColumns
+func todoFields(todo *model.Todo) []any {
+	return []any{
+		&todo.ID,
+		&todo.UserID,
+		&todo.Title,
+		&todo.Description,
+		&todo.CreatedAt,
+		&todo.DeadlineAt,
+		&todo.FinishedAt,
+		&todo.Completed,
+		&todo.Notificated,
+		&todo.DeadlineNotify,
+		&todo.NotifyPeriod,
+		&todo.NotifyOffsets,
+		&todo.Priority,
+		&todo.ProjectID,
+		&todo.RRule,
+		&todo.RecurFromCompletion,
+		&todo.DeletedAt,
+		&todo.Version,
+		&todo.OverdueDismissedAt,
+	}
+}
+
 // TodoStorage handles database communication with PostgreSQL.
 type TodoStorage struct {
 	// Postgres database.PGX
@@ -30,41 +59,92 @@ func NewStorageTodo(postgres *pgxpool.Pool, logger *logging.Logger) model.TodoRe
 // GetReminds return all todos in DB PostgreSQL
 func (s *TodoStorage) GetReminds(ctx context.Context, params model.FetchParams, userID string) ([]model.Todo, int, int, error) {
 	var reminds []model.Todo
-	var sql string
+	now := time.Now()
+
+	var q query
+	// user gets own reminds and reminds shared with them
+	q.where(`"ID" IN (` + remindsWithRole(q.arg(userID), model.RoleViewer) + `)`)
 
 	switch params.FilterByQuery {
 	case "current":
-		sql = fmt.Sprintf(`SELECT *, (
-SELECT COUNT(*) FROM reminder.todo WHERE "User" = '%s' AND "Completed" = false) as total_count
-FROM reminder.todo WHERE "User" = '%s' AND "Completed" = false`, userID, userID)
+		q.where(`"Completed" = false`)
 	case "completed":
-		sql = fmt.Sprintf(`SELECT *, (
-SELECT COUNT(*) FROM reminder.todo WHERE "User" = '%s' AND "Completed" = true) as total_count
-FROM reminder.todo WHERE "User" = '%s' AND "Completed" = true`, userID, userID)
+		q.where(`"Completed" = true`)
+	case "overdue":
+		q.where(`"Completed" = false AND "DeadlineAt" < ` + q.arg(now.UTC()))
 	case "all":
-		sql = fmt.Sprintf(`SELECT *, (
-SELECT COUNT(*) FROM reminder.todo WHERE "User" = '%s') as total_count
-FROM reminder.todo WHERE "User" = '%s'`, userID, userID)
 	default:
 		return nil, 0, 0, errors.New("wrong filterParams value")
 	}
 
+	if len(params.Tags) > 0 {
+		tags := uniqueInts(params.Tags)
+
+		switch params.TagsMatch {
+		case model.TagsMatchAll:
+			q.where(`"ID" IN (SELECT "TodoID" FROM reminder.todo_tags WHERE "TagID" = ANY(` + q.arg(tags) + `) GROUP BY "TodoID" HAVING COUNT(DISTINCT "TagID") = ` + q.arg(len(tags)) + `)`)
+		default:
+			q.where(`"ID" IN (SELECT "TodoID" FROM reminder.todo_tags WHERE "TagID" = ANY(` + q.arg(tags) + `))`)
+		}
+	}
+
+	// reminds of archived projects are shown only when they are requested by project
+	if params.ProjectID > 0 {
+		q.where(`"ProjectID" = ` + q.arg(params.ProjectID))
+	} else {
+		q.where(notInArchivedProject(`"ProjectID"`))
+	}
+
+	if params.Deadline.StartRange != "" {
+		q.where(`"DeadlineAt" >= ` + q.arg(params.Deadline.StartRange) + `::timestamp`)
+	}
+	if params.Deadline.EndRange != "" {
+		q.where(`"DeadlineAt" < ` + q.arg(params.Deadline.EndRange) + `::timestamp`)
+	}
+
+	// search results are ordered by relevance unless sort column is given
+	var tsquery, column, direction string
+	if params.Search != "" {
+		tsquery = searchQuery(q.arg(params.Search))
+		q.where(`"Search" @@ ` + tsquery)
+		column, direction = `ts_rank("Search", `+tsquery+`)`, "DESC"
+	}
+	if params.Search == "" || params.FilterByDate != "" {
+		var err error
+		if column, direction, err = orderBy(params.FilterByDate, params.FilterBySort); err != nil {
+			return nil, 0, 0, err
+		}
+	}
+
+	// total count doesn't depend on the page and finish range, so it's counted before these conditions
+	total := q.String()
+
+	// sort column isn't unique (e.g. many reminds have the same priority), so "ID" is used
+	// as a tiebreaker both for ordering and for the cursor to not skip or repeat reminds
 	if params.Cursor > 0 {
-		switch params.FilterByDate {
-		case "DESC":
-			sql += fmt.Sprintf(` AND "%s" < (SELECT "%s" FROM reminder.todo WHERE "ID" = %d)`, params.FilterByDate, params.FilterByDate, params.Cursor)
-		case "ASC":
-			sql += fmt.Sprintf(` AND "%s" > (SELECT "%s" FROM reminder.todo WHERE "ID" = %d)`, params.FilterByDate, params.FilterByDate, params.Cursor)
+		compare := ">"
+		if direction == "DESC" {
+			compare = "<"
 		}
+		q.where(`(` + column + `, "ID") ` + compare + ` (SELECT ` + column + `, "ID" FROM reminder.todo WHERE "ID" = ` + q.arg(params.Cursor) + `)`)
 	}
 
 	if params.StartRange != "" {
-		sql += fmt.Sprintf(` AND "FinishedAt" BETWEEN '%s' AND '%s'`, params.StartRange, params.EndRange)
+		q.where(`"FinishedAt" BETWEEN ` + q.arg(params.StartRange) + `::timestamp AND ` + q.arg(params.EndRange) + `::timestamp`)
 	}
 
-	sql += fmt.Sprintf(` ORDER BY "%s" %s LIMIT %d`, params.FilterByDate, params.FilterBySort, params.Limit)
+	var headlines string
+	if params.Search != "" {
+		headlines = `ts_headline('simple', "Title", ` + tsquery + `, ` + q.arg(titleHeadlineOptions) + `),
+ts_headline('simple', "Description", ` + tsquery + `, ` + q.arg(descriptionHeadlineOptions) + `), `
+	}
+
+	sql := `SELECT ` + todoColumns + `, ` + progressColumns + `, ` + headlines + `(
+SELECT COUNT(*) FROM reminder.todo WHERE ` + total + `) as total_count
+FROM reminder.todo WHERE ` + q.String() + `
+ORDER BY ` + column + ` ` + direction + `, "ID" ` + direction + ` LIMIT ` + q.arg(params.Limit)
 
-	rows, err := s.Postgres.Query(ctx, sql)
+	rows, err := s.Postgres.Query(ctx, sql, q.args...)
 
 	if err != nil {
 		s.logger.Errorf("error get all reminds from db: %v", err)
@@ -76,26 +156,34 @@ FROM reminder.todo WHERE "User" = '%s'`, userID, userID)
 
 	for rows.Next() {
 		var remind model.Todo
+		var title, description string
 
-		if err := rows.Scan(
-			&remind.ID,
-			&remind.UserID,
-			&remind.Title,
-			&remind.Description,
-			&remind.CreatedAt,
-			&remind.DeadlineAt,
-			&remind.FinishedAt,
-			&remind.Completed,
-			&remind.Notificated,
-			&remind.DeadlineNotify,
-			&remind.NotifyPeriod,
-			&totalCount,
-		); err != nil {
+		fields := append(todoFields(&remind), progressFields(&remind)...)
+		if params.Search != "" {
+			fields = append(fields, &title, &description)
+		}
+		if err := rows.Scan(append(fields, &totalCount)...); err != nil {
 			s.logger.Errorf("remind doesnt exist: %v", err)
 			return []model.Todo{}, 0, 0, err
 		}
+		if params.Search != "" {
+			remind.Highlight = &model.Highlight{Title: highlight(title), Description: highlight(description)}
+		}
+		remind.Overdue = remind.IsOverdue(now)
 		reminds = append(reminds, remind)
 	}
+	rows.Close()
+
+	if err := attachTags(ctx, s.Postgres, reminds); err != nil {
+		s.logger.Errorf("error get tags of reminds: %v", err)
+		return []model.Todo{}, 0, 0, err
+	}
+
+	if params.Occurrences.StartRange != "" {
+		if err := expandOccurrences(reminds, params.Occurrences); err != nil {
+			return []model.Todo{}, 0, 0, err
+		}
+	}
 
 	var nextCursor int
 
@@ -110,42 +198,78 @@ FROM reminder.todo WHERE "User" = '%s'`, userID, userID)
 
 // CreateRemind  store new remind entity to DB PostgresSQL
 func (s *TodoStorage) CreateRemind(ctx context.Context, todo model.Todo) (model.Todo, error) {
-	var createdTodo model.Todo
+	tx, err := s.Postgres.Begin(ctx)
+	if err != nil {
+		s.logger.Errorf("unable to begin transaction: %v", err)
+		return model.Todo{}, err
+	}
+	defer tx.Rollback(ctx)
 
-	const sql = `INSERT INTO reminder.todo ("Title", "Description",  "User", "CreatedAt", "DeadlineAt", "DeadlineNotify", "NotifyPeriod") 
-				 VALUES ($1, $2, $3, $4, $5, $6, $7) returning "ID", "Title", "Description", "User", "CreatedAt", "DeadlineAt", "DeadlineNotify", "NotifyPeriod"`
-	row := s.Postgres.QueryRow(ctx, sql, todo.Title, todo.Description, todo.UserID, todo.CreatedAt, todo.DeadlineAt, todo.DeadlineNotify, todo.NotifyPeriod)
-	err := row.Scan(
-		&createdTodo.ID,
-		&createdTodo.Title,
-		&createdTodo.Description,
-		&createdTodo.UserID,
-		&createdTodo.CreatedAt,
-		&createdTodo.DeadlineAt,
-		&createdTodo.DeadlineNotify,
-		&createdTodo.NotifyPeriod,
-	)
+	createdTodo, err := s.createRemind(ctx, tx, todo)
 	if err != nil {
-		s.logger.Errorf("Error create remind: %v", err)
 		return model.Todo{}, err
 	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return model.Todo{}, err
+	}
+
 	return createdTodo, nil
 }
 
-// UpdateRemind update remind, can change Description, Completed and FinishedAt if Completed = true
-func (s *TodoStorage) UpdateRemind(ctx context.Context, id int, input model.TodoUpdateInput) (model.Todo, error) {
-	const sql = `UPDATE reminder.todo SET "Title" = $1, "Description" = $2, "DeadlineAt"=$3, "FinishedAt" = $4, "Completed" = $5, "DeadlineNotify" = $6, "NotifyPeriod" = $7 WHERE "ID" = $8`
+// createRemind inserts remind with its tags and records it in history inside the transaction
+func (s *TodoStorage) createRemind(ctx context.Context, tx pgx.Tx, todo model.Todo) (model.Todo, error) {
+	if err := checkProject(ctx, tx, todo.UserID, todo.ProjectID); err != nil {
+		s.logger.Errorf("Error check remind project: %v", err)
+		return model.Todo{}, err
+	}
 
-	ct, err := s.Postgres.Exec(ctx, sql, input.Title, input.Description, input.DeadlineAt, input.FinishedAt, input.Completed, input.DeadlineNotify, input.NotifyPeriod, id)
+	createdTodo, err := insertRemind(ctx, tx, todo)
 	if err != nil {
-		s.logger.Printf("unable to update remind %v", err)
+		s.logger.Errorf("Error create remind: %v", err)
+		return model.Todo{}, err
+	}
+
+	tagIDs := make([]int, len(todo.Tags))
+	for i, tag := range todo.Tags {
+		tagIDs[i] = tag.ID
+	}
+	if err := setRemindTags(ctx, tx, createdTodo.ID, tagIDs); err != nil {
+		s.logger.Errorf("Error set remind tags: %v", err)
 		return model.Todo{}, err
 	}
 
-	if ct.RowsAffected() == 0 {
-		return model.Todo{}, errors.New("remind not found")
+	reminds := []model.Todo{createdTodo}
+	if err := attachTags(ctx, tx, reminds); err != nil {
+		s.logger.Errorf("Error get remind tags: %v", err)
+		return model.Todo{}, err
+	}
+
+	if err := recordHistory(ctx, tx, createdTodo.ID, createdTodo.UserID, createdTodo.UserID, model.HistoryCreate, todoChanges(nil, remindValues(reminds[0]))); err != nil {
+		s.logger.Errorf("Error record remind history: %v", err)
+		return model.Todo{}, err
 	}
 
+	return reminds[0], nil
+}
+
+// insertRemind inserts remind using given pool or transaction
+func insertRemind(ctx context.Context, db postgresql.Client, todo model.Todo) (model.Todo, error) {
+	var createdTodo model.Todo
+
+	const sql = `INSERT INTO reminder.todo ("Title", "Description",  "User", "CreatedAt", "DeadlineAt", "DeadlineNotify", "NotifyPeriod", "NotifyOffsets", "Priority", "ProjectID", "RRule", "RecurFromCompletion") 
+				 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12) returning ` + todoColumns
+	row := db.QueryRow(ctx, sql, todo.Title, todo.Description, todo.UserID, todo.CreatedAt, todo.DeadlineAt, todo.DeadlineNotify, todo.NotifyPeriod, notifyOffsets(todo.DeadlineAt, todo.NotifyPeriod), todo.Priority, todo.ProjectID, todo.RRule, todo.RecurFromCompletion)
+	if err := row.Scan(todoFields(&createdTodo)...); err != nil {
+		return model.Todo{}, err
+	}
+	return createdTodo, nil
+}
+
+// UpdateRemind update remind, can change Description, Completed and FinishedAt if Completed = true
+func (s *TodoStorage) UpdateRemind(ctx context.Context, id int, userID string, input model.TodoUpdateInput) (model.Todo, error) {
+	const sql = `UPDATE reminder.todo SET "Title" = $1, "Description" = $2, "DeadlineAt"=$3, "FinishedAt" = $4, "Completed" = $5, "DeadlineNotify" = $6, "NotifyPeriod" = $7, "NotifyOffsets" = $8, "Priority" = COALESCE($9, "Priority"), "ProjectID" = $10, "RRule" = $11, "RecurFromCompletion" = $12, "Version" = "Version" + 1 WHERE "ID" = $13 RETURNING "Priority", "Version"`
+
 	parseDeadline, err := time.Parse(time.RFC3339, input.DeadlineAt)
 	if err != nil {
 		return model.Todo{}, err
@@ -162,6 +286,77 @@ func (s *TodoStorage) UpdateRemind(ctx context.Context, id int, input model.Todo
 		}
 	}
 
+	tx, err := s.Postgres.Begin(ctx)
+	if err != nil {
+		s.logger.Errorf("unable to begin transaction: %v", err)
+		return model.Todo{}, err
+	}
+	defer tx.Rollback(ctx)
+
+	// old remind is kept for history, its author is the owner of projects and tags of the remind
+	var old model.Todo
+
+	row := tx.QueryRow(ctx, `SELECT `+todoColumns+` FROM reminder.todo
+WHERE "ID" = $1 AND "ID" IN (`+remindsWithRole("$2", model.RoleEditor)+`) FOR UPDATE`, id, userID)
+	err = row.Scan(todoFields(&old)...)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return model.Todo{}, model.ErrCantFindRemindWithID
+	}
+	if err != nil {
+		s.logger.Printf("unable to get remind %v", err)
+		return model.Todo{}, err
+	}
+
+	if input.Version != 0 && input.Version != old.Version {
+		return model.Todo{}, model.ErrVersionMismatch
+	}
+
+	olds := []model.Todo{old}
+	if err := attachTags(ctx, tx, olds); err != nil {
+		s.logger.Printf("unable to get remind tags %v", err)
+		return model.Todo{}, err
+	}
+
+	authorID := old.UserID
+	projectID := old.ProjectID
+
+	// project is checked only when it is changed, so reminds of archived project still can be edited
+	if input.ProjectID != nil && (projectID == nil || *projectID != *input.ProjectID) {
+		projectID = nil
+		if *input.ProjectID != 0 {
+			projectID = input.ProjectID
+		}
+
+		if err := checkProject(ctx, tx, authorID, projectID); err != nil {
+			s.logger.Printf("unable to check remind project %v", err)
+			return model.Todo{}, err
+		}
+	}
+
+	var priority model.Priority
+	var version int
+
+	row = tx.QueryRow(ctx, sql, input.Title, input.Description, input.DeadlineAt, input.FinishedAt, input.Completed, input.DeadlineNotify, input.NotifyPeriod, notifyOffsets(parseDeadline, deadlinePeriodNotify), input.Priority, projectID, input.RRule, input.RecurFromCompletion, id)
+	if err := row.Scan(&priority, &version); err != nil {
+		s.logger.Printf("unable to update remind %v", err)
+		return model.Todo{}, err
+	}
+
+	// tags are replaced only when they were passed
+	if input.Tags != nil {
+		if err := setRemindTags(ctx, tx, id, input.Tags); err != nil {
+			s.logger.Printf("unable to update remind tags %v", err)
+			return model.Todo{}, err
+		}
+	}
+
+	reminds := make([]model.Todo, 1)
+	reminds[0].ID = id
+	if err := attachTags(ctx, tx, reminds); err != nil {
+		s.logger.Printf("unable to get remind tags %v", err)
+		return model.Todo{}, err
+	}
+
 	var todo model.Todo
 	todo.ID = id
 	todo.Title = input.Title
@@ -171,183 +366,398 @@ func (s *TodoStorage) UpdateRemind(ctx context.Context, id int, input model.Todo
 	todo.Completed = input.Completed
 	todo.DeadlineNotify = input.DeadlineNotify
 	todo.NotifyPeriod = deadlinePeriodNotify
+	todo.NotifyOffsets = notifyOffsets(parseDeadline, deadlinePeriodNotify)
+	todo.UserID = authorID
+	todo.Priority = priority
+	todo.ProjectID = projectID
+	todo.RRule = input.RRule
+	todo.RecurFromCompletion = input.RecurFromCompletion
+	todo.Tags = reminds[0].Tags
+	// fields which can't be changed by input are kept to not get into history as changes
+	todo.CreatedAt = old.CreatedAt
+	todo.Notificated = old.Notificated
+	todo.Version = version
+
+	if err := recordHistory(ctx, tx, id, authorID, userID, model.HistoryUpdate, todoChanges(remindValues(olds[0]), remindValues(todo))); err != nil {
+		s.logger.Printf("unable to record remind history %v", err)
+		return model.Todo{}, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return model.Todo{}, err
+	}
 
 	return todo, nil
 }
 
-// UpdateNotification update Notificated field
-func (s *TodoStorage) UpdateNotification(ctx context.Context, id int, dao model.NotificationDAO) error {
-	sql := `UPDATE reminder.todo SET "Notificated" = $1 WHERE "ID" = $2`
+// PatchRemind changes only fields present in the patch and returns reloaded remind. Completed is changed
+// after other fields like by UpdateStatus, so recurring remind gets the next occurrence
+func (s *TodoStorage) PatchRemind(ctx context.Context, id int, userID string, patch model.TodoPatch) (model.Todo, error) {
+	tx, err := s.Postgres.Begin(ctx)
+	if err != nil {
+		s.logger.Errorf("unable to begin transaction: %v", err)
+		return model.Todo{}, err
+	}
+	defer tx.Rollback(ctx)
+
+	var old model.Todo
 
-	ct, err := s.Postgres.Exec(ctx, sql, dao.Notificated, id)
+	row := tx.QueryRow(ctx, `SELECT `+todoColumns+` FROM reminder.todo
+WHERE "ID" = $1 AND "ID" IN (`+remindsWithRole("$2", model.RoleEditor)+`) FOR UPDATE`, id, userID)
+	err = row.Scan(todoFields(&old)...)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return model.Todo{}, model.ErrCantFindRemindWithID
+	}
 	if err != nil {
-		s.logger.Printf("unable to update notificated status %v", err)
+		s.logger.Printf("unable to get remind %v", err)
+		return model.Todo{}, err
+	}
+
+	if patch.Version != 0 && patch.Version != old.Version {
+		return model.Todo{}, model.ErrVersionMismatch
+	}
+
+	olds := []model.Todo{old}
+	if err := attachTags(ctx, tx, olds); err != nil {
+		s.logger.Printf("unable to get remind tags %v", err)
+		return model.Todo{}, err
+	}
+
+	var q query
+
+	if patch.Title != nil {
+		q.set(`"Title"`, *patch.Title)
+	}
+	if patch.Description != nil {
+		q.set(`"Description"`, *patch.Description)
+	}
+	if patch.DeadlineNotify != nil {
+		q.set(`"DeadlineNotify"`, *patch.DeadlineNotify)
+	}
+	if patch.Priority != nil {
+		q.set(`"Priority"`, *patch.Priority)
+	}
+	if patch.RecurFromCompletion != nil {
+		q.set(`"RecurFromCompletion"`, *patch.RecurFromCompletion)
+	}
+	if patch.RRule != nil {
+		var rule *string
+		if *patch.RRule != "" {
+			rule = patch.RRule
+		}
+		q.set(`"RRule"`, rule)
+	}
+
+	// project is checked only when it is changed, so reminds of archived project still can be edited
+	if patch.ProjectID != nil && (old.ProjectID == nil || *old.ProjectID != *patch.ProjectID) {
+		var projectID *int
+		if *patch.ProjectID != 0 {
+			projectID = patch.ProjectID
+		}
+
+		if err := checkProject(ctx, tx, old.UserID, projectID); err != nil {
+			s.logger.Printf("unable to check remind project %v", err)
+			return model.Todo{}, err
+		}
+		q.set(`"ProjectID"`, projectID)
+	}
+
+	// offsets depend on both deadline and notify period, so the missing one is taken from the remind
+	if patch.DeadlineAt != nil || patch.NotifyPeriod != nil {
+		deadline, notifyPeriod := old.DeadlineAt, old.NotifyPeriod
+		if patch.DeadlineAt != nil {
+			deadline = *patch.DeadlineAt
+			q.set(`"DeadlineAt"`, deadline)
+		}
+		if patch.NotifyPeriod != nil {
+			notifyPeriod = *patch.NotifyPeriod
+			q.set(`"NotifyPeriod"`, notifyPeriod)
+		}
+		q.set(`"NotifyOffsets"`, notifyOffsets(deadline, notifyPeriod))
+	}
+
+	// empty patch doesn't change the remind and its version
+	if len(q.assignments) > 0 || patch.Tags != nil {
+		sql := `UPDATE reminder.todo SET ` + strings.Join(append(q.assignments, `"Version" = "Version" + 1`), ", ") + ` WHERE "ID" = ` + q.arg(id)
+
+		if _, err := tx.Exec(ctx, sql, q.args...); err != nil {
+			s.logger.Printf("unable to patch remind %v", err)
+			return model.Todo{}, err
+		}
+
+		if patch.Tags != nil {
+			if err := setRemindTags(ctx, tx, id, *patch.Tags); err != nil {
+				s.logger.Printf("unable to update remind tags %v", err)
+				return model.Todo{}, err
+			}
+		}
+
+		var updated model.Todo
+		if err := tx.QueryRow(ctx, `SELECT `+todoColumns+` FROM reminder.todo WHERE "ID" = $1`, id).Scan(todoFields(&updated)...); err != nil {
+			s.logger.Printf("unable to get patched remind %v", err)
+			return model.Todo{}, err
+		}
+
+		reminds := []model.Todo{updated}
+		if err := attachTags(ctx, tx, reminds); err != nil {
+			s.logger.Printf("unable to get remind tags %v", err)
+			return model.Todo{}, err
+		}
+
+		if err := recordHistory(ctx, tx, id, old.UserID, userID, model.HistoryUpdate, todoChanges(remindValues(olds[0]), remindValues(reminds[0]))); err != nil {
+			s.logger.Printf("unable to record remind history %v", err)
+			return model.Todo{}, err
+		}
+	}
+
+	// the next occurrence is created from the patched remind, so it gets new rule, deadline and tags
+	if patch.Completed != nil {
+		if err := s.updateStatus(ctx, tx, id, userID, model.TodoUpdateStatusInput{Completed: *patch.Completed, FinishedAt: patch.FinishedAt}); err != nil {
+			return model.Todo{}, err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return model.Todo{}, err
+	}
+
+	return s.GetRemindByID(ctx, id, userID)
+}
+
+// UpdateNotification update Notificated field of remind of the user, the change is recorded as made by system
+func (s *TodoStorage) UpdateNotification(ctx context.Context, id int, userID string, dao model.NotificationDAO) error {
+	tx, err := s.Postgres.Begin(ctx)
+	if err != nil {
+		s.logger.Errorf("unable to begin transaction: %v", err)
 		return err
 	}
+	defer tx.Rollback(ctx)
 
-	if ct.RowsAffected() == 0 {
-		return errors.New("remind not found")
+	if err := s.updateNotificated(ctx, tx, id, userID, dao.Notificated); err != nil {
+		return err
 	}
 
-	return nil
+	return tx.Commit(ctx)
 }
 
-// UpdateStatus update Completed field
-func (s *TodoStorage) UpdateStatus(ctx context.Context, id int, updateInput model.TodoUpdateStatusInput) error {
-	const sql = `UPDATE reminder.todo SET "FinishedAt" = $1, "Completed" = $2 WHERE "ID" = $3`
+// updateNotificated updates Notificated field of remind of the user in transaction and records it in history
+func (s *TodoStorage) updateNotificated(ctx context.Context, tx pgx.Tx, id int, userID string, notificated bool) error {
+	var old bool
 
-	ct, err := s.Postgres.Exec(ctx, sql, updateInput.FinishedAt, updateInput.Completed, id)
+	err := tx.QueryRow(ctx, `SELECT "Notificated" FROM reminder.todo WHERE "ID" = $1 AND "User" = $2 FOR UPDATE`, id, userID).Scan(&old)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return model.ErrCantFindRemindWithID
+	}
 	if err != nil {
-		s.logger.Printf("unable to update status %v", err)
+		s.logger.Printf("unable to get remind %v", err)
+		return err
+	}
+
+	const sql = `UPDATE reminder.todo SET "Notificated" = $1 WHERE "ID" = $2`
+
+	if _, err := tx.Exec(ctx, sql, notificated, id); err != nil {
+		s.logger.Printf("unable to update notificated status %v", err)
 		return err
 	}
 
-	if ct.RowsAffected() == 0 {
-		return errors.New("remind not found")
+	changes := todoChanges(map[string]any{"notificated": old}, map[string]any{"notificated": notificated})
+	if err := recordHistory(ctx, tx, id, userID, model.ActorSystem, model.HistoryNotification, changes); err != nil {
+		s.logger.Printf("unable to record remind history %v", err)
+		return err
 	}
 
 	return nil
 }
 
-// DeleteRemind deletes remind from DB
-func (s *TodoStorage) DeleteRemind(ctx context.Context, id int) error {
-	const sql = `DELETE FROM reminder.todo WHERE "ID" = $1`
-	res, err := s.Postgres.Exec(ctx, sql, id)
+// UpdateStatus update Completed field, members with editor role can do it too.
+// When recurring remind gets completed the next occurrence is created
+func (s *TodoStorage) UpdateStatus(ctx context.Context, id int, userID string, updateInput model.TodoUpdateStatusInput) error {
+	tx, err := s.Postgres.Begin(ctx)
 	if err != nil {
-		s.logger.Errorf("Error delete remind: %v", err)
-		return model.ErrDeleteFailed
+		s.logger.Errorf("unable to begin transaction: %v", err)
+		return err
 	}
+	defer tx.Rollback(ctx)
 
-	rowsAffected := res.RowsAffected()
-	if rowsAffected == 0 {
-		s.logger.Errorf("error don't found remind: %v", err)
-		return model.ErrCantFindRemindWithID
+	if err := s.updateStatus(ctx, tx, id, userID, updateInput); err != nil {
+		return err
 	}
 
-	return nil
+	return tx.Commit(ctx)
 }
 
-// GetRemindByID takes out one remind from PostgreSQL by id
-func (s *TodoStorage) GetRemindByID(ctx context.Context, id int) (model.Todo, error) {
+// updateStatus updates status of remind in given transaction
+func (s *TodoStorage) updateStatus(ctx context.Context, tx pgx.Tx, id int, userID string, updateInput model.TodoUpdateStatusInput) error {
 	var todo model.Todo
 
-	const sql = `SELECT "ID", "Title", "Description", "User", "CreatedAt", "DeadlineAt", "Completed", "FinishedAt", "Notificated" FROM reminder.todo
-    WHERE "ID" = $1 LIMIT 1`
-
-	row := s.Postgres.QueryRow(ctx, sql, id)
-
-	err := row.Scan(
-		&todo.ID,
-		&todo.Title,
-		&todo.Description,
-		&todo.UserID,
-		&todo.CreatedAt,
-		&todo.DeadlineAt,
-		&todo.Completed,
-		&todo.FinishedAt,
-		&todo.Notificated,
-	)
+	row := tx.QueryRow(ctx, `SELECT `+todoColumns+` FROM reminder.todo
+WHERE "ID" = $1 AND "ID" IN (`+remindsWithRole("$2", model.RoleEditor)+`) FOR UPDATE`, id, userID)
+	err := row.Scan(todoFields(&todo)...)
 	if errors.Is(err, pgx.ErrNoRows) {
-		return model.Todo{}, nil
+		return model.ErrCantFindRemindWithID
 	}
 	if err != nil {
-		s.logger.Printf("cannot get product from database: %v\n", err)
-		return model.Todo{}, errors.New("cannot get product from database")
+		s.logger.Printf("unable to get remind %v", err)
+		return err
 	}
 
-	return todo, nil
-}
+	if updateInput.Version != 0 && updateInput.Version != todo.Version {
+		return model.ErrVersionMismatch
+	}
 
-func (s *TodoStorage) GetRemindsForNotification(ctx context.Context) ([]model.NotificationRemind, error) {
-	reminds := []model.NotificationRemind{}
+	if updateInput.Completed {
+		switch updateInput.Children {
+		case model.ChildrenComplete:
+			const sql = `UPDATE reminder.todo_items SET "Completed" = true WHERE "TodoID" = $1`
+			if _, err := tx.Exec(ctx, sql, id); err != nil {
+				s.logger.Printf("unable to complete checklist items %v", err)
+				return err
+			}
+		case model.ChildrenBlock:
+			var incomplete int
+			const sql = `SELECT COUNT(*) FROM reminder.todo_items WHERE "TodoID" = $1 AND NOT "Completed"`
+			if err := tx.QueryRow(ctx, sql, id).Scan(&incomplete); err != nil {
+				s.logger.Printf("unable to count checklist items %v", err)
+				return err
+			}
+			if incomplete > 0 {
+				return model.ErrIncompleteItems
+			}
+		}
+	}
 
-	for i := 1; i <= 3; i++ {
-		t := time.Now().AddDate(0, 0, i).Format("2006-01-02 15:04:05")
-		tn := time.Now().Format("2006-01-02 15:04:05")
+	const sql = `UPDATE reminder.todo SET "FinishedAt" = $1, "Completed" = $2, "Version" = "Version" + 1 WHERE "ID" = $3`
 
-		sql := fmt.Sprintf(`SELECT t."ID", t."Description", t."Title", t."DeadlineAt", t."User" from reminder.todo t 
-INNER JOIN reminder.users_configs u on u."ID" = t."User" 
-WHERE t."DeadlineAt" BETWEEN '%s' AND '%s' 
-AND t."Completed" = false 
-AND t."Notificated" = false
-AND u."Notification" = true
-AND u."Period" = %d`, tn, t, i)
+	if _, err := tx.Exec(ctx, sql, updateInput.FinishedAt, updateInput.Completed, id); err != nil {
+		s.logger.Printf("unable to update status %v", err)
+		return err
+	}
 
-		rows, err := s.Postgres.Query(ctx, sql)
+	updated := todo
+	updated.Completed = updateInput.Completed
+	updated.FinishedAt = updateInput.FinishedAt
+	if err := recordHistory(ctx, tx, id, todo.UserID, userID, model.HistoryStatus, todoChanges(remindValues(todo), remindValues(updated))); err != nil {
+		s.logger.Printf("unable to record remind history %v", err)
+		return err
+	}
+
+	if updateInput.Completed && !todo.Completed && todo.RRule != nil {
+		finishedAt := time.Now()
+		if updateInput.FinishedAt != nil {
+			finishedAt = *updateInput.FinishedAt
+		}
+
+		next, ok, err := nextOccurrence(todo, finishedAt)
 		if err != nil {
-			s.logger.Errorf("error to select reminds for notification: %v", err)
-			return nil, err
+			s.logger.Errorf("unable to get next occurrence: %v", err)
+			return err
 		}
+		if ok {
+			created, err := insertRemind(ctx, tx, next)
+			if err != nil {
+				s.logger.Errorf("unable to create next occurrence: %v", err)
+				return err
+			}
 
-		for rows.Next() {
-			var remind model.NotificationRemind
-
-			if err := rows.Scan(
-				&remind.ID,
-				&remind.Description,
-				&remind.Title,
-				&remind.DeadlineAt,
-				&remind.UserID,
-			); err != nil {
-				s.logger.Errorf("remind doesn't exist: %v", err)
-				return nil, err
+			// the next occurrence gets the same checklist with all items not done
+			const sql = `INSERT INTO reminder.todo_items ("TodoID", "Title", "DeadlineAt", "Position", "CreatedAt")
+SELECT $1, "Title", "DeadlineAt" + $2 * interval '1 second', "Position", $3 FROM reminder.todo_items WHERE "TodoID" = $4`
+			shift := created.DeadlineAt.Sub(todo.DeadlineAt).Seconds()
+			if _, err := tx.Exec(ctx, sql, created.ID, shift, created.CreatedAt, todo.ID); err != nil {
+				s.logger.Errorf("unable to copy checklist to next occurrence: %v", err)
+				return err
 			}
-			reminds = append(reminds, remind)
-		}
 
+			const tagsSQL = `INSERT INTO reminder.todo_tags ("TodoID", "TagID") SELECT $1, "TagID" FROM reminder.todo_tags WHERE "TodoID" = $2`
+			if _, err := tx.Exec(ctx, tagsSQL, created.ID, todo.ID); err != nil {
+				s.logger.Errorf("unable to copy tags to next occurrence: %v", err)
+				return err
+			}
+
+			reminds := []model.Todo{created}
+			if err := attachTags(ctx, tx, reminds); err != nil {
+				s.logger.Errorf("unable to get tags of next occurrence: %v", err)
+				return err
+			}
+			if err := recordHistory(ctx, tx, created.ID, created.UserID, userID, model.HistoryCreate, todoChanges(nil, remindValues(reminds[0]))); err != nil {
+				s.logger.Errorf("unable to record next occurrence history: %v", err)
+				return err
+			}
+		}
 	}
 
-	return reminds, nil
+	return nil
 }
 
-func (s *TodoStorage) GetRemindsForDeadlineNotification(ctx context.Context) ([]model.NotificationRemind, string, error) {
-	var reminds []model.NotificationRemind
-	tn := time.Now().Truncate(time.Minute).Format(time.RFC3339)
+// DeleteRemind moves remind to trash, it's purged from there after retention period
+func (s *TodoStorage) DeleteRemind(ctx context.Context, id int, userID string) error {
+	tx, err := s.Postgres.Begin(ctx)
+	if err != nil {
+		s.logger.Errorf("unable to begin transaction: %v", err)
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := s.deleteRemind(ctx, tx, id, userID); err != nil {
+		return err
+	}
 
-	sql := fmt.Sprintf(`SELECT t."ID", t."Description", t."Title", t."DeadlineAt", t."User" from reminder.todo t 
-INNER JOIN reminder.users_configs u on u."ID" = t."User" 
-WHERE t."NotifyPeriod" @> ARRAY['%s']::TIMESTAMP[] 
-AND t."Completed" = false 
-AND t."DeadlineNotify" = true`, tn)
+	return tx.Commit(ctx)
+}
+
+// deleteRemind moves remind to trash in given transaction
+func (s *TodoStorage) deleteRemind(ctx context.Context, tx pgx.Tx, id int, userID string) error {
+	deletedAt := time.Now()
+	var authorID string
 
-	rows, err := s.Postgres.Query(ctx, sql)
+	sql := `UPDATE reminder.todo SET "DeletedAt" = $1 WHERE "ID" = $2 AND "ID" IN (` + remindsWithRole("$3", model.RoleOwner) + `) RETURNING "User"`
+	err := tx.QueryRow(ctx, sql, deletedAt, id, userID).Scan(&authorID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		s.logger.Errorf("error don't found remind: %v", err)
+		return model.ErrCantFindRemindWithID
+	}
 	if err != nil {
-		s.logger.Errorf("error to select deadline reminds for notification: %v", err)
-		return nil, "", err
+		s.logger.Errorf("Error delete remind: %v", err)
+		return model.ErrDeleteFailed
 	}
 
-	for rows.Next() {
-		var remind model.NotificationRemind
-
-		if err := rows.Scan(
-			&remind.ID,
-			&remind.Description,
-			&remind.Title,
-			&remind.DeadlineAt,
-			&remind.UserID,
-		); err != nil {
-			s.logger.Errorf("remind doesn't exist: %v", err)
-			return nil, "", err
-		}
-		reminds = append(reminds, remind)
+	if err := recordHistory(ctx, tx, id, authorID, userID, model.HistoryDelete, deletedChanges(nil, &deletedAt)); err != nil {
+		s.logger.Errorf("Error record remind history: %v", err)
+		return err
 	}
 
-	return reminds, tn, nil
+	return nil
 }
 
-func (s *TodoStorage) UpdateNotifyPeriod(ctx context.Context, id int, timeToDelete string) error {
-	sql := fmt.Sprintf(`UPDATE reminder.todo SET "NotifyPeriod" = array_remove("NotifyPeriod", '%s')
-WHERE "ID" = '%d'`, timeToDelete, id)
+// GetRemindByID takes out one remind from PostgreSQL by id
+func (s *TodoStorage) GetRemindByID(ctx context.Context, id int, userID string) (model.Todo, error) {
+	var todo model.Todo
+
+	sql := `SELECT ` + todoColumns + `, ` + progressColumns + ` FROM reminder.todo
+    WHERE "ID" = $1 AND "ID" IN (` + remindsWithRole("$2", model.RoleViewer) + `) LIMIT 1`
 
-	ct, err := s.Postgres.Exec(ctx, sql)
+	row := s.Postgres.QueryRow(ctx, sql, id, userID)
+
+	err := row.Scan(append(todoFields(&todo), progressFields(&todo)...)...)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return model.Todo{}, model.ErrCantFindRemindWithID
+	}
 	if err != nil {
-		s.logger.Printf("unable to update remind notifier period %v", err)
-		return err
+		s.logger.Printf("cannot get product from database: %v\n", err)
+		return model.Todo{}, errors.New("cannot get product from database")
+	}
+	todo.Overdue = todo.IsOverdue(time.Now())
+
+	todo.Items, err = s.getItems(ctx, todo.ID)
+	if err != nil {
+		return model.Todo{}, err
 	}
 
-	if ct.RowsAffected() == 0 {
-		return errors.New("remind not found")
+	reminds := []model.Todo{todo}
+	if err := attachTags(ctx, s.Postgres, reminds); err != nil {
+		s.logger.Printf("cannot get remind tags: %v\n", err)
+		return model.Todo{}, err
 	}
 
-	return nil
+	return reminds[0], nil
 }
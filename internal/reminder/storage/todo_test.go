@@ -91,13 +91,15 @@ func TestStorageTodo_GetRemindByID(t *testing.T) {
 
 	remind, _ := testTodoStorage.CreateRemind(context.Background(), insertTodo)
 
-	got, err := testTodoStorage.GetRemindByID(context.Background(), remind.ID)
+	got, err := testTodoStorage.GetRemindByID(context.Background(), remind.ID, userID)
 
 	require.NoError(t, err)
 	require.NotEmpty(t, got)
 	require.Equal(t, insertTodo.Description, got.Description)
 	require.Equal(t, insertTodo.DeadlineAt, got.DeadlineAt)
 
+	_, err = testTodoStorage.GetRemindByID(context.Background(), remind.ID, "another user")
+	require.ErrorIs(t, err, model.ErrCantFindRemindWithID)
 }
 
 func TestStorageTodo_GetReminds(t *testing.T) {
@@ -135,9 +137,9 @@ func TestStorageTodo_GetReminds(t *testing.T) {
 			FilterBySort:  "DESC",
 			FilterByQuery: "all",
 		}, expectedTodo[0].UserID},
-			want:    expectedTodo,
+			want:    []model.Todo{expectedTodo[4], expectedTodo[3], expectedTodo[2], expectedTodo[1], expectedTodo[0]},
 			want1:   5,
-			want2:   expectedTodo[len(expectedTodo)-1].ID,
+			want2:   expectedTodo[0].ID,
 			wantErr: false},
 		{name: "success get all reminds with cursor", args: args{context.Background(), model.FetchParams{
 			Page: utils.Page{
@@ -148,9 +150,9 @@ func TestStorageTodo_GetReminds(t *testing.T) {
 			FilterBySort:  "DESC",
 			FilterByQuery: "all",
 		}, expectedTodo[0].UserID},
-			want:    []model.Todo{expectedTodo[1], expectedTodo[0]},
+			want:    []model.Todo{expectedTodo[2], expectedTodo[1]},
 			want1:   5,
-			want2:   expectedTodo[len(expectedTodo)-5].ID,
+			want2:   expectedTodo[1].ID,
 			wantErr: false},
 		{name: "success get completed with time range ", args: args{ctx: context.Background(), fetchParams: model.FetchParams{
 			Page: utils.Page{
@@ -195,9 +197,9 @@ func TestStorageTodo_GetReminds(t *testing.T) {
 			FilterBySort:  "DESC",
 			FilterByQuery: "current",
 		}, expectedTodo[0].UserID},
-			want:    []model.Todo{expectedTodo[0], expectedTodo[2], expectedTodo[3], expectedTodo[4]},
+			want:    []model.Todo{expectedTodo[4], expectedTodo[3], expectedTodo[2], expectedTodo[0]},
 			want1:   4,
-			want2:   expectedTodo[len(expectedTodo)-1].ID,
+			want2:   expectedTodo[0].ID,
 			wantErr: false},
 		{name: "empty filterParams value", args: args{context.Background(), model.FetchParams{
 			Page: utils.Page{
@@ -232,6 +234,149 @@ func TestStorageTodo_GetReminds(t *testing.T) {
 	}
 }
 
+func TestStorageTodo_GetRemindsByPriority(t *testing.T) {
+	defer func() {
+		err := Truncate()
+		require.NoError(t, err)
+	}()
+
+	expectedTodo, err := SeedTodos()
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	userID := expectedTodo[0].UserID
+
+	priorities := []model.Priority{model.PriorityLow, model.PriorityCritical, model.PriorityLow, model.PriorityNone, model.PriorityCritical}
+	for i := range priorities {
+		_, err := testTodoStorage.UpdateRemind(ctx, expectedTodo[i].ID, userID, model.TodoUpdateInput{
+			Title:       expectedTodo[i].Title,
+			Description: expectedTodo[i].Description,
+			DeadlineAt:  "2023-04-15T16:27:00Z",
+			Completed:   expectedTodo[i].Completed,
+			FinishedAt:  expectedTodo[i].FinishedAt,
+			Priority:    &priorities[i],
+		})
+		require.NoError(t, err)
+	}
+
+	// priority is kept when it is omitted
+	updated, err := testTodoStorage.UpdateRemind(ctx, expectedTodo[1].ID, userID, model.TodoUpdateInput{
+		Title:       "without priority",
+		Description: expectedTodo[1].Description,
+		DeadlineAt:  "2023-04-15T16:27:00Z",
+		Completed:   expectedTodo[1].Completed,
+		FinishedAt:  expectedTodo[1].FinishedAt,
+	})
+	require.NoError(t, err)
+	require.Equal(t, model.PriorityCritical, updated.Priority)
+
+	params := model.FetchParams{
+		Page:          utils.Page{Limit: 2},
+		FilterByDate:  "Priority",
+		FilterBySort:  "DESC",
+		FilterByQuery: "all",
+	}
+
+	// pages must not skip or repeat reminds with the same priority
+	var got []int
+	for {
+		reminds, _, nextCursor, err := testTodoStorage.GetReminds(ctx, params, userID)
+		require.NoError(t, err)
+		if len(reminds) == 0 {
+			break
+		}
+		for _, remind := range reminds {
+			got = append(got, remind.ID)
+		}
+		params.Cursor = nextCursor
+	}
+
+	require.Equal(t, []int{
+		expectedTodo[4].ID,
+		expectedTodo[1].ID,
+		expectedTodo[2].ID,
+		expectedTodo[0].ID,
+		expectedTodo[3].ID,
+	}, got)
+}
+
+func TestStorageTodo_SearchReminds(t *testing.T) {
+	defer func() {
+		err := Truncate()
+		require.NoError(t, err)
+	}()
+
+	userID, err := SeedUserConfig()
+	require.NoError(t, err)
+
+	ctx := context.Background()
+
+	var ids []int
+	for _, remind := range []model.Todo{
+		{Title: "Buy milk", Description: "and bread"},
+		{Title: "Call mom", Description: "ask about milk price"},
+		{Title: "Meeting", Description: "with team"},
+	} {
+		remind.UserID = userID
+		remind.CreatedAt = time.Now()
+		remind.DeadlineAt = time.Now()
+
+		created, err := testTodoStorage.CreateRemind(ctx, remind)
+		require.NoError(t, err)
+		ids = append(ids, created.ID)
+	}
+
+	params := model.FetchParams{
+		Page:          utils.Page{Limit: 1},
+		FilterByQuery: "all",
+		Search:        "milk",
+	}
+
+	t.Run("ranked by relevance with cursor", func(t *testing.T) {
+		reminds, total, nextCursor, err := testTodoStorage.GetReminds(ctx, params, userID)
+		require.NoError(t, err)
+		require.Equal(t, 2, total)
+		require.Len(t, reminds, 1)
+		// match in title weighs more than match in description
+		require.Equal(t, ids[0], reminds[0].ID)
+		require.Equal(t, &model.Highlight{Title: "Buy <mark>milk</mark>", Description: "and bread"}, reminds[0].Highlight)
+
+		next := params
+		next.Cursor = nextCursor
+		reminds, _, _, err = testTodoStorage.GetReminds(ctx, next, userID)
+		require.NoError(t, err)
+		require.Len(t, reminds, 1)
+		require.Equal(t, ids[1], reminds[0].ID)
+		require.Equal(t, "ask about <mark>milk</mark> price", reminds[0].Highlight.Description)
+	})
+
+	t.Run("with status filter and sort", func(t *testing.T) {
+		require.NoError(t, testTodoStorage.UpdateStatus(ctx, ids[0], userID, model.TodoUpdateStatusInput{Completed: true}))
+
+		current := params
+		current.Limit = 10
+		current.FilterByQuery = "current"
+		current.FilterByDate = "CreatedAt"
+		current.FilterBySort = "DESC"
+
+		reminds, total, _, err := testTodoStorage.GetReminds(ctx, current, userID)
+		require.NoError(t, err)
+		require.Equal(t, 1, total)
+		require.Equal(t, ids[1], reminds[0].ID)
+	})
+
+	t.Run("web search syntax", func(t *testing.T) {
+		query := params
+		query.Limit = 10
+		query.Search = `milk -bread`
+
+		reminds, _, _, err := testTodoStorage.GetReminds(ctx, query, userID)
+		require.NoError(t, err)
+		require.Len(t, reminds, 1)
+		require.Equal(t, ids[1], reminds[0].ID)
+	})
+}
+
 func TestStorageTodo_DeleteRemind(t *testing.T) {
 	defer func() {
 		err := Truncate()
@@ -241,15 +386,22 @@ func TestStorageTodo_DeleteRemind(t *testing.T) {
 	expectedTodo, err := SeedTodos()
 	require.NoError(t, err)
 
+	t.Run("error remind of another user", func(t *testing.T) {
+		err = testTodoStorage.DeleteRemind(context.Background(), expectedTodo[1].ID, "another user")
+		require.ErrorIs(t, err, model.ErrCantFindRemindWithID)
+
+		_, err = testTodoStorage.GetRemindByID(context.Background(), expectedTodo[1].ID, expectedTodo[0].UserID)
+		require.NoError(t, err)
+	})
 	t.Run("success", func(t *testing.T) {
-		err = testTodoStorage.DeleteRemind(context.Background(), expectedTodo[1].ID)
+		err = testTodoStorage.DeleteRemind(context.Background(), expectedTodo[1].ID, expectedTodo[0].UserID)
 		require.NoError(t, err)
 
-		todo, _ := testTodoStorage.GetRemindByID(context.Background(), expectedTodo[1].ID)
+		todo, _ := testTodoStorage.GetRemindByID(context.Background(), expectedTodo[1].ID, expectedTodo[0].UserID)
 		require.Empty(t, todo)
 	})
 	t.Run("error remind doesn't existing", func(t *testing.T) {
-		err = testTodoStorage.DeleteRemind(context.Background(), 99)
+		err = testTodoStorage.DeleteRemind(context.Background(), 99, expectedTodo[0].UserID)
 		require.Error(t, err)
 	})
 }
@@ -278,12 +430,13 @@ func TestStorageTodo_UpdateRemind(t *testing.T) {
 			NotifyPeriod: []string{"2023-01-26T16:05:00Z"},
 		}
 
-		_, err = testTodoStorage.UpdateRemind(context.Background(), expectedTodo[1].ID, updateInput)
+		_, err = testTodoStorage.UpdateRemind(context.Background(), expectedTodo[1].ID, expectedTodo[0].UserID, updateInput)
 		require.NoError(t, err)
 
-		newTodo, _ := testTodoStorage.GetRemindByID(context.Background(), expectedTodo[1].ID)
+		newTodo, _ := testTodoStorage.GetRemindByID(context.Background(), expectedTodo[1].ID, expectedTodo[0].UserID)
 		require.Equal(t, updateInput.Description, newTodo.Description)
 		require.Equal(t, updateInput.Completed, newTodo.Completed)
+		require.Equal(t, []int64{3600}, newTodo.NotifyOffsets)
 	})
 	t.Run("error wrong notify period", func(t *testing.T) {
 		updateInput := model.TodoUpdateInput{
@@ -294,7 +447,7 @@ func TestStorageTodo_UpdateRemind(t *testing.T) {
 			NotifyPeriod: []string{"2023-01-26"},
 		}
 
-		_, err = testTodoStorage.UpdateRemind(context.Background(), expectedTodo[1].ID, updateInput)
+		_, err = testTodoStorage.UpdateRemind(context.Background(), expectedTodo[1].ID, expectedTodo[0].UserID, updateInput)
 		require.Error(t, err)
 	})
 	t.Run("error wrong deadlineAt ", func(t *testing.T) {
@@ -305,7 +458,7 @@ func TestStorageTodo_UpdateRemind(t *testing.T) {
 			DeadlineAt:  "2023-01-26",
 		}
 
-		_, err = testTodoStorage.UpdateRemind(context.Background(), expectedTodo[1].ID, updateInput)
+		_, err = testTodoStorage.UpdateRemind(context.Background(), expectedTodo[1].ID, expectedTodo[0].UserID, updateInput)
 		require.Error(t, err)
 	})
 	t.Run("error not existing remind ", func(t *testing.T) {
@@ -316,9 +469,174 @@ func TestStorageTodo_UpdateRemind(t *testing.T) {
 			DeadlineAt:  "2023-01-26T17:05:00Z",
 		}
 
-		_, err = testTodoStorage.UpdateRemind(context.Background(), 9999, updateInput)
+		_, err = testTodoStorage.UpdateRemind(context.Background(), 9999, expectedTodo[0].UserID, updateInput)
 		require.Error(t, err)
 	})
+	t.Run("error remind of another user", func(t *testing.T) {
+		updateInput := model.TodoUpdateInput{
+			Description: "Not mine",
+			DeadlineAt:  "2023-01-26T17:05:00Z",
+		}
+
+		_, err = testTodoStorage.UpdateRemind(context.Background(), expectedTodo[2].ID, "another user", updateInput)
+		require.ErrorIs(t, err, model.ErrCantFindRemindWithID)
+
+		todo, err := testTodoStorage.GetRemindByID(context.Background(), expectedTodo[2].ID, expectedTodo[0].UserID)
+		require.NoError(t, err)
+		require.Equal(t, expectedTodo[2].Description, todo.Description)
+	})
+	t.Run("version", func(t *testing.T) {
+		todo, err := testTodoStorage.GetRemindByID(context.Background(), expectedTodo[3].ID, expectedTodo[0].UserID)
+		require.NoError(t, err)
+
+		updateInput := model.TodoUpdateInput{
+			Description: "Tab one",
+			DeadlineAt:  "2023-01-26T17:05:00Z",
+			Version:     todo.Version,
+		}
+
+		updated, err := testTodoStorage.UpdateRemind(context.Background(), todo.ID, todo.UserID, updateInput)
+		require.NoError(t, err)
+		require.Equal(t, todo.Version+1, updated.Version)
+
+		// the second tab still has the old version
+		updateInput.Description = "Tab two"
+		_, err = testTodoStorage.UpdateRemind(context.Background(), todo.ID, todo.UserID, updateInput)
+		require.ErrorIs(t, err, model.ErrVersionMismatch)
+
+		err = testTodoStorage.UpdateStatus(context.Background(), todo.ID, todo.UserID, model.TodoUpdateStatusInput{Completed: true, Version: todo.Version})
+		require.ErrorIs(t, err, model.ErrVersionMismatch)
+
+		err = testTodoStorage.UpdateStatus(context.Background(), todo.ID, todo.UserID, model.TodoUpdateStatusInput{Completed: true, Version: updated.Version})
+		require.NoError(t, err)
+
+		got, err := testTodoStorage.GetRemindByID(context.Background(), todo.ID, todo.UserID)
+		require.NoError(t, err)
+		require.Equal(t, "Tab one", got.Description)
+		require.Equal(t, updated.Version+1, got.Version)
+	})
+}
+
+func TestStorageTodo_PatchRemind(t *testing.T) {
+	defer func() {
+		err := Truncate()
+		if err != nil {
+			log.Fatal("error truncate table")
+		}
+	}()
+
+	expectedTodo, err := SeedTodos()
+	if err != nil {
+		log.Fatal("error seed reminds")
+	}
+
+	ctx := context.Background()
+	todo := expectedTodo[0]
+
+	t.Run("only patched fields are changed", func(t *testing.T) {
+		before, err := testTodoStorage.GetRemindByID(ctx, todo.ID, todo.UserID)
+		require.NoError(t, err)
+
+		title := "patched"
+		got, err := testTodoStorage.PatchRemind(ctx, todo.ID, todo.UserID, model.TodoPatch{Title: &title})
+		require.NoError(t, err)
+		require.Equal(t, title, got.Title)
+		require.Equal(t, before.Description, got.Description)
+		require.Equal(t, before.DeadlineAt, got.DeadlineAt)
+		require.Equal(t, before.NotifyPeriod, got.NotifyPeriod)
+		require.Equal(t, before.Version+1, got.Version)
+	})
+
+	t.Run("deadline recalculates notify offsets", func(t *testing.T) {
+		deadline := time.Date(2023, time.April, 15, 16, 0, 0, 0, time.UTC)
+		period := []time.Time{deadline.Add(-time.Hour)}
+
+		got, err := testTodoStorage.PatchRemind(ctx, todo.ID, todo.UserID, model.TodoPatch{DeadlineAt: &deadline, NotifyPeriod: &period})
+		require.NoError(t, err)
+		require.Equal(t, []int64{3600}, got.NotifyOffsets)
+
+		later := deadline.Add(time.Hour)
+		got, err = testTodoStorage.PatchRemind(ctx, todo.ID, todo.UserID, model.TodoPatch{DeadlineAt: &later})
+		require.NoError(t, err)
+		require.Equal(t, []int64{7200}, got.NotifyOffsets)
+	})
+
+	t.Run("empty patch keeps version", func(t *testing.T) {
+		before, err := testTodoStorage.GetRemindByID(ctx, todo.ID, todo.UserID)
+		require.NoError(t, err)
+
+		got, err := testTodoStorage.PatchRemind(ctx, todo.ID, todo.UserID, model.TodoPatch{})
+		require.NoError(t, err)
+		require.Equal(t, before.Version, got.Version)
+	})
+
+	t.Run("stale version", func(t *testing.T) {
+		title := "stale"
+		_, err := testTodoStorage.PatchRemind(ctx, todo.ID, todo.UserID, model.TodoPatch{Title: &title, Version: 1})
+		require.ErrorIs(t, err, model.ErrVersionMismatch)
+	})
+
+	t.Run("remind of another user", func(t *testing.T) {
+		title := "not mine"
+		_, err := testTodoStorage.PatchRemind(ctx, todo.ID, "another user", model.TodoPatch{Title: &title})
+		require.ErrorIs(t, err, model.ErrCantFindRemindWithID)
+	})
+
+	t.Run("completed recurring remind spawns next occurrence", func(t *testing.T) {
+		rule := "FREQ=WEEKLY;BYDAY=MO"
+		deadline := time.Date(2023, time.April, 3, 9, 0, 0, 0, time.UTC)
+
+		remind, err := testTodoStorage.CreateRemind(ctx, model.Todo{
+			Title:       "patched weekly",
+			Description: "patched weekly",
+			UserID:      todo.UserID,
+			CreatedAt:   time.Now(),
+			DeadlineAt:  deadline,
+			RRule:       &rule,
+		})
+		require.NoError(t, err)
+
+		_, err = testTodoStorage.CreateItem(ctx, remind.ID, todo.UserID, model.TodoItemInput{Title: "step"})
+		require.NoError(t, err)
+
+		tag, err := testTagStorage.CreateTag(ctx, todo.UserID, model.TagInput{Name: "weekly"})
+		require.NoError(t, err)
+
+		completed := true
+		finishedAt := deadline.Add(-time.Hour)
+		tags := []int{tag.ID}
+		got, err := testTodoStorage.PatchRemind(ctx, remind.ID, todo.UserID, model.TodoPatch{Completed: &completed, FinishedAt: &finishedAt, Tags: &tags})
+		require.NoError(t, err)
+		require.True(t, got.Completed)
+		require.Equal(t, finishedAt, got.FinishedAt.UTC())
+
+		reminds, _, _, err := testTodoStorage.GetReminds(ctx, model.FetchParams{
+			Page:          utils.Page{Limit: 10},
+			FilterByDate:  "CreatedAt",
+			FilterBySort:  "DESC",
+			FilterByQuery: "current",
+		}, todo.UserID)
+		require.NoError(t, err)
+
+		var next model.Todo
+		for _, r := range reminds {
+			if r.Title == "patched weekly" {
+				next = r
+			}
+		}
+		require.NotZero(t, next.ID)
+		require.NotEqual(t, remind.ID, next.ID)
+		require.Equal(t, deadline.AddDate(0, 0, 7), next.DeadlineAt)
+		require.Equal(t, rule, *next.RRule)
+		require.Len(t, next.Tags, 1)
+		require.Equal(t, tag.ID, next.Tags[0].ID)
+
+		items, err := testTodoStorage.GetItems(ctx, next.ID, todo.UserID)
+		require.NoError(t, err)
+		require.Len(t, items, 1)
+		require.Equal(t, "step", items[0].Title)
+		require.False(t, items[0].Completed)
+	})
 }
 
 func TestStorageTodo_SeedTodos(t *testing.T) {
@@ -359,9 +677,10 @@ func TestTodoStorage_UpdateNotification(t *testing.T) {
 	require.NoError(t, err)
 
 	type args struct {
-		ctx context.Context
-		id  int
-		dao model.NotificationDAO
+		ctx    context.Context
+		id     int
+		userID string
+		dao    model.NotificationDAO
 	}
 	ctx := context.Background()
 	tests := []struct {
@@ -370,29 +689,38 @@ func TestTodoStorage_UpdateNotification(t *testing.T) {
 		wantErr bool
 	}{
 		{name: "success", args: args{
-			ctx: ctx,
-			id:  expectedTodo[0].ID,
-			dao: model.NotificationDAO{Notificated: true},
+			ctx:    ctx,
+			id:     expectedTodo[0].ID,
+			userID: expectedTodo[0].UserID,
+			dao:    model.NotificationDAO{Notificated: true},
 		}, wantErr: false,
 		},
 		{name: "error doesn't existing remind", args: args{
-			ctx: ctx,
-			id:  9999,
-			dao: model.NotificationDAO{Notificated: true},
+			ctx:    ctx,
+			id:     9999,
+			userID: expectedTodo[0].UserID,
+			dao:    model.NotificationDAO{Notificated: true},
+		}, wantErr: true,
+		},
+		{name: "error remind of another user", args: args{
+			ctx:    ctx,
+			id:     expectedTodo[0].ID,
+			userID: "another user",
+			dao:    model.NotificationDAO{Notificated: false},
 		}, wantErr: true,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 
-			err = testTodoStorage.UpdateNotification(tt.args.ctx, tt.args.id, tt.args.dao)
+			err = testTodoStorage.UpdateNotification(tt.args.ctx, tt.args.id, tt.args.userID, tt.args.dao)
 			if tt.wantErr {
-				require.Error(t, err)
+				require.ErrorIs(t, err, model.ErrCantFindRemindWithID)
 				return
 			}
 			require.NoError(t, err)
 
-			remind, err := testTodoStorage.GetRemindByID(ctx, expectedTodo[0].ID)
+			remind, err := testTodoStorage.GetRemindByID(ctx, expectedTodo[0].ID, expectedTodo[0].UserID)
 			require.NoError(t, err)
 
 			require.Equal(t, tt.args.dao.Notificated, remind.Notificated)
@@ -412,9 +740,10 @@ func TestTodoStorage_UpdateStatus(t *testing.T) {
 	tn := time.Now().UTC()
 
 	type args struct {
-		ctx context.Context
-		id  int
-		dao model.TodoUpdateStatusInput
+		ctx    context.Context
+		id     int
+		userID string
+		dao    model.TodoUpdateStatusInput
 	}
 	ctx := context.Background()
 	tests := []struct {
@@ -422,9 +751,20 @@ func TestTodoStorage_UpdateStatus(t *testing.T) {
 		args    args
 		wantErr bool
 	}{
+		{name: "error remind of another user", args: args{
+			ctx:    ctx,
+			id:     expectedTodo[0].ID,
+			userID: "another user",
+			dao: model.TodoUpdateStatusInput{
+				Completed:  true,
+				FinishedAt: &tn,
+			},
+		}, wantErr: true,
+		},
 		{name: "success", args: args{
-			ctx: ctx,
-			id:  expectedTodo[0].ID,
+			ctx:    ctx,
+			id:     expectedTodo[0].ID,
+			userID: expectedTodo[0].UserID,
 			dao: model.TodoUpdateStatusInput{
 				Completed:  true,
 				FinishedAt: &tn,
@@ -432,8 +772,9 @@ func TestTodoStorage_UpdateStatus(t *testing.T) {
 		}, wantErr: false,
 		},
 		{name: "error doesn't existing remind", args: args{
-			ctx: ctx,
-			id:  9999,
+			ctx:    ctx,
+			id:     9999,
+			userID: expectedTodo[0].UserID,
 			dao: model.TodoUpdateStatusInput{
 				Completed:  true,
 				FinishedAt: &tn,
@@ -444,133 +785,80 @@ func TestTodoStorage_UpdateStatus(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 
-			err = testTodoStorage.UpdateStatus(tt.args.ctx, tt.args.id, tt.args.dao)
+			err = testTodoStorage.UpdateStatus(tt.args.ctx, tt.args.id, tt.args.userID, tt.args.dao)
 			if tt.wantErr {
-				require.Error(t, err)
+				require.ErrorIs(t, err, model.ErrCantFindRemindWithID)
+
+				remind, err := testTodoStorage.GetRemindByID(ctx, expectedTodo[0].ID, expectedTodo[0].UserID)
+				require.NoError(t, err)
+				require.False(t, remind.Completed)
 				return
 			}
 			require.NoError(t, err)
 
-			remind, err := testTodoStorage.GetRemindByID(ctx, expectedTodo[0].ID)
+			remind, err := testTodoStorage.GetRemindByID(ctx, expectedTodo[0].ID, expectedTodo[0].UserID)
 			require.NoError(t, err)
 
 			require.Equal(t, tt.args.dao.FinishedAt.Truncate(time.Millisecond), remind.FinishedAt.Truncate(time.Millisecond))
 			require.Equal(t, tt.args.dao.Completed, remind.Completed)
 		})
 	}
-}
 
-func TestStorage_GetRemindsForNotification(t *testing.T) {
-	defer func() {
-		err := Truncate()
-		if err != nil {
-			log.Fatal("error truncate table")
-		}
-	}()
-
-	_, err := SeedTodosForDeadline()
-	if err != nil {
-		log.Fatal("error seed reminds")
-	}
-	require.NoError(t, err)
-
-	t.Run("success", func(t *testing.T) {
-		reminds, err := testTodoStorage.GetRemindsForNotification(context.Background())
+	t.Run("recurring remind spawns next occurrence", func(t *testing.T) {
+		rule := "FREQ=WEEKLY;BYDAY=MO"
+		deadline := time.Date(2023, time.April, 3, 9, 0, 0, 0, time.UTC)
+
+		remind, err := testTodoStorage.CreateRemind(ctx, model.Todo{
+			Title:       "weekly",
+			Description: "weekly",
+			UserID:      expectedTodo[0].UserID,
+			CreatedAt:   time.Now(),
+			DeadlineAt:  deadline,
+			RRule:       &rule,
+		})
 		require.NoError(t, err)
-		require.Equal(t, 0, len(reminds))
-
-		tn := time.Now().Truncate(1 * time.Second).UTC()
-
-		for _, remind := range reminds {
-			userID := remind.UserID
-			userConfig, err := testConfigStorage.GetUserConfigs(context.Background(), userID)
-			if err != nil {
-				log.Fatal("error to get userConfig")
-			}
-			tfromPeriod := time.Now().AddDate(0, 0, userConfig.Period)
-			expr := remind.DeadlineAt.After(tn) && remind.DeadlineAt.Before(tfromPeriod)
-			require.Equal(t, true, expr)
-		}
 
-		err = Truncate()
-		if err != nil {
-			log.Fatal("error truncate table")
-		}
+		finishedAt := deadline.Add(-time.Hour)
+		err = testTodoStorage.UpdateStatus(ctx, remind.ID, expectedTodo[0].UserID, model.TodoUpdateStatusInput{Completed: true, FinishedAt: &finishedAt})
 		require.NoError(t, err)
-	})
 
-	t.Run("no reminds for Notification at this moment", func(t *testing.T) {
-		reminds, err := testTodoStorage.GetRemindsForNotification(context.Background())
+		reminds, _, _, err := testTodoStorage.GetReminds(ctx, model.FetchParams{
+			Page:          utils.Page{Limit: 10},
+			FilterByDate:  "CreatedAt",
+			FilterBySort:  "DESC",
+			FilterByQuery: "current",
+		}, expectedTodo[0].UserID)
 		require.NoError(t, err)
-		require.Empty(t, reminds)
-	})
-}
 
-func TestStorage_GetRemindsForDeadlineNotification(t *testing.T) {
-	defer func() {
-		err := Truncate()
-		if err != nil {
-			log.Fatal("error truncate table")
+		var next model.Todo
+		for _, r := range reminds {
+			if r.Title == "weekly" {
+				next = r
+			}
 		}
-	}()
-
-	expectedReminds, err := SeedTodosForDeadline()
-	if err != nil {
-		log.Fatal("error seed reminds")
-	}
-	require.NoError(t, err)
+		require.NotZero(t, next.ID)
+		require.NotEqual(t, remind.ID, next.ID)
+		require.Equal(t, deadline.AddDate(0, 0, 7), next.DeadlineAt)
+		require.Equal(t, rule, *next.RRule)
 
-	tn := time.Now().Truncate(time.Minute).Format(time.RFC3339)
-
-	t.Run("success", func(t *testing.T) {
-		reminds, timeNow, err := testTodoStorage.GetRemindsForDeadlineNotification(context.Background())
+		// completing already completed remind doesn't spawn one more occurrence
+		err = testTodoStorage.UpdateStatus(ctx, remind.ID, expectedTodo[0].UserID, model.TodoUpdateStatusInput{Completed: true, FinishedAt: &finishedAt})
 		require.NoError(t, err)
-		require.Equal(t, 1, len(reminds))
-		require.Equal(t, tn, timeNow)
-		require.Equal(t, expectedReminds[0].UserID, reminds[0].UserID)
 
-		err = Truncate()
-		if err != nil {
-			log.Fatal("error truncate table")
-		}
+		reminds, _, _, err = testTodoStorage.GetReminds(ctx, model.FetchParams{
+			Page:          utils.Page{Limit: 10},
+			FilterByDate:  "CreatedAt",
+			FilterBySort:  "DESC",
+			FilterByQuery: "all",
+		}, expectedTodo[0].UserID)
 		require.NoError(t, err)
-	})
 
-	t.Run("no reminds for DeadlineNotification at this moment", func(t *testing.T) {
-		reminds, timeNow, err := testTodoStorage.GetRemindsForDeadlineNotification(context.Background())
-		require.Empty(t, reminds)
-		require.Equal(t, tn, timeNow)
-		require.Empty(t, err)
-	})
-}
-
-func TestStorage_UpdateNotifyPeriod(t *testing.T) {
-	defer func() {
-		err := Truncate()
-		if err != nil {
-			log.Fatal("error truncate table")
+		var weekly int
+		for _, r := range reminds {
+			if r.Title == "weekly" {
+				weekly++
+			}
 		}
-	}()
-
-	expectedTodos, err := SeedTodosForDeadline()
-	if err != nil {
-		log.Fatal("error seed reminds")
-	}
-	require.NoError(t, err)
-
-	t.Run("success", func(t *testing.T) {
-		err = testTodoStorage.UpdateNotifyPeriod(context.Background(), expectedTodos[0].ID, (expectedTodos[0].NotifyPeriod[0]).Format("2006-01-02 15:04:05"))
-		require.NoError(t, err)
+		require.Equal(t, 2, weekly)
 	})
-
-	t.Run("remind not found", func(t *testing.T) {
-		err = testTodoStorage.UpdateNotifyPeriod(context.Background(), 0, (expectedTodos[0].NotifyPeriod[0]).Format("2006-01-02 15:04:05"))
-		require.Error(t, err)
-	})
-
-	t.Run("empty timeToDelete", func(t *testing.T) {
-		err = testTodoStorage.UpdateNotifyPeriod(context.Background(), 0, "")
-		require.Error(t, err)
-	})
-
 }
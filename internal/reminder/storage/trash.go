@@ -0,0 +1,131 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	model "github.com/red-rocket-software/reminder-go/internal/reminder/domain"
+)
+
+// GetTrash returns reminds in trash which the user owns, so could delete them, recently deleted first
+func (s *TodoStorage) GetTrash(ctx context.Context, userID string) ([]model.Todo, error) {
+	reminds := []model.Todo{}
+
+	sql := `SELECT ` + todoColumns + `, ` + progressColumns + ` FROM reminder.todo
+WHERE "ID" IN (` + trashWithRole("$1", model.RoleOwner) + `) ORDER BY "DeletedAt" DESC, "ID" DESC`
+
+	rows, err := s.Postgres.Query(ctx, sql, userID)
+	if err != nil {
+		s.logger.Errorf("error get trash from db: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var remind model.Todo
+
+		if err := rows.Scan(append(todoFields(&remind), progressFields(&remind)...)...); err != nil {
+			s.logger.Errorf("remind doesnt exist: %v", err)
+			return nil, err
+		}
+		reminds = append(reminds, remind)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	rows.Close()
+
+	if err := attachTags(ctx, s.Postgres, reminds); err != nil {
+		s.logger.Errorf("error get tags of reminds: %v", err)
+		return nil, err
+	}
+
+	return reminds, nil
+}
+
+// RestoreRemind moves remind back from trash, it can be done by owners of the remind like deletion
+func (s *TodoStorage) RestoreRemind(ctx context.Context, id int, userID string) error {
+	tx, err := s.Postgres.Begin(ctx)
+	if err != nil {
+		s.logger.Errorf("unable to begin transaction: %v", err)
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	var (
+		deletedAt time.Time
+		authorID  string
+	)
+
+	err = tx.QueryRow(ctx, `SELECT "DeletedAt", "User" FROM reminder.todo
+WHERE "ID" = $1 AND "ID" IN (`+trashWithRole("$2", model.RoleOwner)+`) FOR UPDATE`, id, userID).Scan(&deletedAt, &authorID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return model.ErrCantFindRemindWithID
+	}
+	if err != nil {
+		s.logger.Errorf("unable to get remind from trash: %v", err)
+		return err
+	}
+
+	const sql = `UPDATE reminder.todo SET "DeletedAt" = NULL WHERE "ID" = $1`
+
+	if _, err := tx.Exec(ctx, sql, id); err != nil {
+		s.logger.Errorf("unable to restore remind: %v", err)
+		return err
+	}
+
+	if err := recordHistory(ctx, tx, id, authorID, userID, model.HistoryRestore, deletedChanges(&deletedAt, nil)); err != nil {
+		s.logger.Errorf("unable to record remind history: %v", err)
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// PurgeRemind deletes remind from trash, it can be done by owners of the remind like deletion. Its checklist, tags and members are deleted by cascade.
+// History of the remind is kept
+func (s *TodoStorage) PurgeRemind(ctx context.Context, id int, userID string) error {
+	tx, err := s.Postgres.Begin(ctx)
+	if err != nil {
+		s.logger.Errorf("unable to begin transaction: %v", err)
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	var authorID string
+
+	sql := `DELETE FROM reminder.todo WHERE "ID" = $1 AND "ID" IN (` + trashWithRole("$2", model.RoleOwner) + `) RETURNING "User"`
+	err = tx.QueryRow(ctx, sql, id, userID).Scan(&authorID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return model.ErrCantFindRemindWithID
+	}
+	if err != nil {
+		s.logger.Errorf("Error purge remind: %v", err)
+		return model.ErrDeleteFailed
+	}
+
+	if err := recordHistory(ctx, tx, id, authorID, userID, model.HistoryPurge, nil); err != nil {
+		s.logger.Errorf("Error record remind history: %v", err)
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// PurgeTrash deletes reminds of all users which are in trash since before given time
+func (s *TodoStorage) PurgeTrash(ctx context.Context, before time.Time) (int64, error) {
+	// purge is recorded in history of each remind in the same statement
+	const sql = `WITH purged AS (DELETE FROM reminder.todo WHERE "DeletedAt" < $1 RETURNING "ID", "User")
+INSERT INTO reminder.todo_history ("TodoID", "Owner", "Actor", "Action", "CreatedAt")
+SELECT "ID", "User", $2, $3, $4 FROM purged`
+
+	ct, err := s.Postgres.Exec(ctx, sql, before, model.ActorSystem, model.HistoryPurge, time.Now())
+	if err != nil {
+		s.logger.Errorf("Error purge trash: %v", err)
+		return 0, err
+	}
+
+	return ct.RowsAffected(), nil
+}
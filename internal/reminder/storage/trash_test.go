@@ -0,0 +1,152 @@
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	model "github.com/red-rocket-software/reminder-go/internal/reminder/domain"
+	"github.com/red-rocket-software/reminder-go/pkg/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStorageTodo_Trash(t *testing.T) {
+	defer func() {
+		err := Truncate()
+		require.NoError(t, err)
+	}()
+
+	expectedTodo, err := SeedTodos()
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	userID := expectedTodo[0].UserID
+	todo := expectedTodo[0]
+
+	params := model.FetchParams{
+		Page:          utils.Page{Limit: 10},
+		FilterByDate:  "CreatedAt",
+		FilterBySort:  "ASC",
+		FilterByQuery: "all",
+	}
+
+	t.Run("deleted remind is moved to trash", func(t *testing.T) {
+		err := testTodoStorage.DeleteRemind(ctx, todo.ID, userID)
+		require.NoError(t, err)
+
+		_, err = testTodoStorage.GetRemindByID(ctx, todo.ID, userID)
+		require.ErrorIs(t, err, model.ErrCantFindRemindWithID)
+
+		_, count, _, err := testTodoStorage.GetReminds(ctx, params, userID)
+		require.NoError(t, err)
+		require.Equal(t, len(expectedTodo)-1, count)
+
+		_, err = testTodoStorage.UpdateRemind(ctx, todo.ID, userID, model.TodoUpdateInput{Title: "trashed", DeadlineAt: "2023-04-15T16:27:00Z"})
+		require.ErrorIs(t, err, model.ErrCantFindRemindWithID)
+
+		err = testTodoStorage.DeleteRemind(ctx, todo.ID, userID)
+		require.ErrorIs(t, err, model.ErrCantFindRemindWithID)
+
+		trash, err := testTodoStorage.GetTrash(ctx, userID)
+		require.NoError(t, err)
+		require.Len(t, trash, 1)
+		require.Equal(t, todo.ID, trash[0].ID)
+		require.NotNil(t, trash[0].DeletedAt)
+	})
+
+	t.Run("trash of another user", func(t *testing.T) {
+		trash, err := testTodoStorage.GetTrash(ctx, "another user")
+		require.NoError(t, err)
+		require.Empty(t, trash)
+
+		err = testTodoStorage.RestoreRemind(ctx, todo.ID, "another user")
+		require.ErrorIs(t, err, model.ErrCantFindRemindWithID)
+
+		err = testTodoStorage.PurgeRemind(ctx, todo.ID, "another user")
+		require.ErrorIs(t, err, model.ErrCantFindRemindWithID)
+	})
+
+	t.Run("restore remind", func(t *testing.T) {
+		err := testTodoStorage.RestoreRemind(ctx, todo.ID, userID)
+		require.NoError(t, err)
+
+		got, err := testTodoStorage.GetRemindByID(ctx, todo.ID, userID)
+		require.NoError(t, err)
+		require.Nil(t, got.DeletedAt)
+
+		err = testTodoStorage.RestoreRemind(ctx, todo.ID, userID)
+		require.ErrorIs(t, err, model.ErrCantFindRemindWithID)
+	})
+
+	t.Run("purge remind", func(t *testing.T) {
+		// remind which isn't in trash can't be purged
+		err := testTodoStorage.PurgeRemind(ctx, todo.ID, userID)
+		require.ErrorIs(t, err, model.ErrCantFindRemindWithID)
+
+		require.NoError(t, testTodoStorage.DeleteRemind(ctx, todo.ID, userID))
+
+		err = testTodoStorage.PurgeRemind(ctx, todo.ID, userID)
+		require.NoError(t, err)
+
+		trash, err := testTodoStorage.GetTrash(ctx, userID)
+		require.NoError(t, err)
+		require.Empty(t, trash)
+	})
+
+	t.Run("purge trash after retention period", func(t *testing.T) {
+		require.NoError(t, testTodoStorage.DeleteRemind(ctx, expectedTodo[1].ID, userID))
+
+		purged, err := testTodoStorage.PurgeTrash(ctx, time.Now().AddDate(0, 0, -30))
+		require.NoError(t, err)
+		require.Zero(t, purged)
+
+		purged, err = testTodoStorage.PurgeTrash(ctx, time.Now().Add(time.Minute))
+		require.NoError(t, err)
+		require.Equal(t, int64(1), purged)
+
+		trash, err := testTodoStorage.GetTrash(ctx, userID)
+		require.NoError(t, err)
+		require.Empty(t, trash)
+	})
+
+	t.Run("shared remind in trash", func(t *testing.T) {
+		shared := expectedTodo[2]
+		ownerID, editorID := "co-owner", "editor"
+
+		_, err := testTodoStorage.AddMember(ctx, shared.ID, userID, model.MemberInput{UserID: &ownerID, Role: model.RoleOwner})
+		require.NoError(t, err)
+		_, err = testTodoStorage.AddMember(ctx, shared.ID, userID, model.MemberInput{UserID: &editorID, Role: model.RoleEditor})
+		require.NoError(t, err)
+
+		require.NoError(t, testTodoStorage.DeleteRemind(ctx, shared.ID, ownerID))
+
+		// members who couldn't delete the remind don't see it in trash
+		trash, err := testTodoStorage.GetTrash(ctx, editorID)
+		require.NoError(t, err)
+		require.Empty(t, trash)
+		require.ErrorIs(t, testTodoStorage.RestoreRemind(ctx, shared.ID, editorID), model.ErrCantFindRemindWithID)
+		require.ErrorIs(t, testTodoStorage.PurgeRemind(ctx, shared.ID, editorID), model.ErrCantFindRemindWithID)
+
+		for _, id := range []string{userID, ownerID} {
+			trash, err := testTodoStorage.GetTrash(ctx, id)
+			require.NoError(t, err)
+			require.Len(t, trash, 1)
+			require.Equal(t, shared.ID, trash[0].ID)
+		}
+
+		require.NoError(t, testTodoStorage.RestoreRemind(ctx, shared.ID, ownerID))
+
+		history, err := testTodoStorage.GetHistory(ctx, shared.ID, userID)
+		require.NoError(t, err)
+		last := history[len(history)-1]
+		require.Equal(t, model.HistoryRestore, last.Action)
+		require.Equal(t, ownerID, last.Actor)
+
+		require.NoError(t, testTodoStorage.DeleteRemind(ctx, shared.ID, userID))
+		require.NoError(t, testTodoStorage.PurgeRemind(ctx, shared.ID, ownerID))
+
+		trash, err = testTodoStorage.GetTrash(ctx, userID)
+		require.NoError(t, err)
+		require.Empty(t, trash)
+	})
+}
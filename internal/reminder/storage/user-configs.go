@@ -27,30 +27,89 @@ func NewConfigsStorage(postgres *pgxpool.Pool, logger *logging.Logger) model.Con
 	return &ConfigsStorage{Postgres: postgres, logger: logger}
 }
 
-// UpdateUserConfig update user_configs. Changes notification or period
-func (s *ConfigsStorage) UpdateUserConfig(ctx context.Context, id string, input model.UserConfigs) error {
+// UpdateUserConfig update user_configs. Changes notification, period and channels if input version is 0 or the current one,
+// nil channels are kept. Returns updated configs with the new version
+func (s *ConfigsStorage) UpdateUserConfig(ctx context.Context, id string, input model.UserConfigs) (model.UserConfigs, error) {
 	tn := time.Now()
-	const sql = `UPDATE reminder.users_configs SET "Notification" = $1, "Period" = $2, "UpdatedAt" = $3 WHERE "ID" = $4`
+	const sql = `UPDATE reminder.users_configs SET "Notification" = $1, "Period" = $2, "UpdatedAt" = $3, "Channels" = COALESCE($6, "Channels"), "Version" = "Version" + 1
+WHERE "ID" = $4 AND ($5 = 0 OR "Version" = $5)
+RETURNING "ID", "Notification", "Period", "CreatedAt", "UpdatedAt", "Version", "Channels"`
+
+	// nil interface is bound as NULL, nil slice would be stored as JSON null
+	var channels any
+	if input.Channels != nil {
+		channels = input.Channels
+	}
 
-	ct, err := s.Postgres.Exec(ctx, sql, input.Notification, input.Period, tn, id)
+	var updated model.UserConfigs
 
+	err := s.Postgres.QueryRow(ctx, sql, input.Notification, input.Period, tn, id, input.Version, channels).Scan(
+		&updated.ID,
+		&updated.Notification,
+		&updated.Period,
+		&updated.CreatedAt,
+		&updated.UpdatedAt,
+		&updated.Version,
+		&updated.Channels,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		configs, err := s.GetUserConfigs(ctx, id)
+		if err != nil {
+			return model.UserConfigs{}, err
+		}
+		if configs.ID != "" {
+			return model.UserConfigs{}, model.ErrVersionMismatch
+		}
+		return model.UserConfigs{}, model.ErrCantFindUserConfigs
+	}
 	if err != nil {
 		s.logger.Errorf("unable to update user-config %v", err)
+		return model.UserConfigs{}, err
+	}
+
+	return updated, nil
+}
+
+// SetCalendarToken sets hash of calendar feed token of the user, nil revokes the feed
+func (s *ConfigsStorage) SetCalendarToken(ctx context.Context, userID string, tokenHash *string) error {
+	const sql = `UPDATE reminder.users_configs SET "CalendarToken" = $1 WHERE "ID" = $2`
+
+	ct, err := s.Postgres.Exec(ctx, sql, tokenHash, userID)
+	if err != nil {
+		s.logger.Errorf("unable to set calendar token %v", err)
 		return err
 	}
 
 	if ct.RowsAffected() == 0 {
-		return errors.New("user configs not found")
+		return model.ErrCantFindUserConfigs
 	}
 
 	return nil
 }
 
+// GetUserByCalendarToken returns id of the user with given hash of calendar feed token
+func (s *ConfigsStorage) GetUserByCalendarToken(ctx context.Context, tokenHash string) (string, error) {
+	var userID string
+
+	const sql = `SELECT "ID" FROM reminder.users_configs WHERE "CalendarToken" = $1`
+
+	err := s.Postgres.QueryRow(ctx, sql, tokenHash).Scan(&userID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", model.ErrCantFindUserConfigs
+	}
+	if err != nil {
+		s.logger.Errorf("unable to get user by calendar token %v", err)
+		return "", err
+	}
+
+	return userID, nil
+}
+
 // GetUserConfigs returns user configs from database
 func (s *ConfigsStorage) GetUserConfigs(ctx context.Context, userID string) (model.UserConfigs, error) {
 	var configs model.UserConfigs
 
-	const sql = `SELECT "ID", "Notification", "Period", "CreatedAt", "UpdatedAt"  FROM reminder.users_configs
+	const sql = `SELECT "ID", "Notification", "Period", "CreatedAt", "UpdatedAt", "Version", "Channels" FROM reminder.users_configs
     WHERE "ID" = $1 LIMIT 1`
 
 	row := s.Postgres.QueryRow(ctx, sql, userID)
@@ -61,6 +120,8 @@ func (s *ConfigsStorage) GetUserConfigs(ctx context.Context, userID string) (mod
 		&configs.Period,
 		&configs.CreatedAt,
 		&configs.UpdatedAt,
+		&configs.Version,
+		&configs.Channels,
 	)
 	if errors.Is(err, pgx.ErrNoRows) {
 		return model.UserConfigs{}, nil
@@ -83,7 +144,7 @@ func (s *ConfigsStorage) CreateUserConfigs(ctx context.Context, userID string) (
 	userConfig.CreatedAt = time.Now()
 
 	const sql = `INSERT INTO reminder.users_configs ("ID", "Notification",  "Period", "CreatedAt") 
-				 VALUES ($1, $2, $3, $4) returning "ID", "Notification",  "Period", "CreatedAt", "UpdatedAt"`
+				 VALUES ($1, $2, $3, $4) returning "ID", "Notification",  "Period", "CreatedAt", "UpdatedAt", "Version", "Channels"`
 	row := s.Postgres.QueryRow(ctx, sql, userConfig.ID, userConfig.Notification, userConfig.Period, userConfig.CreatedAt)
 	err := row.Scan(
 		&userConfig.ID,
@@ -91,6 +152,8 @@ func (s *ConfigsStorage) CreateUserConfigs(ctx context.Context, userID string) (
 		&userConfig.Period,
 		&userConfig.CreatedAt,
 		&userConfig.UpdatedAt,
+		&userConfig.Version,
+		&userConfig.Channels,
 	)
 	log.Print("CreatedAt ", userConfig.CreatedAt)
 	if err != nil {
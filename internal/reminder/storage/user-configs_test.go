@@ -33,19 +33,66 @@ func TestStorageTodo_UpdateUserConfig(t *testing.T) {
 	}
 
 	t.Run("success", func(t *testing.T) {
-		err = testConfigStorage.UpdateUserConfig(context.Background(), expectedUserID, updateConfigInput)
+		_, err = testConfigStorage.UpdateUserConfig(context.Background(), expectedUserID, updateConfigInput)
 		require.NoError(t, err)
 	})
 	t.Run("empty input", func(t *testing.T) {
-		err = testConfigStorage.UpdateUserConfig(context.Background(), expectedUserID, model.UserConfigs{})
+		_, err = testConfigStorage.UpdateUserConfig(context.Background(), expectedUserID, model.UserConfigs{})
 		require.NoError(t, err)
 	})
 
 	t.Run("user configs not found", func(t *testing.T) {
-		err = testConfigStorage.UpdateUserConfig(context.Background(), "0", updateConfigInput)
-		require.Error(t, err)
+		_, err = testConfigStorage.UpdateUserConfig(context.Background(), "0", updateConfigInput)
+		require.ErrorIs(t, err, model.ErrCantFindUserConfigs)
+	})
+
+	t.Run("version", func(t *testing.T) {
+		configs, err := testConfigStorage.GetUserConfigs(context.Background(), expectedUserID)
+		require.NoError(t, err)
+
+		stale := updateConfigInput
+		stale.Version = configs.Version - 1
+		_, err = testConfigStorage.UpdateUserConfig(context.Background(), expectedUserID, stale)
+		require.ErrorIs(t, err, model.ErrVersionMismatch)
+
+		current := updateConfigInput
+		current.Version = configs.Version
+		returned, err := testConfigStorage.UpdateUserConfig(context.Background(), expectedUserID, current)
+		require.NoError(t, err)
+		require.Equal(t, configs.Version+1, returned.Version)
+
+		updated, err := testConfigStorage.GetUserConfigs(context.Background(), expectedUserID)
+		require.NoError(t, err)
+		require.Equal(t, updated, returned)
 	})
 
+	t.Run("channels", func(t *testing.T) {
+		channels := []model.ChannelPreference{
+			{Channel: model.ChannelEmail},
+			{Channel: model.ChannelNtfy, Address: "my-reminds"},
+		}
+		withChannels := updateConfigInput
+		withChannels.Channels = channels
+		_, err = testConfigStorage.UpdateUserConfig(context.Background(), expectedUserID, withChannels)
+		require.NoError(t, err)
+
+		// channels are kept when they aren't in input
+		_, err = testConfigStorage.UpdateUserConfig(context.Background(), expectedUserID, updateConfigInput)
+		require.NoError(t, err)
+
+		configs, err := testConfigStorage.GetUserConfigs(context.Background(), expectedUserID)
+		require.NoError(t, err)
+		require.Equal(t, channels, configs.Channels)
+
+		// empty list removes channels
+		withChannels.Channels = []model.ChannelPreference{}
+		_, err = testConfigStorage.UpdateUserConfig(context.Background(), expectedUserID, withChannels)
+		require.NoError(t, err)
+
+		configs, err = testConfigStorage.GetUserConfigs(context.Background(), expectedUserID)
+		require.NoError(t, err)
+		require.Empty(t, configs.Channels)
+	})
 }
 
 func TestStorage_CreateUserConfigs(t *testing.T) {
@@ -0,0 +1,135 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	model "github.com/red-rocket-software/reminder-go/internal/reminder/domain"
+	"github.com/red-rocket-software/reminder-go/pkg/logging"
+)
+
+var _ model.ViewRepository = (*ViewStorage)(nil)
+
+// viewColumns lists reminder.views columns in the order viewFields returns them
+const viewColumns = `"ID", "User", "Name", "Definition", "CreatedAt"`
+
+// ViewStorage handles database communication with PostgreSQL.
+type ViewStorage struct {
+	// Postgres database.PGX
+	Postgres *pgxpool.Pool
+	// Logrus logger
+	logger *logging.Logger
+}
+
+// NewViewsStorage  return new ViewStorage with Postgres pool and logger
+func NewViewsStorage(postgres *pgxpool.Pool, logger *logging.Logger) model.ViewRepository {
+	return &ViewStorage{Postgres: postgres, logger: logger}
+}
+
+// viewFields returns pointers to view fields to scan a row selected with viewColumns
+func viewFields(view *model.View) []any {
+	return []any{
+		&view.ID,
+		&view.UserID,
+		&view.Name,
+		&view.Definition,
+		&view.CreatedAt,
+	}
+}
+
+// GetViews returns saved views of the user in order of creation
+func (s *ViewStorage) GetViews(ctx context.Context, userID string) ([]model.View, error) {
+	views := []model.View{}
+
+	const sql = `SELECT ` + viewColumns + ` FROM reminder.views WHERE "User" = $1 ORDER BY "ID"`
+
+	rows, err := s.Postgres.Query(ctx, sql, userID)
+	if err != nil {
+		s.logger.Errorf("error get views from db: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var view model.View
+
+		if err := rows.Scan(viewFields(&view)...); err != nil {
+			s.logger.Errorf("view doesn't exist: %v", err)
+			return nil, err
+		}
+		views = append(views, view)
+	}
+
+	return views, rows.Err()
+}
+
+// GetView returns saved view of the user by id
+func (s *ViewStorage) GetView(ctx context.Context, id int, userID string) (model.View, error) {
+	var view model.View
+
+	const sql = `SELECT ` + viewColumns + ` FROM reminder.views WHERE "ID" = $1 AND "User" = $2`
+
+	err := s.Postgres.QueryRow(ctx, sql, id, userID).Scan(viewFields(&view)...)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return model.View{}, model.ErrCantFindViewWithID
+	}
+	if err != nil {
+		s.logger.Errorf("unable to get view: %v", err)
+		return model.View{}, err
+	}
+
+	return view, nil
+}
+
+// CreateView store new view to DB PostgresSQL
+func (s *ViewStorage) CreateView(ctx context.Context, userID string, input model.ViewInput) (model.View, error) {
+	var view model.View
+
+	const sql = `INSERT INTO reminder.views ("User", "Name", "Definition", "CreatedAt") VALUES ($1, $2, $3, $4) RETURNING ` + viewColumns
+
+	row := s.Postgres.QueryRow(ctx, sql, userID, input.Name, input.Definition, time.Now())
+	if err := row.Scan(viewFields(&view)...); err != nil {
+		s.logger.Errorf("Error create view: %v", err)
+		return model.View{}, err
+	}
+
+	return view, nil
+}
+
+// UpdateView changes name and definition of view
+func (s *ViewStorage) UpdateView(ctx context.Context, id int, userID string, input model.ViewInput) (model.View, error) {
+	var view model.View
+
+	const sql = `UPDATE reminder.views SET "Name" = $1, "Definition" = $2 WHERE "ID" = $3 AND "User" = $4 RETURNING ` + viewColumns
+
+	err := s.Postgres.QueryRow(ctx, sql, input.Name, input.Definition, id, userID).Scan(viewFields(&view)...)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return model.View{}, model.ErrCantFindViewWithID
+	}
+	if err != nil {
+		s.logger.Errorf("unable to update view: %v", err)
+		return model.View{}, err
+	}
+
+	return view, nil
+}
+
+// DeleteView deletes saved view
+func (s *ViewStorage) DeleteView(ctx context.Context, id int, userID string) error {
+	const sql = `DELETE FROM reminder.views WHERE "ID" = $1 AND "User" = $2`
+
+	ct, err := s.Postgres.Exec(ctx, sql, id, userID)
+	if err != nil {
+		s.logger.Errorf("Error delete view: %v", err)
+		return model.ErrDeleteFailed
+	}
+
+	if ct.RowsAffected() == 0 {
+		return model.ErrCantFindViewWithID
+	}
+
+	return nil
+}
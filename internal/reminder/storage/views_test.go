@@ -0,0 +1,101 @@
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	model "github.com/red-rocket-software/reminder-go/internal/reminder/domain"
+	"github.com/red-rocket-software/reminder-go/pkg/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStorageTodo_Views(t *testing.T) {
+	defer func() {
+		err := Truncate()
+		require.NoError(t, err)
+	}()
+
+	expectedTodo, err := SeedTodos()
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	userID := expectedTodo[0].UserID
+
+	input := model.ViewInput{
+		Name: "soon",
+		Definition: model.ViewDefinition{
+			Filter:       "DeadlineAt",
+			FilterOption: "ASC",
+			FilterParams: "all",
+			Tags:         []int{1},
+			Deadline:     &model.ViewRange{From: "now", To: "today+7d"},
+		},
+	}
+
+	view, err := testViewStorage.CreateView(ctx, userID, input)
+	require.NoError(t, err)
+	require.Equal(t, input.Name, view.Name)
+	require.Equal(t, input.Definition, view.Definition)
+
+	t.Run("get views", func(t *testing.T) {
+		views, err := testViewStorage.GetViews(ctx, userID)
+		require.NoError(t, err)
+		require.Equal(t, []model.View{view}, views)
+
+		got, err := testViewStorage.GetView(ctx, view.ID, userID)
+		require.NoError(t, err)
+		require.Equal(t, view, got)
+	})
+
+	t.Run("error view of another user", func(t *testing.T) {
+		_, err := testViewStorage.GetView(ctx, view.ID, "another user")
+		require.ErrorIs(t, err, model.ErrCantFindViewWithID)
+
+		_, err = testViewStorage.UpdateView(ctx, view.ID, "another user", input)
+		require.ErrorIs(t, err, model.ErrCantFindViewWithID)
+	})
+
+	t.Run("update view", func(t *testing.T) {
+		input := input
+		input.Name = "later"
+		input.Definition.Deadline = nil
+
+		updated, err := testViewStorage.UpdateView(ctx, view.ID, userID, input)
+		require.NoError(t, err)
+		require.Equal(t, "later", updated.Name)
+		require.Nil(t, updated.Definition.Deadline)
+	})
+
+	t.Run("filter by deadline", func(t *testing.T) {
+		deadline := expectedTodo[0].DeadlineAt
+
+		_, count, _, err := testTodoStorage.GetReminds(ctx, model.FetchParams{
+			Page:          utils.Page{Limit: 10},
+			FilterByDate:  "CreatedAt",
+			FilterBySort:  "ASC",
+			FilterByQuery: "all",
+			Deadline: model.TimeRangeFilter{
+				StartRange: deadline.Format(time.RFC3339),
+				EndRange:   deadline.Add(time.Second).Format(time.RFC3339),
+			},
+		}, userID)
+		require.NoError(t, err)
+		require.GreaterOrEqual(t, count, 1)
+
+		_, count, _, err = testTodoStorage.GetReminds(ctx, model.FetchParams{
+			Page:          utils.Page{Limit: 10},
+			FilterByDate:  "CreatedAt",
+			FilterBySort:  "ASC",
+			FilterByQuery: "all",
+			Deadline:      model.TimeRangeFilter{EndRange: "2000-01-01T00:00:00Z"},
+		}, userID)
+		require.NoError(t, err)
+		require.Equal(t, 0, count)
+	})
+
+	t.Run("delete view", func(t *testing.T) {
+		require.NoError(t, testViewStorage.DeleteView(ctx, view.ID, userID))
+		require.ErrorIs(t, testViewStorage.DeleteView(ctx, view.ID, userID), model.ErrCantFindViewWithID)
+	})
+}
@@ -13,6 +13,7 @@ import (
 type Client interface {
 	VerifyIDToken(idToken string) (*auth.Token, error)
 	GetUser(userID string) (*auth.UserRecord, error)
+	GetUserByEmail(email string) (*auth.UserRecord, error)
 }
 
 type FirebaseClient struct {
@@ -42,3 +43,7 @@ func (f *FirebaseClient) VerifyIDToken(idToken string) (*auth.Token, error) {
 func (f *FirebaseClient) GetUser(userID string) (*auth.UserRecord, error) {
 	return f.client.GetUser(f.context, userID)
 }
+
+func (f *FirebaseClient) GetUserByEmail(email string) (*auth.UserRecord, error) {
+	return f.client.GetUserByEmail(f.context, email)
+}
@@ -49,6 +49,21 @@ func (mr *MockClientMockRecorder) GetUser(userID interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockClient)(nil).GetUser), userID)
 }
 
+// GetUserByEmail mocks base method.
+func (m *MockClient) GetUserByEmail(email string) (*auth.UserRecord, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByEmail", email)
+	ret0, _ := ret[0].(*auth.UserRecord)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByEmail indicates an expected call of GetUserByEmail.
+func (mr *MockClientMockRecorder) GetUserByEmail(email interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByEmail", reflect.TypeOf((*MockClient)(nil).GetUserByEmail), email)
+}
+
 // VerifyIDToken mocks base method.
 func (m *MockClient) VerifyIDToken(idToken string) (*auth.Token, error) {
 	m.ctrl.T.Helper()
@@ -0,0 +1,209 @@
+package ical
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrInvalidCalendar = errors.New("invalid iCalendar")
+
+// Component is iCalendar component, e.g. VCALENDAR, VTODO or VALARM
+type Component struct {
+	Name       string
+	Properties []Property
+	Components []*Component
+}
+
+// Property is iCalendar property with parameters, names are upper case
+type Property struct {
+	Name   string
+	Params map[string]string
+	Value  string
+}
+
+// Get returns the first property with given name
+func (c *Component) Get(name string) (Property, bool) {
+	for _, p := range c.Properties {
+		if p.Name == name {
+			return p, true
+		}
+	}
+	return Property{}, false
+}
+
+// Text returns unescaped TEXT value of the first property with given name
+func (c *Component) Text(name string) string {
+	p, ok := c.Get(name)
+	if !ok {
+		return ""
+	}
+	return UnescapeText(p.Value)
+}
+
+// Decode parses iCalendar stream and returns its top level component
+func Decode(r io.Reader) (*Component, error) {
+	lines, err := unfold(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var root *Component
+	var stack []*Component
+
+	for i, line := range lines {
+		if line == "" {
+			continue
+		}
+
+		p, err := parseLine(line)
+		if err != nil {
+			return nil, fmt.Errorf("%w: line %d: %v", ErrInvalidCalendar, i+1, err)
+		}
+
+		switch p.Name {
+		case "BEGIN":
+			c := &Component{Name: strings.ToUpper(p.Value)}
+			if len(stack) > 0 {
+				parent := stack[len(stack)-1]
+				parent.Components = append(parent.Components, c)
+			} else if root == nil {
+				root = c
+			} else {
+				return nil, fmt.Errorf("%w: line %d: more than one top level component", ErrInvalidCalendar, i+1)
+			}
+			stack = append(stack, c)
+		case "END":
+			if len(stack) == 0 || stack[len(stack)-1].Name != strings.ToUpper(p.Value) {
+				return nil, fmt.Errorf("%w: line %d: unexpected END:%s", ErrInvalidCalendar, i+1, p.Value)
+			}
+			stack = stack[:len(stack)-1]
+		default:
+			if len(stack) == 0 {
+				return nil, fmt.Errorf("%w: line %d: property outside of component", ErrInvalidCalendar, i+1)
+			}
+			c := stack[len(stack)-1]
+			c.Properties = append(c.Properties, p)
+		}
+	}
+
+	if root == nil {
+		return nil, fmt.Errorf("%w: no components", ErrInvalidCalendar)
+	}
+	if len(stack) > 0 {
+		return nil, fmt.Errorf("%w: %s isn't ended", ErrInvalidCalendar, stack[len(stack)-1].Name)
+	}
+
+	return root, nil
+}
+
+// unfold joins folded content lines
+func unfold(r io.Reader) ([]string, error) {
+	var lines []string
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimSuffix(scanner.Text(), "\r")
+		if len(lines) > 0 && (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+
+	return lines, scanner.Err()
+}
+
+// parseLine parses content line "NAME;PARAM=VALUE:value", colons inside quoted parameter values are allowed
+func parseLine(line string) (Property, error) {
+	p := Property{Params: map[string]string{}}
+
+	quoted := false
+	end := -1
+	for i, r := range line {
+		if r == '"' {
+			quoted = !quoted
+		}
+		if r == ':' && !quoted {
+			end = i
+			break
+		}
+	}
+	if end < 0 {
+		return Property{}, errors.New("no value")
+	}
+
+	parts := strings.Split(line[:end], ";")
+	p.Name = strings.ToUpper(parts[0])
+	p.Value = line[end+1:]
+
+	for _, param := range parts[1:] {
+		name, value, ok := strings.Cut(param, "=")
+		if !ok {
+			return Property{}, fmt.Errorf("wrong parameter %q", param)
+		}
+		p.Params[strings.ToUpper(name)] = strings.Trim(value, `"`)
+	}
+
+	return p, nil
+}
+
+// UnescapeText unescapes TEXT value
+func UnescapeText(s string) string {
+	return strings.NewReplacer(`\\`, `\`, `\;`, `;`, `\,`, `,`, `\n`, "\n", `\N`, "\n").Replace(s)
+}
+
+// ParseTime parses DATE-TIME or DATE value of property. Time with TZID is converted from that zone,
+// floating time and unknown zones are treated as UTC
+func ParseTime(p Property) (time.Time, error) {
+	loc := time.UTC
+	if tzid, ok := p.Params["TZID"]; ok {
+		if l, err := time.LoadLocation(tzid); err == nil {
+			loc = l
+		}
+	}
+
+	switch {
+	case p.Params["VALUE"] == "DATE" || len(p.Value) == len("20060102"):
+		return time.ParseInLocation("20060102", p.Value, loc)
+	case strings.HasSuffix(p.Value, "Z"):
+		return time.Parse("20060102T150405Z", p.Value)
+	default:
+		return time.ParseInLocation("20060102T150405", p.Value, loc)
+	}
+}
+
+var durationPattern = regexp.MustCompile(`^([+-])?P(?:(\d+)W)?(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+)S)?)?$`)
+
+// ParseDuration parses DURATION value, e.g. -PT15M or P1DT2H
+func ParseDuration(s string) (time.Duration, error) {
+	m := durationPattern.FindStringSubmatch(s)
+	if m == nil || s == "P" || s == "-P" || s == "+P" || strings.HasSuffix(s, "T") {
+		return 0, fmt.Errorf("%w: wrong duration %q", ErrInvalidCalendar, s)
+	}
+
+	units := []time.Duration{7 * 24 * time.Hour, 24 * time.Hour, time.Hour, time.Minute, time.Second}
+
+	var d time.Duration
+	for i, unit := range units {
+		if m[i+2] == "" {
+			continue
+		}
+		n, err := strconv.Atoi(m[i+2])
+		if err != nil {
+			return 0, fmt.Errorf("%w: wrong duration %q", ErrInvalidCalendar, s)
+		}
+		d += time.Duration(n) * unit
+	}
+
+	if m[1] == "-" {
+		d = -d
+	}
+	return d, nil
+}
@@ -0,0 +1,98 @@
+package ical
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// maxLineOctets is a limit of RFC 5545 content line length, longer lines are folded
+const maxLineOctets = 75
+
+// Encoder writes RFC 5545 iCalendar content lines
+type Encoder struct {
+	w   *bufio.Writer
+	err error
+}
+
+// NewEncoder returns Encoder which writes to w, Flush should be called after the last component
+func NewEncoder(w io.Writer) *Encoder {
+	return &Encoder{w: bufio.NewWriter(w)}
+}
+
+// Begin starts component, e.g. VCALENDAR or VTODO
+func (e *Encoder) Begin(component string) {
+	e.line("BEGIN:" + component)
+}
+
+// End ends component started by Begin
+func (e *Encoder) End(component string) {
+	e.line("END:" + component)
+}
+
+// Text writes property with TEXT value escaped, name may contain parameters, e.g. "DESCRIPTION;LANGUAGE=en"
+func (e *Encoder) Text(name, value string) {
+	e.line(name + ":" + EscapeText(value))
+}
+
+// Value writes property with value which isn't escaped, e.g. date, duration or recurrence rule
+func (e *Encoder) Value(name, value string) {
+	e.line(name + ":" + value)
+}
+
+// Time writes property with UTC DATE-TIME value
+func (e *Encoder) Time(name string, t time.Time) {
+	e.line(name + ":" + FormatTime(t))
+}
+
+// Flush writes buffered data and returns the first error occurred while encoding
+func (e *Encoder) Flush() error {
+	if e.err != nil {
+		return e.err
+	}
+	return e.w.Flush()
+}
+
+// line writes content line folded to maxLineOctets without splitting UTF-8 characters
+func (e *Encoder) line(s string) {
+	if e.err != nil {
+		return
+	}
+
+	var b strings.Builder
+	width := 0
+	for _, r := range s {
+		size := len(string(r))
+		if width+size > maxLineOctets {
+			b.WriteString("\r\n ")
+			width = 1
+		}
+		b.WriteRune(r)
+		width += size
+	}
+	b.WriteString("\r\n")
+
+	_, e.err = e.w.WriteString(b.String())
+}
+
+// EscapeText escapes TEXT value
+func EscapeText(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `;`, `\;`, `,`, `\,`, "\r\n", `\n`, "\n", `\n`).Replace(s)
+}
+
+// FormatTime formats time as UTC DATE-TIME, e.g. 20230415T162700Z
+func FormatTime(t time.Time) string {
+	return t.UTC().Format("20060102T150405Z")
+}
+
+// FormatDuration formats duration in seconds, e.g. -PT3600S
+func FormatDuration(d time.Duration) string {
+	sign := ""
+	if d < 0 {
+		sign = "-"
+		d = -d
+	}
+	return fmt.Sprintf("%sPT%dS", sign, int64(d/time.Second))
+}
@@ -0,0 +1,127 @@
+package ical
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncoder(t *testing.T) {
+	var buf bytes.Buffer
+
+	enc := NewEncoder(&buf)
+	enc.Begin("VTODO")
+	enc.Text("SUMMARY", "Buy milk, bread; eggs\nand \\ coffee")
+	enc.Time("DUE", time.Date(2023, time.April, 15, 19, 27, 0, 0, time.FixedZone("EEST", 3*60*60)))
+	enc.Text("DESCRIPTION", strings.Repeat("ж", 50))
+	enc.End("VTODO")
+	require.NoError(t, enc.Flush())
+
+	lines := strings.Split(buf.String(), "\r\n")
+	require.Equal(t, "BEGIN:VTODO", lines[0])
+	require.Equal(t, `SUMMARY:Buy milk\, bread\; eggs\nand \\ coffee`, lines[1])
+	require.Equal(t, "DUE:20230415T162700Z", lines[2])
+
+	// long line is folded by 75 octets without splitting two-byte characters
+	require.Equal(t, "DESCRIPTION:"+strings.Repeat("ж", 31), lines[3])
+	require.Equal(t, " "+strings.Repeat("ж", 19), lines[4])
+	for _, line := range lines {
+		require.LessOrEqual(t, len(line), 75)
+	}
+
+	require.Equal(t, "END:VTODO", lines[5])
+	require.Equal(t, "", lines[6])
+}
+
+func TestFormatDuration(t *testing.T) {
+	require.Equal(t, "-PT3600S", FormatDuration(-time.Hour))
+	require.Equal(t, "PT90S", FormatDuration(90*time.Second))
+	require.Equal(t, "PT0S", FormatDuration(0))
+}
+
+func TestDecode(t *testing.T) {
+	data := "BEGIN:VCALENDAR\r\n" +
+		"VERSION:2.0\r\n" +
+		"BEGIN:VTODO\r\n" +
+		"SUMMARY:Buy milk\\, bread\\; eggs\\nand \\\\ coffee\r\n" +
+		"DESCRIPTION:" + strings.Repeat("ж", 31) + "\r\n" +
+		" " + strings.Repeat("ж", 19) + "\r\n" +
+		"DUE;TZID=\"Europe/Kyiv\":20230415T192700\r\n" +
+		"BEGIN:VALARM\r\n" +
+		"TRIGGER;RELATED=END:-PT15M\r\n" +
+		"END:VALARM\r\n" +
+		"END:VTODO\r\n" +
+		"END:VCALENDAR\r\n"
+
+	calendar, err := Decode(strings.NewReader(data))
+	require.NoError(t, err)
+	require.Equal(t, "VCALENDAR", calendar.Name)
+	require.Len(t, calendar.Components, 1)
+
+	todo := calendar.Components[0]
+	require.Equal(t, "VTODO", todo.Name)
+	require.Equal(t, "Buy milk, bread; eggs\nand \\ coffee", todo.Text("SUMMARY"))
+	require.Equal(t, strings.Repeat("ж", 50), todo.Text("DESCRIPTION"))
+
+	due, ok := todo.Get("DUE")
+	require.True(t, ok)
+	require.Equal(t, "Europe/Kyiv", due.Params["TZID"])
+
+	dueTime, err := ParseTime(due)
+	require.NoError(t, err)
+	require.True(t, dueTime.Equal(time.Date(2023, time.April, 15, 16, 27, 0, 0, time.UTC)))
+
+	require.Len(t, todo.Components, 1)
+	trigger, ok := todo.Components[0].Get("TRIGGER")
+	require.True(t, ok)
+	require.Equal(t, "END", trigger.Params["RELATED"])
+	require.Equal(t, "-PT15M", trigger.Value)
+
+	_, err = Decode(strings.NewReader("BEGIN:VCALENDAR\r\nBEGIN:VTODO\r\nEND:VCALENDAR\r\n"))
+	require.ErrorIs(t, err, ErrInvalidCalendar)
+
+	_, err = Decode(strings.NewReader("SUMMARY:no component\r\n"))
+	require.ErrorIs(t, err, ErrInvalidCalendar)
+}
+
+func TestParseTime(t *testing.T) {
+	utc, err := ParseTime(Property{Value: "20230415T162700Z"})
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2023, time.April, 15, 16, 27, 0, 0, time.UTC), utc)
+
+	date, err := ParseTime(Property{Value: "20230415", Params: map[string]string{"VALUE": "DATE"}})
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2023, time.April, 15, 0, 0, 0, 0, time.UTC), date)
+
+	// unknown zone is treated as UTC
+	unknown, err := ParseTime(Property{Value: "20230415T162700", Params: map[string]string{"TZID": "FLE Standard Time"}})
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2023, time.April, 15, 16, 27, 0, 0, time.UTC), unknown)
+
+	_, err = ParseTime(Property{Value: "2023-04-15"})
+	require.Error(t, err)
+}
+
+func TestParseDuration(t *testing.T) {
+	testCases := map[string]time.Duration{
+		"-PT3600S": -time.Hour,
+		"-PT15M":   -15 * time.Minute,
+		"P1DT2H":   26 * time.Hour,
+		"+P1W":     7 * 24 * time.Hour,
+		"-P2D":     -48 * time.Hour,
+	}
+
+	for value, expected := range testCases {
+		d, err := ParseDuration(value)
+		require.NoError(t, err, value)
+		require.Equal(t, expected, d, value)
+	}
+
+	for _, value := range []string{"", "P", "-PT", "PT1X", "1H"} {
+		_, err := ParseDuration(value)
+		require.ErrorIs(t, err, ErrInvalidCalendar, value)
+	}
+}
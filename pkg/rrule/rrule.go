@@ -0,0 +1,501 @@
+package rrule
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Frequency describes RFC 5545 FREQ rule part
+type Frequency int
+
+const (
+	Daily Frequency = iota
+	Weekly
+	Monthly
+	Yearly
+)
+
+var frequencyNames = map[string]Frequency{
+	"DAILY":   Daily,
+	"WEEKLY":  Weekly,
+	"MONTHLY": Monthly,
+	"YEARLY":  Yearly,
+}
+
+var weekdayNames = map[string]time.Weekday{
+	"SU": time.Sunday,
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+}
+
+// maxEmptyPeriods limits how many periods without any occurrence are scanned
+// before the rule is considered exhausted (e.g. BYMONTHDAY=30;BYMONTH=2)
+const maxEmptyPeriods = 1000
+
+// maxBetweenSteps limits how many occurrences Between walks from dtstart, including ones
+// before the range, so a range far from dtstart doesn't iterate for too long
+const maxBetweenSteps = 10000
+
+var ErrInvalidRule = errors.New("invalid recurrence rule")
+
+// WeekdayNum is a BYDAY entry, e.g. "MO", "2TU" or "-1FR"
+type WeekdayNum struct {
+	N   int
+	Day time.Weekday
+}
+
+// Rule is a parsed RFC 5545 recurrence rule.
+// Supported rule parts: FREQ, INTERVAL, COUNT, UNTIL, BYDAY, BYMONTHDAY, BYMONTH, BYSETPOS and WKST.
+// BYDAY ordinals (e.g. "-1FR") are always counted inside a month
+type Rule struct {
+	Freq       Frequency
+	Interval   int
+	Count      int
+	Until      *time.Time
+	ByDay      []WeekdayNum
+	ByMonthDay []int
+	ByMonth    []int
+	BySetPos   []int
+	WeekStart  time.Weekday
+}
+
+// Parse parses rule string like "FREQ=WEEKLY;BYDAY=MO" (optionally prefixed with "RRULE:")
+func Parse(s string) (Rule, error) {
+	r := Rule{Interval: 1, WeekStart: time.Monday}
+
+	s = strings.TrimPrefix(strings.TrimSpace(s), "RRULE:")
+	if s == "" {
+		return Rule{}, ErrInvalidRule
+	}
+
+	hasFreq := false
+
+	for _, part := range strings.Split(s, ";") {
+		kv := strings.SplitN(part, "=", 2)
+		if len(kv) != 2 || kv[1] == "" {
+			return Rule{}, fmt.Errorf("%w: malformed part %q", ErrInvalidRule, part)
+		}
+		key, value := strings.ToUpper(kv[0]), strings.ToUpper(kv[1])
+
+		var err error
+		switch key {
+		case "FREQ":
+			f, ok := frequencyNames[value]
+			if !ok {
+				return Rule{}, fmt.Errorf("%w: unsupported FREQ %q", ErrInvalidRule, value)
+			}
+			r.Freq = f
+			hasFreq = true
+		case "INTERVAL":
+			r.Interval, err = strconv.Atoi(value)
+			if err == nil && r.Interval < 1 {
+				err = errors.New("must be positive")
+			}
+		case "COUNT":
+			r.Count, err = strconv.Atoi(value)
+			if err == nil && r.Count < 1 {
+				err = errors.New("must be positive")
+			}
+		case "UNTIL":
+			var until time.Time
+			until, err = parseUntil(value)
+			r.Until = &until
+		case "BYDAY":
+			r.ByDay, err = parseByDay(value)
+		case "BYMONTHDAY":
+			r.ByMonthDay, err = parseIntList(value, -31, 31)
+		case "BYMONTH":
+			r.ByMonth, err = parseIntList(value, 1, 12)
+		case "BYSETPOS":
+			r.BySetPos, err = parseIntList(value, -366, 366)
+		case "WKST":
+			d, ok := weekdayNames[value]
+			if !ok {
+				err = errors.New("unknown weekday")
+			}
+			r.WeekStart = d
+		default:
+			return Rule{}, fmt.Errorf("%w: unsupported rule part %q", ErrInvalidRule, key)
+		}
+		if err != nil {
+			return Rule{}, fmt.Errorf("%w: %s: %v", ErrInvalidRule, key, err)
+		}
+	}
+
+	if !hasFreq {
+		return Rule{}, fmt.Errorf("%w: FREQ is required", ErrInvalidRule)
+	}
+	if r.Count > 0 && r.Until != nil {
+		return Rule{}, fmt.Errorf("%w: COUNT and UNTIL can't be used together", ErrInvalidRule)
+	}
+
+	return r, nil
+}
+
+// String returns rule in RFC 5545 format
+func (r Rule) String() string {
+	var parts []string
+
+	for name, f := range frequencyNames {
+		if f == r.Freq {
+			parts = append(parts, "FREQ="+name)
+		}
+	}
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+	if r.Count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.Count))
+	}
+	if r.Until != nil {
+		parts = append(parts, "UNTIL="+r.Until.UTC().Format("20060102T150405Z"))
+	}
+	if len(r.ByDay) > 0 {
+		days := make([]string, len(r.ByDay))
+		for i, d := range r.ByDay {
+			days[i] = d.String()
+		}
+		parts = append(parts, "BYDAY="+strings.Join(days, ","))
+	}
+	if len(r.ByMonthDay) > 0 {
+		parts = append(parts, "BYMONTHDAY="+joinInts(r.ByMonthDay))
+	}
+	if len(r.ByMonth) > 0 {
+		parts = append(parts, "BYMONTH="+joinInts(r.ByMonth))
+	}
+	if len(r.BySetPos) > 0 {
+		parts = append(parts, "BYSETPOS="+joinInts(r.BySetPos))
+	}
+	if r.WeekStart != time.Monday {
+		parts = append(parts, "WKST="+weekdayString(r.WeekStart))
+	}
+
+	return strings.Join(parts, ";")
+}
+
+func (w WeekdayNum) String() string {
+	if w.N == 0 {
+		return weekdayString(w.Day)
+	}
+	return strconv.Itoa(w.N) + weekdayString(w.Day)
+}
+
+// Iterator walks through occurrences of the rule starting from dtstart
+type Iterator struct {
+	rule    Rule
+	dtstart time.Time
+	period  int
+	buffer  []time.Time
+	emitted int
+	done    bool
+}
+
+// Iterator returns new Iterator. Like in RFC 5545, dtstart is always the first occurrence
+func (r Rule) Iterator(dtstart time.Time) *Iterator {
+	return &Iterator{rule: r, dtstart: dtstart, buffer: []time.Time{dtstart}}
+}
+
+// Next returns next occurrence. The second value is false when the rule is exhausted
+func (it *Iterator) Next() (time.Time, bool) {
+	empty := 0
+
+	for len(it.buffer) == 0 && !it.done {
+		candidates := it.rule.periodCandidates(it.dtstart, it.period)
+		it.period++
+
+		for _, c := range candidates {
+			if c.After(it.dtstart) {
+				it.buffer = append(it.buffer, c)
+			}
+		}
+
+		if len(it.buffer) == 0 {
+			empty++
+			if empty > maxEmptyPeriods {
+				it.done = true
+			}
+		}
+	}
+
+	if it.done || len(it.buffer) == 0 {
+		return time.Time{}, false
+	}
+
+	next := it.buffer[0]
+	it.buffer = it.buffer[1:]
+
+	if it.rule.Until != nil && next.After(*it.rule.Until) {
+		it.done = true
+		return time.Time{}, false
+	}
+	if it.rule.Count > 0 && it.emitted >= it.rule.Count {
+		it.done = true
+		return time.Time{}, false
+	}
+
+	it.emitted++
+
+	return next, true
+}
+
+// Between returns occurrences in [from, to] range, but not more than limit.
+// Occurrences later than maxBetweenSteps occurrences after dtstart aren't returned
+func (r Rule) Between(dtstart, from, to time.Time, limit int) []time.Time {
+	var res []time.Time
+
+	it := r.Iterator(dtstart)
+	for steps := 0; len(res) < limit && steps < maxBetweenSteps; steps++ {
+		t, ok := it.Next()
+		if !ok || t.After(to) {
+			break
+		}
+		if !t.Before(from) {
+			res = append(res, t)
+		}
+	}
+
+	return res
+}
+
+// periodCandidates returns sorted occurrences of period number n (counted in intervals from dtstart)
+func (r Rule) periodCandidates(dtstart time.Time, n int) []time.Time {
+	step := n * r.Interval
+	var days []time.Time
+
+	switch r.Freq {
+	case Daily:
+		day := dateOf(dtstart).AddDate(0, 0, step)
+		if r.matchMonth(day) && r.matchMonthDay(day) && r.matchWeekday(day) {
+			days = append(days, day)
+		}
+	case Weekly:
+		start := dateOf(dtstart)
+		start = start.AddDate(0, 0, -((int(start.Weekday())-int(r.WeekStart))+7)%7)
+		start = start.AddDate(0, 0, 7*step)
+		for i := 0; i < 7; i++ {
+			day := start.AddDate(0, 0, i)
+			if len(r.ByDay) == 0 && day.Weekday() != dtstart.Weekday() {
+				continue
+			}
+			if r.matchMonth(day) && r.matchWeekday(day) {
+				days = append(days, day)
+			}
+		}
+	case Monthly:
+		first := time.Date(dtstart.Year(), dtstart.Month()+time.Month(step), 1, 0, 0, 0, 0, dtstart.Location())
+		if r.matchMonth(first) {
+			days = r.daysInMonth(dtstart, first)
+		}
+	case Yearly:
+		year := dtstart.Year() + step
+		switch {
+		case len(r.ByMonth) > 0:
+			for _, m := range r.ByMonth {
+				first := time.Date(year, time.Month(m), 1, 0, 0, 0, 0, dtstart.Location())
+				days = append(days, r.daysInMonth(dtstart, first)...)
+			}
+		case len(r.ByDay) > 0 || len(r.ByMonthDay) > 0:
+			for m := 1; m <= 12; m++ {
+				first := time.Date(year, time.Month(m), 1, 0, 0, 0, 0, dtstart.Location())
+				days = append(days, r.daysInMonth(dtstart, first)...)
+			}
+		default:
+			day := time.Date(year, dtstart.Month(), dtstart.Day(), 0, 0, 0, 0, dtstart.Location())
+			if day.Day() == dtstart.Day() {
+				days = append(days, day)
+			}
+		}
+	}
+
+	sort.Slice(days, func(i, j int) bool { return days[i].Before(days[j]) })
+	days = r.applySetPos(days)
+
+	res := make([]time.Time, len(days))
+	for i, d := range days {
+		res[i] = time.Date(d.Year(), d.Month(), d.Day(), dtstart.Hour(), dtstart.Minute(), dtstart.Second(), 0, dtstart.Location())
+	}
+
+	return res
+}
+
+// daysInMonth returns days of month (starting from first) which match BYDAY and BYMONTHDAY
+func (r Rule) daysInMonth(dtstart, first time.Time) []time.Time {
+	var res []time.Time
+
+	if len(r.ByDay) == 0 && len(r.ByMonthDay) == 0 {
+		day := first.AddDate(0, 0, dtstart.Day()-1)
+		if day.Month() == first.Month() {
+			res = append(res, day)
+		}
+		return res
+	}
+
+	for day := first; day.Month() == first.Month(); day = day.AddDate(0, 0, 1) {
+		if r.matchMonthDay(day) && r.matchWeekdayInMonth(day) {
+			res = append(res, day)
+		}
+	}
+
+	return res
+}
+
+func (r Rule) applySetPos(days []time.Time) []time.Time {
+	if len(r.BySetPos) == 0 {
+		return days
+	}
+
+	var res []time.Time
+	for _, pos := range r.BySetPos {
+		i := pos - 1
+		if pos < 0 {
+			i = len(days) + pos
+		}
+		if i >= 0 && i < len(days) {
+			res = append(res, days[i])
+		}
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].Before(res[j]) })
+
+	return res
+}
+
+func (r Rule) matchMonth(day time.Time) bool {
+	if len(r.ByMonth) == 0 {
+		return true
+	}
+	for _, m := range r.ByMonth {
+		if time.Month(m) == day.Month() {
+			return true
+		}
+	}
+	return false
+}
+
+func (r Rule) matchMonthDay(day time.Time) bool {
+	if len(r.ByMonthDay) == 0 {
+		return true
+	}
+	last := daysIn(day)
+	for _, md := range r.ByMonthDay {
+		if md == day.Day() || (md < 0 && last+md+1 == day.Day()) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchWeekday checks BYDAY ignoring ordinals (used for DAILY and WEEKLY rules)
+func (r Rule) matchWeekday(day time.Time) bool {
+	if len(r.ByDay) == 0 {
+		return true
+	}
+	for _, wd := range r.ByDay {
+		if wd.Day == day.Weekday() {
+			return true
+		}
+	}
+	return false
+}
+
+// matchWeekdayInMonth checks BYDAY with ordinals relative to the month, e.g. "-1FR" - last friday
+func (r Rule) matchWeekdayInMonth(day time.Time) bool {
+	if len(r.ByDay) == 0 {
+		return true
+	}
+	for _, wd := range r.ByDay {
+		if wd.Day != day.Weekday() {
+			continue
+		}
+		if wd.N == 0 {
+			return true
+		}
+		if wd.N > 0 && (day.Day()-1)/7+1 == wd.N {
+			return true
+		}
+		if wd.N < 0 && (daysIn(day)-day.Day())/7+1 == -wd.N {
+			return true
+		}
+	}
+	return false
+}
+
+func parseUntil(value string) (time.Time, error) {
+	for _, layout := range []string{"20060102T150405Z", "20060102T150405", "20060102"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, errors.New("wrong date format")
+}
+
+func parseByDay(value string) ([]WeekdayNum, error) {
+	var res []WeekdayNum
+
+	for _, item := range strings.Split(value, ",") {
+		if len(item) < 2 {
+			return nil, fmt.Errorf("wrong weekday %q", item)
+		}
+		day, ok := weekdayNames[item[len(item)-2:]]
+		if !ok {
+			return nil, fmt.Errorf("wrong weekday %q", item)
+		}
+		wd := WeekdayNum{Day: day}
+		if prefix := item[:len(item)-2]; prefix != "" {
+			n, err := strconv.Atoi(prefix)
+			if err != nil || n == 0 || n < -53 || n > 53 {
+				return nil, fmt.Errorf("wrong weekday %q", item)
+			}
+			wd.N = n
+		}
+		res = append(res, wd)
+	}
+
+	return res, nil
+}
+
+func parseIntList(value string, min, max int) ([]int, error) {
+	var res []int
+
+	for _, item := range strings.Split(value, ",") {
+		n, err := strconv.Atoi(item)
+		if err != nil || n == 0 || n < min || n > max {
+			return nil, fmt.Errorf("wrong value %q", item)
+		}
+		res = append(res, n)
+	}
+
+	return res, nil
+}
+
+func joinInts(values []int) string {
+	s := make([]string, len(values))
+	for i, v := range values {
+		s[i] = strconv.Itoa(v)
+	}
+	return strings.Join(s, ",")
+}
+
+func weekdayString(d time.Weekday) string {
+	for name, wd := range weekdayNames {
+		if wd == d {
+			return name
+		}
+	}
+	return ""
+}
+
+func dateOf(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+func daysIn(t time.Time) int {
+	return time.Date(t.Year(), t.Month()+1, 0, 0, 0, 0, 0, t.Location()).Day()
+}
@@ -0,0 +1,148 @@
+package rrule
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    string
+		want    string
+		wantErr bool
+	}{
+		{name: "weekly", rule: "FREQ=WEEKLY;BYDAY=MO", want: "FREQ=WEEKLY;BYDAY=MO"},
+		{name: "with prefix", rule: "RRULE:FREQ=DAILY;INTERVAL=3", want: "FREQ=DAILY;INTERVAL=3"},
+		{name: "last business day", rule: "freq=monthly;byday=MO,TU,WE,TH,FR;bysetpos=-1", want: "FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-1"},
+		{name: "ordinal weekday", rule: "FREQ=MONTHLY;BYDAY=-1FR;COUNT=3", want: "FREQ=MONTHLY;COUNT=3;BYDAY=-1FR"},
+		{name: "until", rule: "FREQ=DAILY;UNTIL=20230501T000000Z", want: "FREQ=DAILY;UNTIL=20230501T000000Z"},
+		{name: "empty", rule: "", wantErr: true},
+		{name: "no freq", rule: "INTERVAL=2", wantErr: true},
+		{name: "unsupported freq", rule: "FREQ=SECONDLY", wantErr: true},
+		{name: "wrong interval", rule: "FREQ=DAILY;INTERVAL=0", wantErr: true},
+		{name: "wrong weekday", rule: "FREQ=WEEKLY;BYDAY=XX", wantErr: true},
+		{name: "count with until", rule: "FREQ=DAILY;COUNT=2;UNTIL=20230501", wantErr: true},
+		{name: "unknown part", rule: "FREQ=DAILY;BYHOUR=9", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.rule)
+			if tt.wantErr {
+				require.ErrorIs(t, err, ErrInvalidRule)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got.String())
+		})
+	}
+}
+
+func TestRule_Iterator(t *testing.T) {
+	// Monday
+	dtstart := time.Date(2023, time.April, 3, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		rule    string
+		dtstart time.Time
+		want    []string
+	}{
+		{
+			name:    "every monday",
+			rule:    "FREQ=WEEKLY;BYDAY=MO",
+			dtstart: dtstart,
+			want:    []string{"2023-04-03", "2023-04-10", "2023-04-17", "2023-04-24"},
+		},
+		{
+			name:    "every 3 days",
+			rule:    "FREQ=DAILY;INTERVAL=3",
+			dtstart: dtstart,
+			want:    []string{"2023-04-03", "2023-04-06", "2023-04-09", "2023-04-12"},
+		},
+		{
+			name:    "last business day of month",
+			rule:    "FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-1",
+			dtstart: time.Date(2023, time.March, 31, 9, 0, 0, 0, time.UTC),
+			want:    []string{"2023-03-31", "2023-04-28", "2023-05-31", "2023-06-30"},
+		},
+		{
+			name:    "last day of month",
+			rule:    "FREQ=MONTHLY;BYMONTHDAY=-1",
+			dtstart: time.Date(2023, time.January, 31, 9, 0, 0, 0, time.UTC),
+			want:    []string{"2023-01-31", "2023-02-28", "2023-03-31", "2023-04-30"},
+		},
+		{
+			name:    "skip short months",
+			rule:    "FREQ=MONTHLY",
+			dtstart: time.Date(2023, time.January, 31, 9, 0, 0, 0, time.UTC),
+			want:    []string{"2023-01-31", "2023-03-31", "2023-05-31", "2023-07-31"},
+		},
+		{
+			name:    "count",
+			rule:    "FREQ=WEEKLY;BYDAY=TU,TH;COUNT=3",
+			dtstart: time.Date(2023, time.April, 4, 9, 0, 0, 0, time.UTC),
+			want:    []string{"2023-04-04", "2023-04-06", "2023-04-11"},
+		},
+		{
+			name:    "until",
+			rule:    "FREQ=DAILY;UNTIL=20230405T235959Z",
+			dtstart: dtstart,
+			want:    []string{"2023-04-03", "2023-04-04", "2023-04-05"},
+		},
+		{
+			name:    "yearly",
+			rule:    "FREQ=YEARLY",
+			dtstart: dtstart,
+			want:    []string{"2023-04-03", "2024-04-03", "2025-04-03", "2026-04-03"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := Parse(tt.rule)
+			require.NoError(t, err)
+
+			var got []string
+			it := rule.Iterator(tt.dtstart)
+			for range tt.want {
+				next, ok := it.Next()
+				require.True(t, ok)
+				require.Equal(t, 9, next.Hour())
+				got = append(got, next.Format("2006-01-02"))
+			}
+			require.Equal(t, tt.want, got)
+
+			if rule.Count > 0 || rule.Until != nil {
+				_, ok := it.Next()
+				require.False(t, ok)
+			}
+		})
+	}
+}
+
+func TestRule_Between(t *testing.T) {
+	rule, err := Parse("FREQ=WEEKLY;BYDAY=MO")
+	require.NoError(t, err)
+
+	dtstart := time.Date(2023, time.April, 3, 9, 0, 0, 0, time.UTC)
+	from := time.Date(2023, time.April, 15, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2023, time.May, 1, 23, 0, 0, 0, time.UTC)
+
+	got := rule.Between(dtstart, from, to, 10)
+	require.Equal(t, []time.Time{
+		time.Date(2023, time.April, 17, 9, 0, 0, 0, time.UTC),
+		time.Date(2023, time.April, 24, 9, 0, 0, 0, time.UTC),
+		time.Date(2023, time.May, 1, 9, 0, 0, 0, time.UTC),
+	}, got)
+
+	require.Len(t, rule.Between(dtstart, from, to, 2), 2)
+
+	daily, err := Parse("FREQ=DAILY")
+	require.NoError(t, err)
+
+	// range far from dtstart doesn't walk all occurrences before it
+	farFrom := time.Date(9999, time.January, 1, 0, 0, 0, 0, time.UTC)
+	require.Empty(t, daily.Between(dtstart, farFrom, farFrom.AddDate(0, 1, 0), 10))
+}
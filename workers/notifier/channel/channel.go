@@ -0,0 +1,226 @@
+package channel
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"sort"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/red-rocket-software/reminder-go/config"
+	"github.com/red-rocket-software/reminder-go/internal/reminder/domain"
+	"github.com/red-rocket-software/reminder-go/workers/notifier/mail"
+)
+
+var ErrChannelDisabled = errors.New("notification channel isn't enabled")
+
+// IdempotencyHeader has idempotency key of the message in HTTP requests of channels, repeated deliveries
+// of the same message have the same key, so receivers can dedupe them
+const IdempotencyHeader = "Idempotency-Key"
+
+// deliveredKeys is the number of idempotency keys of the last delivered messages kept by registry
+const deliveredKeys = 1024
+
+// Message is a notification about remind. Email gets HTML, other channels get Subject and Text.
+// IdempotencyKey is the same for each delivery of the message
+type Message struct {
+	IdempotencyKey string          `json:"idempotency_key,omitempty"`
+	Subject        string          `json:"subject"`
+	Text           string          `json:"text"`
+	HTML           string          `json:"-"`
+	RemindID       int             `json:"remind_id"`
+	Title          string          `json:"title"`
+	DeadlineAt     time.Time       `json:"deadline_at"`
+	Priority       domain.Priority `json:"priority"`
+}
+
+// Recipient is a user notified by channel, Address is the user address in this channel from channel preferences
+type Recipient struct {
+	UserID  string
+	Name    string
+	Email   string
+	Address string
+}
+
+// Channel delivers messages to recipients
+type Channel interface {
+	// Name is one of domain channels
+	Name() string
+	Send(ctx context.Context, to Recipient, msg Message) error
+}
+
+// Registry keeps enabled channels by name and idempotency keys of the last messages it has delivered
+type Registry struct {
+	channels map[string]Channel
+
+	mu        sync.Mutex
+	delivered map[string]bool
+	// keys are delivered keys in order of delivery, the oldest one is replaced by the next key
+	keys []string
+	next int
+}
+
+// NewRegistry returns registry with channels enabled in config
+func NewRegistry(cfg config.Config) *Registry {
+	r := &Registry{channels: map[string]Channel{}, delivered: map[string]bool{}}
+	client := &http.Client{Timeout: cfg.Channels.Timeout}
+	// webhook and slack addresses are set by users, so they can be sent only to public addresses
+	userClient := client
+	if !cfg.Channels.AllowPrivateNetworks {
+		userClient = NewPublicClient(cfg.Channels.Timeout)
+	}
+
+	if cfg.ChannelEnabled(domain.ChannelEmail) {
+		r.Register(NewEmail(mail.NewGmailSender(cfg.Email.EmailSenderName,
+			cfg.Email.EmailSenderAddress,
+			cfg.Email.EmailSenderPassword,
+			cfg.Email.SMTPAuthAddress,
+			cfg.Email.SMTPServerAddress,
+		)))
+	}
+	if cfg.ChannelEnabled(domain.ChannelWebhook) {
+		r.Register(NewWebhook(userClient, cfg.Channels.Webhook.Secret))
+	}
+	if cfg.ChannelEnabled(domain.ChannelSlack) {
+		r.Register(NewSlack(userClient))
+	}
+	if cfg.ChannelEnabled(domain.ChannelTelegram) {
+		r.Register(NewTelegram(client, cfg.Channels.Telegram.APIURL, cfg.Channels.Telegram.BotToken))
+	}
+	if cfg.ChannelEnabled(domain.ChannelNtfy) {
+		r.Register(NewNtfy(client, cfg.Channels.Ntfy.ServerURL, cfg.Channels.Ntfy.Token))
+	}
+	if cfg.ChannelEnabled(domain.ChannelGotify) {
+		r.Register(NewGotify(client, cfg.Channels.Gotify.ServerURL))
+	}
+
+	return r
+}
+
+// Register adds channel replacing the one with the same name
+func (r *Registry) Register(ch Channel) {
+	r.channels[ch.Name()] = ch
+}
+
+// Get returns enabled channel by name
+func (r *Registry) Get(name string) (Channel, error) {
+	ch, ok := r.channels[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrChannelDisabled, name)
+	}
+	return ch, nil
+}
+
+// Send delivers message by enabled channel. Message whose idempotency key was delivered by the registry recently
+// isn't delivered again, e.g. when the worker failed to record the delivery and retries it
+func (r *Registry) Send(ctx context.Context, name string, to Recipient, msg Message) error {
+	ch, err := r.Get(name)
+	if err != nil {
+		return err
+	}
+
+	if msg.IdempotencyKey == "" {
+		return ch.Send(ctx, to, msg)
+	}
+
+	r.mu.Lock()
+	delivered := r.delivered[msg.IdempotencyKey]
+	r.mu.Unlock()
+	if delivered {
+		return nil
+	}
+
+	if err := ch.Send(ctx, to, msg); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(r.keys) < deliveredKeys {
+		r.keys = append(r.keys, msg.IdempotencyKey)
+	} else {
+		delete(r.delivered, r.keys[r.next])
+		r.keys[r.next] = msg.IdempotencyKey
+		r.next = (r.next + 1) % deliveredKeys
+	}
+	r.delivered[msg.IdempotencyKey] = true
+
+	return nil
+}
+
+// Names returns names of enabled channels in alphabetical order
+func (r *Registry) Names() []string {
+	names := make([]string, 0, len(r.channels))
+	for name := range r.channels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// post sends request with idempotency key of the message and checks that response status is 2xx. Errors don't
+// contain request URL, because webhook URLs and Telegram API URL have secrets in them
+// NewPublicClient returns HTTP client which connects only to public IP addresses, it's checked after host
+// resolution for each connection, including redirects, so local host names and DNS rebinding are refused too
+func NewPublicClient(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{
+		Timeout: timeout,
+		Control: func(_, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if !domain.PublicIP(net.ParseIP(host)) {
+				return fmt.Errorf("%w: %s", domain.ErrPrivateAddress, host)
+			}
+			return nil
+		},
+	}
+
+	return &http.Client{
+		Timeout: timeout,
+		Transport: &http.Transport{
+			// proxy from environment would connect instead of the dialer
+			Proxy:               nil,
+			DialContext:         dialer.DialContext,
+			TLSHandshakeTimeout: timeout,
+			MaxIdleConns:        100,
+			IdleConnTimeout:     90 * time.Second,
+		},
+	}
+}
+
+func post(ctx context.Context, client *http.Client, endpoint, idempotencyKey string, body []byte, header http.Header) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return errors.New("invalid request url")
+	}
+	for key, values := range header {
+		req.Header[key] = values
+	}
+	if idempotencyKey != "" {
+		req.Header.Set(IdempotencyHeader, idempotencyKey)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			return urlErr.Err
+		}
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("unexpected response status %d", resp.StatusCode)
+	}
+
+	return nil
+}
@@ -0,0 +1,282 @@
+package channel
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/red-rocket-software/reminder-go/config"
+	"github.com/red-rocket-software/reminder-go/internal/reminder/domain"
+	"github.com/stretchr/testify/require"
+)
+
+// request is a request received by stand-in server
+type request struct {
+	path   string
+	header http.Header
+	body   []byte
+}
+
+// standIn starts local HTTP server which records requests and responds with status
+func standIn(t *testing.T, status int) (*httptest.Server, *[]request) {
+	var requests []request
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		requests = append(requests, request{path: r.URL.Path, header: r.Header, body: body})
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv, &requests
+}
+
+var testMessage = Message{
+	Subject:    "[HIGH] Reminder notification",
+	Text:       "Title\nDescription",
+	HTML:       "<p>Description</p>",
+	RemindID:   1,
+	Title:      "Title",
+	DeadlineAt: time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC),
+	Priority:   domain.PriorityHigh,
+}
+
+func TestWebhook_Send(t *testing.T) {
+	srv, requests := standIn(t, http.StatusNoContent)
+
+	wh := NewWebhook(srv.Client(), "secret")
+	require.NoError(t, wh.Send(context.Background(), Recipient{UserID: "user", Address: srv.URL + "/hook"}, testMessage))
+
+	require.Len(t, *requests, 1)
+	req := (*requests)[0]
+	require.Equal(t, "/hook", req.path)
+	require.Equal(t, "application/json", req.header.Get("Content-Type"))
+
+	var payload map[string]any
+	require.NoError(t, json.Unmarshal(req.body, &payload))
+	require.Equal(t, "user", payload["user_id"])
+	require.Equal(t, "Title", payload["title"])
+	require.Equal(t, float64(1), payload["remind_id"])
+	require.Equal(t, "2023-01-02T03:04:05Z", payload["deadline_at"])
+	require.NotContains(t, payload, "HTML")
+
+	mac := hmac.New(sha256.New, []byte("secret"))
+	mac.Write(req.body)
+	require.Equal(t, "sha256="+hex.EncodeToString(mac.Sum(nil)), req.header.Get(SignatureHeader))
+
+	t.Run("with idempotency key", func(t *testing.T) {
+		srv, requests := standIn(t, http.StatusOK)
+
+		msg := testMessage
+		msg.IdempotencyKey = "notification-10/user/webhook/0"
+		require.NoError(t, NewWebhook(srv.Client(), "").Send(context.Background(), Recipient{Address: srv.URL}, msg))
+
+		req := (*requests)[0]
+		require.Equal(t, msg.IdempotencyKey, req.header.Get(IdempotencyHeader))
+
+		var payload map[string]any
+		require.NoError(t, json.Unmarshal(req.body, &payload))
+		require.Equal(t, msg.IdempotencyKey, payload["idempotency_key"])
+	})
+
+	t.Run("without secret", func(t *testing.T) {
+		srv, requests := standIn(t, http.StatusOK)
+
+		require.NoError(t, NewWebhook(srv.Client(), "").Send(context.Background(), Recipient{Address: srv.URL}, testMessage))
+		require.Empty(t, (*requests)[0].header.Get(SignatureHeader))
+	})
+
+	t.Run("private address is refused", func(t *testing.T) {
+		srv, requests := standIn(t, http.StatusOK)
+
+		err := NewWebhook(NewPublicClient(time.Second), "").Send(context.Background(), Recipient{Address: srv.URL}, testMessage)
+		require.ErrorIs(t, err, domain.ErrPrivateAddress)
+		require.Empty(t, *requests)
+	})
+
+	t.Run("error has only response status", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte("internal details"))
+		}))
+		t.Cleanup(srv.Close)
+
+		err := NewWebhook(srv.Client(), "").Send(context.Background(), Recipient{Address: srv.URL}, testMessage)
+		require.EqualError(t, err, "unexpected response status 400")
+	})
+}
+
+func TestSlack_Send(t *testing.T) {
+	srv, requests := standIn(t, http.StatusOK)
+
+	require.NoError(t, NewSlack(srv.Client()).Send(context.Background(), Recipient{Address: srv.URL + "/services/T/B/X"}, testMessage))
+
+	require.Len(t, *requests, 1)
+	require.Equal(t, "/services/T/B/X", (*requests)[0].path)
+	require.JSONEq(t, `{"text": "*[HIGH] Reminder notification*\nTitle\nDescription"}`, string((*requests)[0].body))
+}
+
+func TestTelegram_Send(t *testing.T) {
+	srv, requests := standIn(t, http.StatusOK)
+
+	require.NoError(t, NewTelegram(srv.Client(), srv.URL+"/", "123:token").Send(context.Background(), Recipient{Address: "42"}, testMessage))
+
+	require.Len(t, *requests, 1)
+	require.Equal(t, "/bot123:token/sendMessage", (*requests)[0].path)
+	require.JSONEq(t, `{"chat_id": "42", "text": "[HIGH] Reminder notification\nTitle\nDescription"}`, string((*requests)[0].body))
+
+	t.Run("error doesn't leak token", func(t *testing.T) {
+		srv, _ := standIn(t, http.StatusUnauthorized)
+
+		err := NewTelegram(srv.Client(), srv.URL, "123:token").Send(context.Background(), Recipient{Address: "42"}, testMessage)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "401")
+		require.NotContains(t, err.Error(), "token")
+
+		srv.Close()
+		err = NewTelegram(srv.Client(), srv.URL, "123:token").Send(context.Background(), Recipient{Address: "42"}, testMessage)
+		require.Error(t, err)
+		require.NotContains(t, err.Error(), "token")
+	})
+}
+
+func TestNtfy_Send(t *testing.T) {
+	srv, requests := standIn(t, http.StatusOK)
+
+	require.NoError(t, NewNtfy(srv.Client(), srv.URL, "tk_secret").Send(context.Background(), Recipient{Address: "my-reminds"}, testMessage))
+
+	require.Len(t, *requests, 1)
+	req := (*requests)[0]
+	require.Equal(t, "/my-reminds", req.path)
+	require.Equal(t, "Title\nDescription", string(req.body))
+	require.Equal(t, "[HIGH] Reminder notification", req.header.Get("Title"))
+	require.Equal(t, "4", req.header.Get("Priority"))
+	require.Equal(t, "Bearer tk_secret", req.header.Get("Authorization"))
+}
+
+func TestGotify_Send(t *testing.T) {
+	srv, requests := standIn(t, http.StatusOK)
+
+	msg := testMessage
+	msg.Priority = domain.PriorityNone
+	require.NoError(t, NewGotify(srv.Client(), srv.URL).Send(context.Background(), Recipient{Address: "app-token"}, msg))
+
+	require.Len(t, *requests, 1)
+	req := (*requests)[0]
+	require.Equal(t, "/message", req.path)
+	require.Equal(t, "app-token", req.header.Get("X-Gotify-Key"))
+	require.JSONEq(t, `{"title": "[HIGH] Reminder notification", "message": "Title\nDescription", "priority": 3}`, string(req.body))
+
+	t.Run("unexpected status", func(t *testing.T) {
+		srv, _ := standIn(t, http.StatusInternalServerError)
+
+		require.Error(t, NewGotify(srv.Client(), srv.URL).Send(context.Background(), Recipient{Address: "app-token"}, msg))
+	})
+}
+
+// fakeSender records sent emails
+type fakeSender struct {
+	subject, content string
+	to               []string
+	err              error
+}
+
+func (f *fakeSender) SendEmail(subject string, content string, to []string, _ []string, _ []string, _ []string) error {
+	f.subject, f.content, f.to = subject, content, to
+	return f.err
+}
+
+func TestEmail_Send(t *testing.T) {
+	sender := &fakeSender{}
+	email := NewEmail(sender)
+
+	require.NoError(t, email.Send(context.Background(), Recipient{Email: "account@example.com"}, testMessage))
+	require.Equal(t, []string{"account@example.com"}, sender.to)
+	require.Equal(t, testMessage.Subject, sender.subject)
+	require.Equal(t, testMessage.HTML, sender.content)
+
+	require.NoError(t, email.Send(context.Background(), Recipient{Email: "account@example.com", Address: "other@example.com"}, testMessage))
+	require.Equal(t, []string{"other@example.com"}, sender.to)
+
+	require.Error(t, email.Send(context.Background(), Recipient{}, testMessage))
+
+	sender.err = errors.New("something went wrong")
+	require.Error(t, email.Send(context.Background(), Recipient{Email: "account@example.com"}, testMessage))
+}
+
+func TestNewRegistry(t *testing.T) {
+	cfg := config.Config{}
+	require.Equal(t, []string{domain.ChannelEmail}, NewRegistry(cfg).Names())
+
+	cfg.Channels.Email.Disabled = true
+	cfg.Channels.Slack.Enabled = true
+	cfg.Channels.Ntfy.Enabled = true
+
+	registry := NewRegistry(cfg)
+	require.Equal(t, []string{domain.ChannelNtfy, domain.ChannelSlack}, registry.Names())
+
+	ch, err := registry.Get(domain.ChannelSlack)
+	require.NoError(t, err)
+	require.Equal(t, domain.ChannelSlack, ch.Name())
+
+	_, err = registry.Get(domain.ChannelEmail)
+	require.ErrorIs(t, err, ErrChannelDisabled)
+}
+
+// countingChannel counts sent messages
+type countingChannel struct {
+	sent int
+}
+
+func (c *countingChannel) Name() string {
+	return domain.ChannelEmail
+}
+
+func (c *countingChannel) Send(_ context.Context, _ Recipient, _ Message) error {
+	c.sent++
+	return nil
+}
+
+func TestRegistry_Send(t *testing.T) {
+	cfg := config.Config{}
+	cfg.Channels.Email.Disabled = true
+
+	registry := NewRegistry(cfg)
+	require.ErrorIs(t, registry.Send(context.Background(), domain.ChannelEmail, Recipient{}, testMessage), ErrChannelDisabled)
+
+	ch := &countingChannel{}
+	registry.Register(ch)
+
+	msg := testMessage
+	msg.IdempotencyKey = "key-0"
+	require.NoError(t, registry.Send(context.Background(), domain.ChannelEmail, Recipient{}, msg))
+	require.NoError(t, registry.Send(context.Background(), domain.ChannelEmail, Recipient{}, msg))
+	require.Equal(t, 1, ch.sent)
+
+	// messages without key are always delivered
+	require.NoError(t, registry.Send(context.Background(), domain.ChannelEmail, Recipient{}, testMessage))
+	require.NoError(t, registry.Send(context.Background(), domain.ChannelEmail, Recipient{}, testMessage))
+	require.Equal(t, 3, ch.sent)
+
+	// the oldest keys are forgotten
+	for i := 1; i <= deliveredKeys; i++ {
+		msg.IdempotencyKey = fmt.Sprintf("key-%d", i)
+		require.NoError(t, registry.Send(context.Background(), domain.ChannelEmail, Recipient{}, msg))
+	}
+	require.Equal(t, 3+deliveredKeys, ch.sent)
+
+	msg.IdempotencyKey = "key-0"
+	require.NoError(t, registry.Send(context.Background(), domain.ChannelEmail, Recipient{}, msg))
+	msg.IdempotencyKey = fmt.Sprintf("key-%d", deliveredKeys)
+	require.NoError(t, registry.Send(context.Background(), domain.ChannelEmail, Recipient{}, msg))
+	require.Equal(t, 4+deliveredKeys, ch.sent)
+}
@@ -0,0 +1,35 @@
+package channel
+
+import (
+	"context"
+	"errors"
+
+	"github.com/red-rocket-software/reminder-go/internal/reminder/domain"
+	"github.com/red-rocket-software/reminder-go/workers/notifier/mail"
+)
+
+// Email sends HTML message by SMTP
+type Email struct {
+	sender mail.EmailSender
+}
+
+func NewEmail(sender mail.EmailSender) *Email {
+	return &Email{sender: sender}
+}
+
+func (e *Email) Name() string {
+	return domain.ChannelEmail
+}
+
+// Send sends message to address from preferences or to email of the account
+func (e *Email) Send(_ context.Context, to Recipient, msg Message) error {
+	address := to.Address
+	if address == "" {
+		address = to.Email
+	}
+	if address == "" {
+		return errors.New("user has no email")
+	}
+
+	return e.sender.SendEmail(msg.Subject, msg.HTML, []string{address}, nil, nil, nil)
+}
@@ -0,0 +1,83 @@
+package channel
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/red-rocket-software/reminder-go/internal/reminder/domain"
+)
+
+// pushPriority maps remind priority to priority of ntfy (1-5) and Gotify, default priority is 3
+func pushPriority(priority domain.Priority) int {
+	switch priority {
+	case domain.PriorityLow:
+		return 2
+	case domain.PriorityHigh:
+		return 4
+	case domain.PriorityCritical:
+		return 5
+	}
+	return 3
+}
+
+// Ntfy publishes message to ntfy topic of the user
+type Ntfy struct {
+	client    *http.Client
+	serverURL string
+	token     string
+}
+
+func NewNtfy(client *http.Client, serverURL, token string) *Ntfy {
+	return &Ntfy{client: client, serverURL: strings.TrimSuffix(serverURL, "/"), token: token}
+}
+
+func (n *Ntfy) Name() string {
+	return domain.ChannelNtfy
+}
+
+func (n *Ntfy) Send(ctx context.Context, to Recipient, msg Message) error {
+	header := http.Header{
+		"Title":    {msg.Subject},
+		"Priority": {strconv.Itoa(pushPriority(msg.Priority))},
+	}
+	if n.token != "" {
+		header.Set("Authorization", "Bearer "+n.token)
+	}
+
+	return post(ctx, n.client, n.serverURL+"/"+url.PathEscape(to.Address), msg.IdempotencyKey, []byte(msg.Text), header)
+}
+
+// Gotify sends message to Gotify application, address of the user is the application token
+type Gotify struct {
+	client    *http.Client
+	serverURL string
+}
+
+func NewGotify(client *http.Client, serverURL string) *Gotify {
+	return &Gotify{client: client, serverURL: strings.TrimSuffix(serverURL, "/")}
+}
+
+func (g *Gotify) Name() string {
+	return domain.ChannelGotify
+}
+
+func (g *Gotify) Send(ctx context.Context, to Recipient, msg Message) error {
+	body, err := json.Marshal(map[string]any{
+		"title":    msg.Subject,
+		"message":  msg.Text,
+		"priority": pushPriority(msg.Priority),
+	})
+	if err != nil {
+		return err
+	}
+
+	header := http.Header{
+		"Content-Type": {"application/json"},
+		"X-Gotify-Key": {to.Address},
+	}
+	return post(ctx, g.client, g.serverURL+"/message", msg.IdempotencyKey, body, header)
+}
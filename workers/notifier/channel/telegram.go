@@ -0,0 +1,38 @@
+package channel
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/red-rocket-software/reminder-go/internal/reminder/domain"
+)
+
+// Telegram sends message by bot to chat of the user
+type Telegram struct {
+	client   *http.Client
+	apiURL   string
+	botToken string
+}
+
+func NewTelegram(client *http.Client, apiURL, botToken string) *Telegram {
+	return &Telegram{client: client, apiURL: strings.TrimSuffix(apiURL, "/"), botToken: botToken}
+}
+
+func (t *Telegram) Name() string {
+	return domain.ChannelTelegram
+}
+
+func (t *Telegram) Send(ctx context.Context, to Recipient, msg Message) error {
+	body, err := json.Marshal(map[string]string{
+		"chat_id": to.Address,
+		"text":    msg.Subject + "\n" + msg.Text,
+	})
+	if err != nil {
+		return err
+	}
+
+	endpoint := t.apiURL + "/bot" + t.botToken + "/sendMessage"
+	return post(ctx, t.client, endpoint, msg.IdempotencyKey, body, http.Header{"Content-Type": {"application/json"}})
+}
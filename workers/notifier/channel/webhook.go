@@ -0,0 +1,72 @@
+package channel
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+
+	"github.com/red-rocket-software/reminder-go/internal/reminder/domain"
+)
+
+// SignatureHeader has HMAC-SHA256 of webhook body, e.g. "sha256=5d2c..."
+const SignatureHeader = "X-Reminder-Signature"
+
+// Webhook posts message as JSON to URL of the user
+type Webhook struct {
+	client *http.Client
+	secret string
+}
+
+func NewWebhook(client *http.Client, secret string) *Webhook {
+	return &Webhook{client: client, secret: secret}
+}
+
+func (wh *Webhook) Name() string {
+	return domain.ChannelWebhook
+}
+
+type webhookPayload struct {
+	Message
+	UserID string `json:"user_id"`
+}
+
+func (wh *Webhook) Send(ctx context.Context, to Recipient, msg Message) error {
+	body, err := json.Marshal(webhookPayload{Message: msg, UserID: to.UserID})
+	if err != nil {
+		return err
+	}
+
+	header := http.Header{"Content-Type": {"application/json"}}
+	if wh.secret != "" {
+		mac := hmac.New(sha256.New, []byte(wh.secret))
+		mac.Write(body)
+		header.Set(SignatureHeader, "sha256="+hex.EncodeToString(mac.Sum(nil)))
+	}
+
+	return post(ctx, wh.client, to.Address, msg.IdempotencyKey, body, header)
+}
+
+// Slack posts message to Slack incoming webhook of the user
+type Slack struct {
+	client *http.Client
+}
+
+func NewSlack(client *http.Client) *Slack {
+	return &Slack{client: client}
+}
+
+func (s *Slack) Name() string {
+	return domain.ChannelSlack
+}
+
+func (s *Slack) Send(ctx context.Context, to Recipient, msg Message) error {
+	body, err := json.Marshal(map[string]string{"text": "*" + msg.Subject + "*\n" + msg.Text})
+	if err != nil {
+		return err
+	}
+
+	return post(ctx, s.client, to.Address, msg.IdempotencyKey, body, http.Header{"Content-Type": {"application/json"}})
+}
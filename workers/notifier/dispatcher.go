@@ -0,0 +1,92 @@
+package notifier
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/red-rocket-software/reminder-go/internal/reminder/domain"
+	"github.com/red-rocket-software/reminder-go/workers/notifier/channel"
+)
+
+// outboxPayload is a message with its recipient kept in the outbox. HTML is kept separately because it isn't
+// marshaled with the message
+type outboxPayload struct {
+	Recipient channel.Recipient `json:"recipient"`
+	Message   channel.Message   `json:"message"`
+	HTML      string            `json:"html,omitempty"`
+}
+
+// newOutboxMessage returns delivery of message to the recipient by channel with idempotency key
+func newOutboxMessage(key string, todoID int, channelName string, to channel.Recipient, msg channel.Message) (domain.OutboxMessage, error) {
+	msg.IdempotencyKey = key
+
+	payload, err := json.Marshal(outboxPayload{Recipient: to, Message: msg, HTML: msg.HTML})
+	if err != nil {
+		return domain.OutboxMessage{}, fmt.Errorf("erorr to marshal outbox message, err: %v", err)
+	}
+
+	return domain.OutboxMessage{
+		IdempotencyKey: key,
+		TodoID:         todoID,
+		UserID:         to.UserID,
+		Channel:        channelName,
+		Payload:        payload,
+	}, nil
+}
+
+// ProcessOutbox delivers messages added to the outbox by ProcessDueNotifications and ProcessSendEscalations
+// and returns time of the next notification or delivery. Each message is delivered at least once: it is leased
+// like notifications and marked sent after delivery, so a message whose delivery wasn't recorded is delivered
+// again with the same idempotency key. Failed delivery is retried with backoff and doesn't stop other ones
+func (w *Worker) ProcessOutbox() (*time.Time, error) {
+	for {
+		outbox, err := w.todoStorage.ClaimOutbox(w.ctx, w.id, time.Now(), w.cfg.Scheduler.Lease, w.cfg.Scheduler.BatchSize)
+		if err != nil {
+			return nil, fmt.Errorf("erorr to claim outbox, err: %v", err)
+		}
+
+		for _, m := range outbox {
+			if err := w.dispatch(m); err != nil {
+				w.logger.Errorf("error to dispatch outbox message: %v", err)
+			}
+		}
+
+		if len(outbox) < w.cfg.Scheduler.BatchSize {
+			break
+		}
+	}
+
+	return w.todoStorage.NextNotificationAt(w.ctx, time.Now())
+}
+
+// dispatch delivers leased outbox message and records the attempt, failed message is retried with backoff
+// until the last attempt after which it is dead
+func (w *Worker) dispatch(m domain.OutboxMessage) error {
+	var payload outboxPayload
+
+	err := json.Unmarshal(m.Payload, &payload)
+	if err == nil {
+		payload.Message.HTML = payload.HTML
+		err = w.channels.Send(w.ctx, m.Channel, payload.Recipient, payload.Message)
+	}
+	if err != nil {
+		now := time.Now()
+		retryAt := nextAttempt(w.cfg, m.Attempts+1, now)
+
+		if markErr := w.todoStorage.MarkOutboxFailed(w.ctx, m.ID, w.id, now, err.Error(), retryAt); markErr != nil {
+			return fmt.Errorf("failed to mark outbox message %d failed: %w", m.ID, markErr)
+		}
+		if retryAt == nil {
+			return fmt.Errorf("outbox message %d is dead after %d attempts: %w", m.ID, m.Attempts+1, err)
+		}
+		return fmt.Errorf("failed to notify user %s by %s, it is retried at %s: %w", m.UserID, m.Channel, retryAt.Format(time.RFC3339), err)
+	}
+
+	err = w.todoStorage.MarkOutboxSent(w.ctx, m.ID, w.id, time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to mark outbox message %d sent: %w", m.ID, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,150 @@
+package notifier
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/red-rocket-software/reminder-go/config"
+	"github.com/red-rocket-software/reminder-go/internal/reminder/domain"
+	mockdb "github.com/red-rocket-software/reminder-go/internal/reminder/domain/mocks"
+	"github.com/red-rocket-software/reminder-go/workers/notifier/channel"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorker_ProcessOutbox(t *testing.T) {
+	next := time.Now().Add(time.Hour)
+	remind := domain.NotificationRemind{ID: 1, Title: "Title", UserID: "owner"}
+	msg := remindMessage(remind, "subject", "deadline to", "<p>owner</p>")
+
+	setup := func(t *testing.T) (*Worker, *mockdb.MockTodoRepository, *fakeChannel, *fakeChannel) {
+		c := gomock.NewController(t)
+
+		cfg := config.Config{}
+		cfg.Channels.Email.Disabled = true
+		cfg.Scheduler.WorkerID = "worker-1"
+		cfg.Scheduler.Lease = 5 * time.Minute
+		cfg.Scheduler.BatchSize = 2
+		cfg.Retry.MaxAttempts = 3
+		cfg.Retry.Delay = time.Minute
+		cfg.Retry.MaxDelay = time.Hour
+
+		store := mockdb.NewMockTodoRepository(c)
+		worker := NewWorker(context.Background(), &testLogger, store, nil, nil, cfg)
+		email := &fakeChannel{name: domain.ChannelEmail}
+		webhook := &fakeChannel{name: domain.ChannelWebhook}
+		worker.channels.Register(email)
+		worker.channels.Register(webhook)
+
+		return worker, store, email, webhook
+	}
+
+	outboxMessage := func(t *testing.T, id int64, key, channelName string, to channel.Recipient) domain.OutboxMessage {
+		m, err := newOutboxMessage(key, remind.ID, channelName, to, msg)
+		require.NoError(t, err)
+		m.ID = id
+		return m
+	}
+
+	toEmail := outboxMessage(t, 1, "notification-10/owner/email/0", domain.ChannelEmail, channel.Recipient{UserID: "owner", Email: "owner@example.com"})
+	toWebhook := outboxMessage(t, 2, "notification-10/member/webhook/0", domain.ChannelWebhook, channel.Recipient{UserID: "member", Address: "https://example.com/hook"})
+
+	t.Run("messages are delivered and marked", func(t *testing.T) {
+		worker, store, email, webhook := setup(t)
+
+		gomock.InOrder(
+			store.EXPECT().ClaimOutbox(gomock.Any(), "worker-1", gomock.Any(), 5*time.Minute, 2).Return([]domain.OutboxMessage{toEmail, toWebhook}, nil),
+			store.EXPECT().MarkOutboxSent(gomock.Any(), int64(1), "worker-1", gomock.Any()).Return(nil),
+			store.EXPECT().MarkOutboxSent(gomock.Any(), int64(2), "worker-1", gomock.Any()).Return(nil),
+			// the whole batch was claimed, so the next one is claimed too
+			store.EXPECT().ClaimOutbox(gomock.Any(), "worker-1", gomock.Any(), 5*time.Minute, 2).Return([]domain.OutboxMessage{}, nil),
+			store.EXPECT().NextNotificationAt(gomock.Any(), gomock.Any()).Return(&next, nil),
+		)
+
+		got, err := worker.ProcessOutbox()
+		require.NoError(t, err)
+		require.Equal(t, &next, got)
+
+		require.Equal(t, []channel.Recipient{{UserID: "owner", Email: "owner@example.com"}}, email.sent)
+		require.Equal(t, "notification-10/owner/email/0", email.messages[0].IdempotencyKey)
+		require.Equal(t, "<p>owner</p>", email.messages[0].HTML)
+		require.Equal(t, "Title", email.messages[0].Title)
+
+		require.Equal(t, []channel.Recipient{{UserID: "member", Address: "https://example.com/hook"}}, webhook.sent)
+		require.Equal(t, "notification-10/member/webhook/0", webhook.messages[0].IdempotencyKey)
+	})
+
+	t.Run("failed delivery is retried and doesn't block others", func(t *testing.T) {
+		worker, store, email, webhook := setup(t)
+		email.fail = map[string]bool{"owner": true}
+
+		failed := toEmail
+		failed.Attempts = 1
+
+		gomock.InOrder(
+			store.EXPECT().ClaimOutbox(gomock.Any(), "worker-1", gomock.Any(), 5*time.Minute, 2).Return([]domain.OutboxMessage{failed, toWebhook}, nil),
+			store.EXPECT().MarkOutboxFailed(gomock.Any(), int64(1), "worker-1", gomock.Any(), "something went wrong", gomock.Any()).
+				DoAndReturn(func(_ context.Context, _ int64, _ string, failedAt time.Time, _ string, retryAt *time.Time) error {
+					require.NotNil(t, retryAt)
+					require.Equal(t, failedAt.Add(2*time.Minute), *retryAt)
+					return nil
+				}),
+			store.EXPECT().MarkOutboxSent(gomock.Any(), int64(2), "worker-1", gomock.Any()).Return(nil),
+			store.EXPECT().ClaimOutbox(gomock.Any(), "worker-1", gomock.Any(), 5*time.Minute, 2).Return([]domain.OutboxMessage{}, nil),
+			store.EXPECT().NextNotificationAt(gomock.Any(), gomock.Any()).Return(&next, nil),
+		)
+
+		_, err := worker.ProcessOutbox()
+		require.NoError(t, err)
+		require.Empty(t, email.sent)
+		require.Len(t, webhook.sent, 1)
+	})
+
+	t.Run("message is dead after the last attempt", func(t *testing.T) {
+		worker, store, email, _ := setup(t)
+		email.fail = map[string]bool{"owner": true}
+
+		failed := toEmail
+		failed.Attempts = 2
+
+		store.EXPECT().ClaimOutbox(gomock.Any(), "worker-1", gomock.Any(), 5*time.Minute, 2).Return([]domain.OutboxMessage{failed}, nil)
+		store.EXPECT().MarkOutboxFailed(gomock.Any(), int64(1), "worker-1", gomock.Any(), gomock.Any(), nil).Return(nil)
+		store.EXPECT().NextNotificationAt(gomock.Any(), gomock.Any()).Return(nil, nil)
+
+		got, err := worker.ProcessOutbox()
+		require.NoError(t, err)
+		require.Nil(t, got)
+	})
+
+	t.Run("delivered message isn't delivered again", func(t *testing.T) {
+		worker, store, email, _ := setup(t)
+
+		// the delivery wasn't recorded, so the message is claimed again
+		gomock.InOrder(
+			store.EXPECT().ClaimOutbox(gomock.Any(), "worker-1", gomock.Any(), 5*time.Minute, 2).Return([]domain.OutboxMessage{toEmail}, nil),
+			store.EXPECT().MarkOutboxSent(gomock.Any(), int64(1), "worker-1", gomock.Any()).Return(errors.New("something went wrong")),
+			store.EXPECT().NextNotificationAt(gomock.Any(), gomock.Any()).Return(&next, nil),
+			store.EXPECT().ClaimOutbox(gomock.Any(), "worker-1", gomock.Any(), 5*time.Minute, 2).Return([]domain.OutboxMessage{toEmail}, nil),
+			store.EXPECT().MarkOutboxSent(gomock.Any(), int64(1), "worker-1", gomock.Any()).Return(nil),
+			store.EXPECT().NextNotificationAt(gomock.Any(), gomock.Any()).Return(&next, nil),
+		)
+
+		_, err := worker.ProcessOutbox()
+		require.NoError(t, err)
+		_, err = worker.ProcessOutbox()
+		require.NoError(t, err)
+
+		require.Len(t, email.sent, 1)
+	})
+
+	t.Run("error claim", func(t *testing.T) {
+		worker, store, _, _ := setup(t)
+
+		store.EXPECT().ClaimOutbox(gomock.Any(), "worker-1", gomock.Any(), 5*time.Minute, 2).Return(nil, errors.New("something went wrong"))
+
+		_, err := worker.ProcessOutbox()
+		require.Error(t, err)
+	})
+}
@@ -3,107 +3,366 @@ package notifier
 import (
 	"context"
 	"fmt"
+	"os"
+	"strings"
+	"time"
 
+	"firebase.google.com/go/auth"
 	"github.com/red-rocket-software/reminder-go/config"
 	"github.com/red-rocket-software/reminder-go/internal/reminder/domain"
 	"github.com/red-rocket-software/reminder-go/pkg/firestore"
-	"github.com/red-rocket-software/reminder-go/workers/notifier/mail"
+	"github.com/red-rocket-software/reminder-go/pkg/logging"
+	"github.com/red-rocket-software/reminder-go/workers/notifier/channel"
 )
 
 type Worker struct {
-	todoStorage domain.TodoRepository
-	fireClient  firestore.Client
-	ctx         context.Context
-	cfg         config.Config
+	// id identifies the worker in leases of notifications
+	id             string
+	todoStorage    domain.TodoRepository
+	configsStorage domain.ConfigRepository
+	fireClient     firestore.Client
+	channels       *channel.Registry
+	ctx            context.Context
+	logger         *logging.Logger
+	cfg            config.Config
 }
 
-func NewWorker(ctx context.Context, todoStorage domain.TodoRepository, fireClient firestore.Client, cfg config.Config) *Worker {
+func NewWorker(ctx context.Context, logger *logging.Logger, todoStorage domain.TodoRepository, configsStorage domain.ConfigRepository, fireClient firestore.Client, cfg config.Config) *Worker {
 	return &Worker{
-		todoStorage: todoStorage,
-		fireClient:  fireClient,
-		ctx:         ctx,
-		cfg:         cfg,
+		id:             workerID(cfg),
+		todoStorage:    todoStorage,
+		configsStorage: configsStorage,
+		fireClient:     fireClient,
+		channels:       channel.NewRegistry(cfg),
+		ctx:            ctx,
+		logger:         logger,
+		cfg:            cfg,
 	}
 }
 
-func (w *Worker) ProcessSendNotification() error {
-	remindsToNotify, err := w.todoStorage.GetRemindsForNotification(w.ctx)
+// workerID returns id of the worker from config or its hostname and pid
+func workerID(cfg config.Config) string {
+	if cfg.Scheduler.WorkerID != "" {
+		return cfg.Scheduler.WorkerID
+	}
+
+	hostname, err := os.Hostname()
 	if err != nil {
-		return fmt.Errorf("erorr to get reminds to notification, err: %v", err)
+		hostname = "worker"
 	}
+	return fmt.Sprintf("%s-%d", hostname, os.Getpid())
+}
 
-	mailer := mail.NewGmailSender(w.cfg.Email.EmailSenderName,
-		w.cfg.Email.EmailSenderAddress,
-		w.cfg.Email.EmailSenderPassword,
-		w.cfg.Email.SMTPAuthAddress,
-		w.cfg.Email.SMTPServerAddress)
+// ProcessDueNotifications adds notifications of reminds to the queue and moves all of them which are due and not
+// sent yet to the outbox, ProcessOutbox delivers them. Notifications missed while the worker was down are sent on its
+// first run. Notifications are leased in batches, so several workers can send them at the same time without
+// duplicates. Failed notification is retried later and doesn't stop sending other ones
+func (w *Worker) ProcessDueNotifications() error {
+	now := time.Now()
 
-	for _, remind := range remindsToNotify {
-		user, err := w.fireClient.GetUser(remind.UserID)
+	err := w.todoStorage.ScheduleNotifications(w.ctx, now)
+	if err != nil {
+		return fmt.Errorf("erorr to schedule notifications, err: %v", err)
+	}
+
+	for {
+		notifications, err := w.todoStorage.ClaimDueNotifications(w.ctx, w.id, time.Now(), w.cfg.Scheduler.Lease, w.cfg.Scheduler.BatchSize)
 		if err != nil {
-			return fmt.Errorf("erorr to get user, err: %v", err)
+			return fmt.Errorf("erorr to claim due notifications, err: %v", err)
+		}
+
+		for _, n := range notifications {
+			if err := w.sendNotification(n); err != nil {
+				w.logger.Errorf("error to send notification: %v", err)
+			}
+		}
+
+		if len(notifications) < w.cfg.Scheduler.BatchSize {
+			break
+		}
+	}
+
+	return nil
+}
+
+// sendNotification adds deliveries of leased notification to the outbox and marks it sent in one transaction.
+// When its recipients can't be resolved, the attempt is recorded and the notification is retried with backoff
+// until the last attempt after which it is dead
+func (w *Worker) sendNotification(n domain.Notification) error {
+	outbox, err := w.outboxMessages(n.Remind, fmt.Sprintf("notification-%d", n.ID), notificationMessage(n))
+	if err != nil {
+		now := time.Now()
+		retryAt := nextAttempt(w.cfg, n.Attempts+1, now)
+
+		if markErr := w.todoStorage.MarkNotificationFailed(w.ctx, n.ID, w.id, now, err.Error(), retryAt); markErr != nil {
+			return fmt.Errorf("failed to mark notification %d failed: %w", n.ID, markErr)
+		}
+		if retryAt == nil {
+			return fmt.Errorf("notification %d is dead after %d attempts: %w", n.ID, n.Attempts+1, err)
+		}
+		return fmt.Errorf("failed to send notification %d, it is retried at %s: %w", n.ID, retryAt.Format(time.RFC3339), err)
+	}
+
+	err = w.todoStorage.MarkNotificationSent(w.ctx, n.ID, w.id, time.Now(), outbox)
+	if err != nil {
+		return fmt.Errorf("failed to mark notification %d sent: %w", n.ID, err)
+	}
+
+	return nil
+}
+
+// nextAttempt returns time of the next attempt after given number of failed ones, the delay is doubled after
+// each attempt up to the max delay. Nil is returned after the last attempt
+func nextAttempt(cfg config.Config, attempts int, failedAt time.Time) *time.Time {
+	if attempts >= cfg.Retry.MaxAttempts {
+		return nil
+	}
+
+	delay := cfg.Retry.Delay
+	for i := 1; i < attempts && delay < cfg.Retry.MaxDelay; i++ {
+		delay *= 2
+	}
+	if delay > cfg.Retry.MaxDelay {
+		delay = cfg.Retry.MaxDelay
+	}
+
+	next := failedAt.Add(delay)
+	return &next
+}
+
+// notificationMessage returns message of queued notification for each recipient
+func notificationMessage(n domain.Notification) func(user *auth.UserRecord) channel.Message {
+	remind := n.Remind
+
+	return func(user *auth.UserRecord) channel.Message {
+		if n.Kind == domain.NotificationDeadline {
+			content := fmt.Sprintf(`Hello %s,<br/>
+	I wont to remember that you have a deadline: <br/> <p style="color: red">
+	%s <p/> deadline to %s<br/>
+	`, user.DisplayName, remind.Description, remind.DeadlineAt)
+
+			return remindMessage(remind, notificationSubject(remind.Priority), "deadline to", content)
 		}
 
-		subject := "Reminder notification"
 		content := fmt.Sprintf(`Hello %s,<br/>
 	I wont to remember that you have something to do...<br/><p style="color: red">
 	%s <p/> deadline to %s<br/>
-	`, user.UserInfo.DisplayName, remind.Description, remind.DeadlineAt)
-		to := []string{user.Email}
+	`, user.DisplayName, remind.Description, remind.DeadlineAt)
+
+		return remindMessage(remind, notificationSubject(remind.Priority), "deadline to", content)
+	}
+}
+
+// Wakeup returns how long the worker waits for the next run: until the next notification is due, but no longer
+// than maxWait, so reminds created or changed since the last run are scheduled in time
+func Wakeup(now time.Time, next *time.Time, maxWait time.Duration) time.Duration {
+	wait := maxWait
+	if next != nil && next.Sub(now) < wait {
+		wait = next.Sub(now)
+	}
+	if wait < 0 {
+		wait = 0
+	}
+
+	return wait
+}
+
+// ProcessSendEscalations notifies again about overdue reminds on backoff schedule until they are completed
+// or dismissed, notifications are added to the outbox with the escalation state
+func (w *Worker) ProcessSendEscalations() error {
+	backoff := w.cfg.Escalation.Backoff
+	if len(backoff) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+
+	remindsToNotify, err := w.todoStorage.ClaimRemindsForEscalation(w.ctx, now, backoff[0], w.cfg.Scheduler.Lease)
+	if err != nil {
+		return fmt.Errorf("erorr to get overdue reminds to notification, err: %v", err)
+	}
+
+	for _, remind := range remindsToNotify {
+		outbox, err := w.outboxMessages(remind, fmt.Sprintf("escalation-%d-%d", remind.ID, now.Unix()), func(user *auth.UserRecord) channel.Message {
+			content := fmt.Sprintf(`Hello %s,<br/>
+	I wont to remember that deadline has passed and it is still not done: <br/> <p style="color: red">
+	%s <p/> deadline was %s<br/>
+	`, user.DisplayName, remind.Description, remind.DeadlineAt)
 
-		err = mailer.SendEmail(subject, content, to, nil, nil, nil)
+			return remindMessage(remind, overdueSubject(remind.Priority), "deadline was", content)
+		})
 		if err != nil {
-			return fmt.Errorf("failed to send notifier email: %w", err)
+			// the remind stays leased and is notified again when the lease expires
+			w.logger.Errorf("failed to send overdue notification of remind %d: %v", remind.ID, err)
+			continue
 		}
 
-		err = w.todoStorage.UpdateNotification(w.ctx, remind.ID, domain.NotificationDAO{Notificated: true})
+		escalations := remind.Escalations + 1
+		err = w.todoStorage.UpdateEscalation(w.ctx, remind.ID, escalations, now, nextEscalation(backoff, escalations, now), outbox)
 		if err != nil {
-			return fmt.Errorf("failed to update notificated status: %w", err)
+			return fmt.Errorf("failed to update escalation: %w", err)
 		}
-		fmt.Println("Email sent successful")
 	}
 
 	return nil
 }
 
-func (w *Worker) ProcessSendDeadlineNotification() error {
-	remindsToNotify, timeToDelete, err := w.todoStorage.GetRemindsForDeadlineNotification(w.ctx)
+// nextEscalation returns time of the next overdue notification after given number of them was sent at sentAt,
+// the last delay of backoff is repeated
+func nextEscalation(backoff []time.Duration, escalations int, sentAt time.Time) time.Time {
+	if escalations >= len(backoff) {
+		escalations = len(backoff) - 1
+	}
+	return sentAt.Add(backoff[escalations])
+}
+
+// ProcessPurgeTrash permanently deletes reminds which are in trash longer than retention period
+func (w *Worker) ProcessPurgeTrash() error {
+	before := time.Now().AddDate(0, 0, -w.cfg.Trash.RetentionDays)
+
+	purged, err := w.todoStorage.PurgeTrash(w.ctx, before)
 	if err != nil {
-		return fmt.Errorf("erorr to get reminds to notification, err: %v", err)
+		return fmt.Errorf("failed to purge trash: %w", err)
 	}
 
-	mailer := mail.NewGmailSender(w.cfg.Email.EmailSenderName,
-		w.cfg.Email.EmailSenderAddress,
-		w.cfg.Email.EmailSenderPassword,
-		w.cfg.Email.SMTPAuthAddress,
-		w.cfg.Email.SMTPServerAddress,
-	)
+	if purged > 0 {
+		w.logger.Infof("%d reminds purged from trash", purged)
+	}
 
-	for _, remind := range remindsToNotify {
-		user, err := w.fireClient.GetUser(remind.UserID)
-		if err != nil {
-			return fmt.Errorf("erorr to get user, err: %v", err)
+	return nil
+}
+
+// ProcessErasures deletes all data of users whose erasure grace period is over
+func (w *Worker) ProcessErasures() error {
+	userIDs, err := w.configsStorage.GetDueErasures(w.ctx, time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to get due erasures: %w", err)
+	}
+
+	for _, userID := range userIDs {
+		if err := w.configsStorage.EraseUser(w.ctx, userID); err != nil {
+			return fmt.Errorf("failed to erase user %s: %w", userID, err)
 		}
+	}
 
-		subject := "Reminder notification"
-		content := fmt.Sprintf(`Hello %s,<br/>
-	I wont to remember that you have a deadline: <br/> <p style="color: red">
-	%s <p/> deadline to %s<br/>
-	`, user.DisplayName, remind.Description, remind.DeadlineAt)
-		to := []string{user.Email}
+	if len(userIDs) > 0 {
+		w.logger.Infof("%d users erased", len(userIDs))
+	}
+
+	return nil
+}
 
-		err = mailer.SendEmail(subject, content, to, nil, nil, nil)
+// outboxMessages returns deliveries of message to remind owner and members by each of their enabled channels.
+// Idempotency key of each delivery is made of the key of notification, the user and the channel. Channels which
+// aren't enabled are logged and skipped, error is returned when there is nothing to deliver
+func (w *Worker) outboxMessages(remind domain.NotificationRemind, key string, message func(user *auth.UserRecord) channel.Message) ([]domain.OutboxMessage, error) {
+	users, err := w.recipients(remind)
+	if err != nil {
+		return nil, err
+	}
+
+	var outbox []domain.OutboxMessage
+	var lastErr error
+
+	for _, user := range users {
+		prefs, err := w.channelPreferences(user.UID)
 		if err != nil {
-			return fmt.Errorf("failed to send verify email: %w", err)
+			return nil, err
+		}
+
+		msg := message(user)
+		for i, pref := range prefs {
+			if _, err := w.channels.Get(pref.Channel); err != nil {
+				lastErr = fmt.Errorf("failed to notify user %s by %s: %w", user.UID, pref.Channel, err)
+				w.logger.Errorf("%v", lastErr)
+				continue
+			}
+
+			to := channel.Recipient{UserID: user.UID, Name: user.DisplayName, Email: user.Email, Address: pref.Address}
+			m, err := newOutboxMessage(fmt.Sprintf("%s/%s/%s/%d", key, user.UID, pref.Channel, i), remind.ID, pref.Channel, to, msg)
+			if err != nil {
+				return nil, err
+			}
+			outbox = append(outbox, m)
 		}
+	}
+
+	if len(outbox) == 0 {
+		return nil, lastErr
+	}
+
+	return outbox, nil
+}
+
+// channelPreferences returns notification channels of the user, email when the user has not chosen any
+func (w *Worker) channelPreferences(userID string) ([]domain.ChannelPreference, error) {
+	configs, err := w.configsStorage.GetUserConfigs(w.ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("erorr to get user configs, err: %v", err)
+	}
+
+	if len(configs.Channels) == 0 {
+		return []domain.ChannelPreference{{Channel: domain.ChannelEmail}}, nil
+	}
+
+	return configs.Channels, nil
+}
+
+// remindMessage returns message about remind with the email content, text of other channels tells
+// the deadline after given label
+func remindMessage(remind domain.NotificationRemind, subject, deadlineLabel, html string) channel.Message {
+	return channel.Message{
+		Subject:    subject,
+		Text:       fmt.Sprintf("%s\n%s\n%s %s", remind.Title, remind.Description, deadlineLabel, remind.DeadlineAt.Format(time.RFC1123)),
+		HTML:       html,
+		RemindID:   remind.ID,
+		Title:      remind.Title,
+		DeadlineAt: remind.DeadlineAt,
+		Priority:   remind.Priority,
+	}
+}
 
-		err = w.todoStorage.UpdateNotifyPeriod(w.ctx, remind.ID, timeToDelete)
+// recipients returns remind owner and members who opted in to its notifications.
+// Members whose accounts were deleted are skipped
+func (w *Worker) recipients(remind domain.NotificationRemind) ([]*auth.UserRecord, error) {
+	owner, err := w.fireClient.GetUser(remind.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("erorr to get user, err: %v", err)
+	}
+
+	users := []*auth.UserRecord{owner}
+	for _, memberID := range remind.MemberIDs {
+		user, err := w.fireClient.GetUser(memberID)
+		if auth.IsUserNotFound(err) {
+			continue
+		}
 		if err != nil {
-			return fmt.Errorf("failed to update deadline notification period")
+			return nil, fmt.Errorf("erorr to get member, err: %v", err)
 		}
+		users = append(users, user)
+	}
+
+	return users, nil
+}
 
-		fmt.Println("Deadline notification Email sent successful")
+// overdueSubject is subject of overdue notification marked with remind priority like notificationSubject
+func overdueSubject(priority domain.Priority) string {
+	const subject = "Overdue remind"
+
+	if priority == domain.PriorityNone {
+		return subject
 	}
 
-	return nil
+	return fmt.Sprintf("[%s] %s", strings.ToUpper(priority.String()), subject)
+}
+
+// notificationSubject marks email subject with remind priority, e.g. "[CRITICAL] Reminder notification"
+func notificationSubject(priority domain.Priority) string {
+	const subject = "Reminder notification"
+
+	if priority == domain.PriorityNone {
+		return subject
+	}
+
+	return fmt.Sprintf("[%s] %s", strings.ToUpper(priority.String()), subject)
 }
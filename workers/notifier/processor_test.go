@@ -0,0 +1,425 @@
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"firebase.google.com/go/auth"
+	"github.com/golang/mock/gomock"
+	"github.com/red-rocket-software/reminder-go/config"
+	"github.com/red-rocket-software/reminder-go/internal/reminder/domain"
+	mockdb "github.com/red-rocket-software/reminder-go/internal/reminder/domain/mocks"
+	mock_firestore "github.com/red-rocket-software/reminder-go/pkg/firestore/mocks"
+	"github.com/red-rocket-software/reminder-go/pkg/logging"
+	"github.com/red-rocket-software/reminder-go/workers/notifier/channel"
+	"github.com/stretchr/testify/require"
+)
+
+var testLogger = logging.GetLogger()
+
+// fakeChannel records sent messages and fails for recipients from fail
+type fakeChannel struct {
+	name     string
+	sent     []channel.Recipient
+	messages []channel.Message
+	fail     map[string]bool
+}
+
+func (f *fakeChannel) Name() string {
+	return f.name
+}
+
+func (f *fakeChannel) Send(_ context.Context, to channel.Recipient, msg channel.Message) error {
+	if f.fail[to.UserID] {
+		return errors.New("something went wrong")
+	}
+	f.sent = append(f.sent, to)
+	f.messages = append(f.messages, msg)
+	return nil
+}
+
+func TestWorker_recipients(t *testing.T) {
+	owner := &auth.UserRecord{UserInfo: &auth.UserInfo{UID: "owner", Email: "owner@example.com"}}
+	member := &auth.UserRecord{UserInfo: &auth.UserInfo{UID: "member", Email: "member@example.com"}}
+
+	testCases := []struct {
+		name         string
+		remind       domain.NotificationRemind
+		mockBehavior func(client *mock_firestore.MockClient)
+		expected     []*auth.UserRecord
+		wantErr      bool
+	}{
+		{
+			name:   "owner only",
+			remind: domain.NotificationRemind{UserID: "owner"},
+			mockBehavior: func(client *mock_firestore.MockClient) {
+				client.EXPECT().GetUser("owner").Return(owner, nil)
+			},
+			expected: []*auth.UserRecord{owner},
+		},
+		{
+			name:   "owner and members",
+			remind: domain.NotificationRemind{UserID: "owner", MemberIDs: []string{"member"}},
+			mockBehavior: func(client *mock_firestore.MockClient) {
+				client.EXPECT().GetUser("owner").Return(owner, nil)
+				client.EXPECT().GetUser("member").Return(member, nil)
+			},
+			expected: []*auth.UserRecord{owner, member},
+		},
+		{
+			name:   "error get owner",
+			remind: domain.NotificationRemind{UserID: "owner", MemberIDs: []string{"member"}},
+			mockBehavior: func(client *mock_firestore.MockClient) {
+				client.EXPECT().GetUser("owner").Return(nil, errors.New("something went wrong"))
+			},
+			wantErr: true,
+		},
+		{
+			name:   "error get member",
+			remind: domain.NotificationRemind{UserID: "owner", MemberIDs: []string{"member"}},
+			mockBehavior: func(client *mock_firestore.MockClient) {
+				client.EXPECT().GetUser("owner").Return(owner, nil)
+				client.EXPECT().GetUser("member").Return(nil, errors.New("something went wrong"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			client := mock_firestore.NewMockClient(c)
+			test.mockBehavior(client)
+
+			worker := NewWorker(context.Background(), &testLogger, nil, nil, client, config.Config{})
+
+			users, err := worker.recipients(test.remind)
+			if test.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.expected, users)
+		})
+	}
+}
+
+func TestWorker_ProcessPurgeTrash(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	cfg := config.Config{}
+	cfg.Trash.RetentionDays = 30
+
+	store := mockdb.NewMockTodoRepository(c)
+	store.EXPECT().PurgeTrash(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, before time.Time) (int64, error) {
+		require.WithinDuration(t, time.Now().AddDate(0, 0, -30), before, time.Minute)
+		return 2, nil
+	})
+	store.EXPECT().PurgeTrash(gomock.Any(), gomock.Any()).Return(int64(0), errors.New("something went wrong"))
+
+	worker := NewWorker(context.Background(), &testLogger, store, nil, nil, cfg)
+
+	require.NoError(t, worker.ProcessPurgeTrash())
+	require.Error(t, worker.ProcessPurgeTrash())
+}
+
+func TestWorker_ProcessErasures(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	store := mockdb.NewMockConfigRepository(c)
+	gomock.InOrder(
+		store.EXPECT().GetDueErasures(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, before time.Time) ([]string, error) {
+			require.WithinDuration(t, time.Now(), before, time.Minute)
+			return []string{"first", "second"}, nil
+		}),
+		store.EXPECT().EraseUser(gomock.Any(), "first").Return(nil),
+		store.EXPECT().EraseUser(gomock.Any(), "second").Return(nil),
+		store.EXPECT().GetDueErasures(gomock.Any(), gomock.Any()).Return([]string{"first"}, nil),
+		store.EXPECT().EraseUser(gomock.Any(), "first").Return(errors.New("something went wrong")),
+	)
+
+	worker := NewWorker(context.Background(), &testLogger, nil, store, nil, config.Config{})
+
+	require.NoError(t, worker.ProcessErasures())
+	require.Error(t, worker.ProcessErasures())
+}
+
+func TestNextEscalation(t *testing.T) {
+	backoff := []time.Duration{time.Hour, 24 * time.Hour, 72 * time.Hour}
+	sentAt := time.Date(2023, time.April, 10, 12, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		escalations int
+		expected    time.Time
+	}{
+		{escalations: 1, expected: sentAt.Add(24 * time.Hour)},
+		{escalations: 2, expected: sentAt.Add(72 * time.Hour)},
+		{escalations: 5, expected: sentAt.Add(72 * time.Hour)},
+	}
+
+	for _, test := range testCases {
+		require.Equal(t, test.expected, nextEscalation(backoff, test.escalations, sentAt))
+	}
+}
+
+func TestWorker_ProcessSendEscalations(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	cfg := config.Config{}
+	cfg.Escalation.Backoff = []time.Duration{time.Hour, 24 * time.Hour, 72 * time.Hour}
+	cfg.Scheduler.Lease = 5 * time.Minute
+
+	store := mockdb.NewMockTodoRepository(c)
+	gomock.InOrder(
+		store.EXPECT().ClaimRemindsForEscalation(gomock.Any(), gomock.Any(), time.Hour, 5*time.Minute).DoAndReturn(func(_ context.Context, now time.Time, _, _ time.Duration) ([]domain.NotificationRemind, error) {
+			require.WithinDuration(t, time.Now(), now, time.Minute)
+			return []domain.NotificationRemind{}, nil
+		}),
+		store.EXPECT().ClaimRemindsForEscalation(gomock.Any(), gomock.Any(), time.Hour, 5*time.Minute).Return(nil, errors.New("something went wrong")),
+	)
+
+	worker := NewWorker(context.Background(), &testLogger, store, nil, nil, cfg)
+
+	require.NoError(t, worker.ProcessSendEscalations())
+	require.Error(t, worker.ProcessSendEscalations())
+
+	// escalation is disabled with empty backoff
+	require.NoError(t, NewWorker(context.Background(), &testLogger, store, nil, nil, config.Config{}).ProcessSendEscalations())
+}
+
+func TestNextAttempt(t *testing.T) {
+	cfg := config.Config{}
+	cfg.Retry.MaxAttempts = 5
+	cfg.Retry.Delay = time.Minute
+	cfg.Retry.MaxDelay = 5 * time.Minute
+	failedAt := time.Date(2023, time.April, 10, 12, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		attempts int
+		expected *time.Time
+	}{
+		{attempts: 1, expected: func() *time.Time { t := failedAt.Add(time.Minute); return &t }()},
+		{attempts: 2, expected: func() *time.Time { t := failedAt.Add(2 * time.Minute); return &t }()},
+		{attempts: 3, expected: func() *time.Time { t := failedAt.Add(4 * time.Minute); return &t }()},
+		{attempts: 4, expected: func() *time.Time { t := failedAt.Add(5 * time.Minute); return &t }()},
+		{attempts: 5, expected: nil},
+	}
+
+	for _, test := range testCases {
+		require.Equal(t, test.expected, nextAttempt(cfg, test.attempts, failedAt), "attempts %d", test.attempts)
+	}
+}
+
+func TestOverdueSubject(t *testing.T) {
+	require.Equal(t, "Overdue remind", overdueSubject(domain.PriorityNone))
+	require.Equal(t, "[HIGH] Overdue remind", overdueSubject(domain.PriorityHigh))
+}
+
+func TestWorker_outboxMessages(t *testing.T) {
+	owner := &auth.UserRecord{UserInfo: &auth.UserInfo{UID: "owner", Email: "owner@example.com"}}
+	member := &auth.UserRecord{UserInfo: &auth.UserInfo{UID: "member", Email: "member@example.com"}}
+	remind := domain.NotificationRemind{ID: 1, UserID: "owner", MemberIDs: []string{"member"}}
+
+	setup := func(t *testing.T, enabled ...string) *Worker {
+		c := gomock.NewController(t)
+
+		client := mock_firestore.NewMockClient(c)
+		client.EXPECT().GetUser("owner").Return(owner, nil)
+		client.EXPECT().GetUser("member").Return(member, nil)
+
+		configs := mockdb.NewMockConfigRepository(c)
+		configs.EXPECT().GetUserConfigs(gomock.Any(), "owner").Return(domain.UserConfigs{
+			ID: "owner",
+			Channels: []domain.ChannelPreference{
+				{Channel: domain.ChannelWebhook, Address: "https://example.com/hook"},
+				{Channel: domain.ChannelSlack, Address: "https://hooks.slack.com/services/T/B/X"},
+			},
+		}, nil)
+		// member hasn't chosen channels and gets email
+		configs.EXPECT().GetUserConfigs(gomock.Any(), "member").Return(domain.UserConfigs{ID: "member"}, nil)
+
+		cfg := config.Config{}
+		cfg.Channels.Email.Disabled = true
+
+		worker := NewWorker(context.Background(), &testLogger, nil, configs, client, cfg)
+		for _, name := range enabled {
+			worker.channels.Register(&fakeChannel{name: name})
+		}
+
+		return worker
+	}
+
+	message := func(user *auth.UserRecord) channel.Message {
+		return remindMessage(remind, "subject", "deadline to", "<p>"+user.UID+"</p>")
+	}
+
+	t.Run("by preferences of each user", func(t *testing.T) {
+		worker := setup(t, domain.ChannelEmail, domain.ChannelWebhook)
+
+		// slack isn't enabled, it is skipped without stopping other deliveries
+		outbox, err := worker.outboxMessages(remind, "notification-10", message)
+		require.NoError(t, err)
+		require.Len(t, outbox, 2)
+
+		require.Equal(t, "notification-10/owner/webhook/0", outbox[0].IdempotencyKey)
+		require.Equal(t, 1, outbox[0].TodoID)
+		require.Equal(t, "owner", outbox[0].UserID)
+		require.Equal(t, domain.ChannelWebhook, outbox[0].Channel)
+
+		var payload outboxPayload
+		require.NoError(t, json.Unmarshal(outbox[0].Payload, &payload))
+		require.Equal(t, channel.Recipient{UserID: "owner", Email: "owner@example.com", Address: "https://example.com/hook"}, payload.Recipient)
+		require.Equal(t, "notification-10/owner/webhook/0", payload.Message.IdempotencyKey)
+		require.Equal(t, "<p>owner</p>", payload.HTML)
+
+		require.Equal(t, "notification-10/member/email/0", outbox[1].IdempotencyKey)
+		require.Equal(t, "member", outbox[1].UserID)
+		require.Equal(t, domain.ChannelEmail, outbox[1].Channel)
+	})
+
+	t.Run("no enabled channels", func(t *testing.T) {
+		worker := setup(t)
+
+		_, err := worker.outboxMessages(remind, "notification-10", message)
+		require.ErrorIs(t, err, channel.ErrChannelDisabled)
+	})
+}
+
+func TestWorker_ProcessDueNotifications(t *testing.T) {
+	owner := &auth.UserRecord{UserInfo: &auth.UserInfo{UID: "owner", Email: "owner@example.com"}}
+
+	setup := func(t *testing.T) (*Worker, *mockdb.MockTodoRepository, *fakeChannel) {
+		c := gomock.NewController(t)
+
+		client := mock_firestore.NewMockClient(c)
+		client.EXPECT().GetUser("owner").Return(owner, nil).AnyTimes()
+		client.EXPECT().GetUser("unknown").Return(nil, errors.New("something went wrong")).AnyTimes()
+
+		configs := mockdb.NewMockConfigRepository(c)
+		configs.EXPECT().GetUserConfigs(gomock.Any(), "owner").Return(domain.UserConfigs{ID: "owner"}, nil).AnyTimes()
+
+		cfg := config.Config{}
+		cfg.Channels.Email.Disabled = true
+		cfg.Scheduler.WorkerID = "worker-1"
+		cfg.Scheduler.Lease = 5 * time.Minute
+		cfg.Scheduler.BatchSize = 2
+		cfg.Retry.MaxAttempts = 3
+		cfg.Retry.Delay = time.Minute
+		cfg.Retry.MaxDelay = time.Hour
+
+		store := mockdb.NewMockTodoRepository(c)
+		worker := NewWorker(context.Background(), &testLogger, store, configs, client, cfg)
+		email := &fakeChannel{name: domain.ChannelEmail}
+		worker.channels.Register(email)
+
+		return worker, store, email
+	}
+
+	// outboxOf checks that notification is moved to the outbox with delivery to the owner by email
+	outboxOf := func(id int64) func(context.Context, int64, string, time.Time, []domain.OutboxMessage) error {
+		return func(_ context.Context, _ int64, _ string, _ time.Time, outbox []domain.OutboxMessage) error {
+			require.Len(t, outbox, 1)
+			require.Equal(t, fmt.Sprintf("notification-%d/owner/email/0", id), outbox[0].IdempotencyKey)
+			require.Equal(t, domain.ChannelEmail, outbox[0].Channel)
+			return nil
+		}
+	}
+
+	t.Run("due notifications are moved to the outbox", func(t *testing.T) {
+		worker, store, email := setup(t)
+
+		remind := domain.NotificationRemind{ID: 1, UserID: "owner"}
+		gomock.InOrder(
+			store.EXPECT().ScheduleNotifications(gomock.Any(), gomock.Any()).Return(nil),
+			store.EXPECT().ClaimDueNotifications(gomock.Any(), "worker-1", gomock.Any(), 5*time.Minute, 2).Return([]domain.Notification{
+				{ID: 10, Kind: domain.NotificationDeadline, Remind: remind},
+				{ID: 11, Kind: domain.NotificationPeriod, Remind: remind},
+			}, nil),
+			store.EXPECT().MarkNotificationSent(gomock.Any(), int64(10), "worker-1", gomock.Any(), gomock.Any()).DoAndReturn(outboxOf(10)),
+			store.EXPECT().MarkNotificationSent(gomock.Any(), int64(11), "worker-1", gomock.Any(), gomock.Any()).DoAndReturn(outboxOf(11)),
+			// the whole batch was claimed, so the next one is claimed too
+			store.EXPECT().ClaimDueNotifications(gomock.Any(), "worker-1", gomock.Any(), 5*time.Minute, 2).Return([]domain.Notification{
+				{ID: 12, Kind: domain.NotificationDeadline, Remind: remind},
+			}, nil),
+			store.EXPECT().MarkNotificationSent(gomock.Any(), int64(12), "worker-1", gomock.Any(), gomock.Any()).DoAndReturn(outboxOf(12)),
+		)
+
+		require.NoError(t, worker.ProcessDueNotifications())
+		// notifications are delivered from the outbox
+		require.Empty(t, email.sent)
+	})
+
+	t.Run("error schedule", func(t *testing.T) {
+		worker, store, _ := setup(t)
+
+		store.EXPECT().ScheduleNotifications(gomock.Any(), gomock.Any()).Return(errors.New("something went wrong"))
+
+		require.Error(t, worker.ProcessDueNotifications())
+	})
+
+	t.Run("failed notification is retried and doesn't block others", func(t *testing.T) {
+		worker, store, _ := setup(t)
+
+		gomock.InOrder(
+			store.EXPECT().ScheduleNotifications(gomock.Any(), gomock.Any()).Return(nil),
+			store.EXPECT().ClaimDueNotifications(gomock.Any(), "worker-1", gomock.Any(), 5*time.Minute, 2).Return([]domain.Notification{
+				{ID: 10, Kind: domain.NotificationDeadline, Attempts: 1, Remind: domain.NotificationRemind{ID: 1, UserID: "unknown"}},
+				{ID: 11, Kind: domain.NotificationDeadline, Remind: domain.NotificationRemind{ID: 2, UserID: "owner"}},
+			}, nil),
+			store.EXPECT().MarkNotificationFailed(gomock.Any(), int64(10), "worker-1", gomock.Any(), gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, _ int64, _ string, failedAt time.Time, lastError string, retryAt *time.Time) error {
+					require.NotEmpty(t, lastError)
+					require.NotNil(t, retryAt)
+					// the second attempt has failed, so the delay is doubled
+					require.Equal(t, failedAt.Add(2*time.Minute), *retryAt)
+					return nil
+				}),
+			store.EXPECT().MarkNotificationSent(gomock.Any(), int64(11), "worker-1", gomock.Any(), gomock.Any()).DoAndReturn(outboxOf(11)),
+			store.EXPECT().ClaimDueNotifications(gomock.Any(), "worker-1", gomock.Any(), 5*time.Minute, 2).Return([]domain.Notification{}, nil),
+		)
+
+		require.NoError(t, worker.ProcessDueNotifications())
+	})
+
+	t.Run("notification is dead after the last attempt", func(t *testing.T) {
+		worker, store, _ := setup(t)
+
+		store.EXPECT().ScheduleNotifications(gomock.Any(), gomock.Any()).Return(nil)
+		store.EXPECT().ClaimDueNotifications(gomock.Any(), "worker-1", gomock.Any(), 5*time.Minute, 2).Return([]domain.Notification{
+			{ID: 10, Kind: domain.NotificationDeadline, Attempts: 2, Remind: domain.NotificationRemind{ID: 1, UserID: "unknown"}},
+		}, nil)
+		store.EXPECT().MarkNotificationFailed(gomock.Any(), int64(10), "worker-1", gomock.Any(), gomock.Any(), nil).Return(nil)
+
+		require.NoError(t, worker.ProcessDueNotifications())
+	})
+}
+
+func TestWakeup(t *testing.T) {
+	now := time.Now()
+	soon := now.Add(10 * time.Second)
+	late := now.Add(time.Hour)
+	missed := now.Add(-time.Minute)
+
+	require.Equal(t, time.Minute, Wakeup(now, nil, time.Minute))
+	require.Equal(t, 10*time.Second, Wakeup(now, &soon, time.Minute))
+	require.Equal(t, time.Minute, Wakeup(now, &late, time.Minute))
+	require.Equal(t, time.Duration(0), Wakeup(now, &missed, time.Minute))
+}
+
+func TestWorkerID(t *testing.T) {
+	cfg := config.Config{}
+	cfg.Scheduler.WorkerID = "worker-1"
+	require.Equal(t, "worker-1", workerID(cfg))
+
+	require.NotEmpty(t, workerID(config.Config{}))
+	require.NotEqual(t, "worker-1", workerID(config.Config{}))
+}